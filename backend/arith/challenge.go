@@ -1,105 +1,133 @@
 package arith
 
 import (
-	"crypto/rand"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
+	"math/bits"
 
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const NumBytesChallenge = 128 / 8
 
+// Challenge is a 128 bits integer, with arithmetic modulo 2^128. It is
+// stored in two little-endian limbs, so that arithmetic never allocates.
+// The zero value is the challenge 0.
 type Challenge struct {
-	val big.Int
+	v [2]uint64
 }
 
 func (c *Challenge) String() string {
-	return c.val.String()
+	return c.bigInt().String()
 }
 
 func FiatShamirChallenge(data ...[]byte) *Challenge {
 	hashBytes := crypto.Keccak256(data...)
 	// We employ 128 bits challenges
 	challenge := new(Challenge)
-	challenge.val.SetBytes(hashBytes[16:])
+	challenge.setBytes(hashBytes[32-NumBytesChallenge:])
 	return challenge
 }
 
+// ChallengeHasher computes the same challenges as FiatShamirChallenge, but
+// reuses its internal state across calls. The data is written with Write,
+// and the challenge is read with Challenge. A ChallengeHasher is not safe
+// for concurrent use.
+type ChallengeHasher struct {
+	h   crypto.KeccakState
+	sum [32]byte
+}
+
+func NewChallengeHasher() *ChallengeHasher {
+	return &ChallengeHasher{h: crypto.NewKeccakState()}
+}
+
+// Reset discards the data written so far.
+func (h *ChallengeHasher) Reset() {
+	h.h.Reset()
+}
+
+func (h *ChallengeHasher) Write(data []byte) {
+	h.h.Write(data)
+}
+
+// Challenge sets c to the challenge of the data written since the last
+// Reset, resets h and returns c.
+func (h *ChallengeHasher) Challenge(c *Challenge) *Challenge {
+	h.h.Read(h.sum[:])
+	h.h.Reset()
+	c.setBytes(h.sum[32-NumBytesChallenge:])
+	return c
+}
+
 func RandomChallenge(reader io.Reader) (*Challenge, error) {
-	val, err := rand.Int(reader, challengeModulus())
-	if err != nil {
+	var buf [NumBytesChallenge]byte
+	if _, err := io.ReadFull(reader, buf[:]); err != nil {
 		return nil, err
 	}
 	challenge := new(Challenge)
-	challenge.val.Set(val)
-	return challenge, err
-}
-
-func challengeModulus() *big.Int {
-	// We employ 128 bits challenges
-	return new(big.Int).Lsh(big.NewInt(1), 128)
+	challenge.setBytes(buf[:])
+	return challenge, nil
 }
 
 func (c *Challenge) Scalar() *Scalar {
-	return NewScalar(&c.val)
+	return new(Scalar).SetChallenge(c)
 }
 
 func (e *Challenge) Set(a *Challenge) *Challenge {
-	e.val.Set(&a.val)
+	e.v = a.v
 	return e
 }
 
 func (e *Challenge) Add(a, b *Challenge) *Challenge {
-	c := new(big.Int).Add(&a.val, &b.val)
-	if c.Cmp(challengeModulus()) > 0 {
-		c.Sub(c, challengeModulus())
-	}
-	e.val.Set(c)
+	var carry uint64
+	e.v[0], carry = bits.Add64(a.v[0], b.v[0], 0)
+	e.v[1], _ = bits.Add64(a.v[1], b.v[1], carry)
 	return e
 }
 
 func (e *Challenge) Sub(a, b *Challenge) *Challenge {
-	e.val.Set(new(big.Int).Sub(&a.val, &b.val))
-	if b.val.Cmp(&a.val) > 0 {
-		e.val.Add(&e.val, challengeModulus())
-	}
+	var borrow uint64
+	e.v[0], borrow = bits.Sub64(a.v[0], b.v[0], 0)
+	e.v[1], _ = bits.Sub64(a.v[1], b.v[1], borrow)
 	return e
 }
 
 func (e *Challenge) Mul(a, b *Challenge) *Challenge {
-	e.val.Set(new(big.Int).Mul(&a.val, &b.val))
-	e.val.Mod(&e.val, challengeModulus())
+	hi, lo := bits.Mul64(a.v[0], b.v[0])
+	hi += a.v[0]*b.v[1] + a.v[1]*b.v[0]
+	e.v = [2]uint64{lo, hi}
 	return e
 }
 
 func (e *Challenge) Neg(a *Challenge) *Challenge {
-	e.val.Set(new(big.Int).Sub(challengeModulus(), &a.val))
-	return e
+	return e.Sub(new(Challenge), a)
 }
 
 func (a *Challenge) Equal(b *Challenge) bool {
-	return a.val.Cmp(&b.val) == 0
+	return a.v == b.v
 }
 
 func (e Challenge) MarshalBinary() ([]byte, error) {
 	buf := make([]byte, NumBytesChallenge)
-	e.val.FillBytes(buf)
+	binary.BigEndian.PutUint64(buf[:8], e.v[1])
+	binary.BigEndian.PutUint64(buf[8:], e.v[0])
 	return buf, nil
 }
 
 func (e *Challenge) UnmarshalBinary(m []byte) error {
 	if len(m) != NumBytesChallenge {
-		return fmt.Errorf("challenge should be represented with %d bytes", NumBytesScalar)
+		return fmt.Errorf("challenge should be represented with %d bytes", NumBytesChallenge)
 	}
-	e.val.Set(new(big.Int).SetBytes(m))
+	e.setBytes(m)
 	return nil
 }
 
 func (e Challenge) MarshalJSON() ([]byte, error) {
-	return e.val.MarshalJSON()
+	return e.bigInt().MarshalJSON()
 }
 
 func (e *Challenge) UnmarshalJSON(data []byte) error {
@@ -116,3 +144,15 @@ func (e *Challenge) UnmarshalJSON(data []byte) error {
 
 	return e.UnmarshalBinary(buf)
 }
+
+// setBytes sets e to the big-endian integer m, which should be
+// NumBytesChallenge bytes long.
+func (e *Challenge) setBytes(m []byte) {
+	e.v[1] = binary.BigEndian.Uint64(m[:8])
+	e.v[0] = binary.BigEndian.Uint64(m[8:])
+}
+
+func (e *Challenge) bigInt() *big.Int {
+	buf, _ := e.MarshalBinary()
+	return new(big.Int).SetBytes(buf)
+}
@@ -3,7 +3,10 @@ package arith
 import (
 	"crypto/rand"
 	"encoding/json"
+	"math/big"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 func TestUnmarshalInvalidChallenge(t *testing.T) {
@@ -62,3 +65,83 @@ func TestMarshalUnmarshalJSONChallenge(t *testing.T) {
 		t.Fatalf("want: %s, got: %s", want, got)
 	}
 }
+
+func TestChallengeArithmetic(t *testing.T) {
+	modulus := new(big.Int).Lsh(big.NewInt(1), 128)
+	max := new(big.Int).Sub(modulus, big.NewInt(1))
+	mod := func(x *big.Int) *big.Int { return new(big.Int).Mod(x, modulus) }
+	values := []*big.Int{big.NewInt(0), big.NewInt(1), max, new(big.Int).Lsh(big.NewInt(1), 64)}
+	for i := 0; i < 5; i++ {
+		c, err := RandomChallenge(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, c.bigInt())
+	}
+
+	for _, x := range values {
+		for _, y := range values {
+			a, b := newTestChallenge(t, x), newTestChallenge(t, y)
+			results := map[string]struct {
+				got      *Challenge
+				expected *big.Int
+			}{
+				"add": {new(Challenge).Add(a, b), mod(new(big.Int).Add(x, y))},
+				"sub": {new(Challenge).Sub(a, b), mod(new(big.Int).Sub(x, y))},
+				"mul": {new(Challenge).Mul(a, b), mod(new(big.Int).Mul(x, y))},
+				"neg": {new(Challenge).Neg(a), mod(new(big.Int).Neg(x))},
+			}
+			for op, r := range results {
+				if r.got.String() != r.expected.String() {
+					t.Fatalf("%s(%s, %s): expected %s, got %s", op, x, y, r.expected, r.got)
+				}
+			}
+			if !a.Scalar().Equal(NewScalar(x)) {
+				t.Fatalf("Scalar(): expected %s, got %s", x, a.Scalar())
+			}
+		}
+	}
+}
+
+func TestFiatShamirChallenge(t *testing.T) {
+	data := []byte("e-voting")
+	// the challenge is made of the low 128 bits of the hash
+	expected := new(big.Int).SetBytes(crypto.Keccak256(data)[16:])
+	if got := FiatShamirChallenge(data); got.String() != expected.String() {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func newTestChallenge(t *testing.T, x *big.Int) *Challenge {
+	t.Helper()
+	c := new(Challenge)
+	if err := json.Unmarshal([]byte(x.String()), c); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func BenchmarkChallengeSub(b *testing.B) {
+	x, err := RandomChallenge(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	y, err := RandomChallenge(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Sub(x, y)
+	}
+}
+
+func BenchmarkFiatShamirChallenge(b *testing.B) {
+	data := make([]byte, 4*NumBytesCurvePoint)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FiatShamirChallenge(data)
+	}
+}
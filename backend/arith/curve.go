@@ -48,23 +48,55 @@ func (e *CurvePoint) Set(a *CurvePoint) *CurvePoint {
 	return e
 }
 
+// IsValid reports whether a has been assigned a value. Operations on the
+// zero CurvePoint panic, so points which may not have been set, e.g. fields
+// of a struct built by hand, should be checked with IsValid.
+func (a *CurvePoint) IsValid() bool {
+	return a.p != (bn256.G1{})
+}
+
+// The operations below write to the receiver in place, which saves an
+// allocation when the receiver is reused.
+
 func (e *CurvePoint) ScalarBaseMult(k *Scalar) *CurvePoint {
-	e.p.Set(new(bn256.G1).ScalarBaseMult(&k.val))
+	e.p.ScalarBaseMult(k.m.bigInt())
 	return e
 }
 
 func (e *CurvePoint) ScalarMult(a *CurvePoint, k *Scalar) *CurvePoint {
-	e.p.Set(new(bn256.G1).ScalarMult(&a.p, &k.val))
+	e.p.ScalarMult(&a.p, k.m.bigInt())
+	return e
+}
+
+// ScalarBaseMultSecret sets e to k*g, where g is the generator of
+// bn256.G1, and returns e.
+func (e *CurvePoint) ScalarBaseMultSecret(k *SecretScalar) *CurvePoint {
+	b := k.m.bigInt()
+	defer wipeBig(b)
+	e.p.ScalarBaseMult(b)
+	return e
+}
+
+// ScalarMultSecret sets e to k*a and returns e.
+func (e *CurvePoint) ScalarMultSecret(a *CurvePoint, k *SecretScalar) *CurvePoint {
+	b := k.m.bigInt()
+	defer wipeBig(b)
+	e.p.ScalarMult(&a.p, b)
 	return e
 }
 
 func (e *CurvePoint) Add(a, b *CurvePoint) *CurvePoint {
-	e.p.Set(new(bn256.G1).Add(&a.p, &b.p))
+	if e == a || e == b {
+		// bn256 addition does not support aliasing
+		e.p.Set(new(bn256.G1).Add(&a.p, &b.p))
+		return e
+	}
+	e.p.Add(&a.p, &b.p)
 	return e
 }
 
 func (e *CurvePoint) Neg(a *CurvePoint) *CurvePoint {
-	e.p.Set(new(bn256.G1).Neg(&a.p))
+	e.p.Neg(&a.p)
 	return e
 }
 
@@ -102,6 +134,9 @@ func (e *CurvePoint) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
+	if point.X == nil || point.Y == nil {
+		return fmt.Errorf("curve point should have both x and y coordinates")
+	}
 	if len(point.X.Bytes()) > NumBytesCurvePoint/2 {
 		return fmt.Errorf("curve point x-coordinate is too big")
 	}
@@ -0,0 +1,252 @@
+package arith
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// Scalars modulo the order of bn256.G1 are represented by 4 little-endian
+// 64 bits limbs in Montgomery form, i.e. x is stored as x*R mod order with
+// R = 2^256. All the functions of this file run in constant time, so that
+// they can be shared by Scalar and SecretScalar.
+
+const numLimbs = 4
+
+// NumBytesWide is the number of random bytes which are reduced modulo the
+// order of bn256.G1 in order to obtain a uniformly distributed scalar.
+const NumBytesWide = 2 * NumBytesScalar
+
+type limbs [numLimbs]uint64
+
+var (
+	// order is the order of bn256.G1, in little-endian limbs
+	order limbs
+	// orderInv is -order^-1 mod 2^64
+	orderInv uint64
+	// rSquared and rCubed are R^2 and R^3 mod order, where R = 2^256
+	rSquared limbs
+	rCubed   limbs
+	one      = limbs{1}
+)
+
+func init() {
+	order = limbsFromBig(bn256.Order)
+
+	m := new(big.Int).Lsh(big.NewInt(1), 64)
+	inv := new(big.Int).ModInverse(new(big.Int).SetUint64(order[0]), m)
+	orderInv = new(big.Int).Sub(m, inv).Uint64()
+
+	r := new(big.Int).Lsh(big.NewInt(1), 64*numLimbs)
+	r.Mod(r, bn256.Order)
+	rSquared = limbsFromBig(new(big.Int).Mod(new(big.Int).Mul(r, r), bn256.Order))
+	rCubed = limbsFromBig(new(big.Int).Mod(new(big.Int).Mul(new(big.Int).Mul(r, r), r), bn256.Order))
+}
+
+func limbsFromBig(b *big.Int) limbs {
+	var buf [NumBytesScalar]byte
+	b.FillBytes(buf[:])
+	return limbsFromBytes(&buf)
+}
+
+func limbsFromBytes(buf *[NumBytesScalar]byte) limbs {
+	var l limbs
+	for i := range l {
+		l[i] = binary.BigEndian.Uint64(buf[NumBytesScalar-8*(i+1):])
+	}
+	return l
+}
+
+func (l *limbs) fillBytes(buf *[NumBytesScalar]byte) {
+	for i := range l {
+		binary.BigEndian.PutUint64(buf[NumBytesScalar-8*(i+1):], l[i])
+	}
+}
+
+// setBytes sets l to the Montgomery form of the big-endian integer buf. It
+// reports whether buf is below order, and leaves l untouched otherwise.
+func (l *limbs) setBytes(buf *[NumBytesScalar]byte) bool {
+	x := limbsFromBytes(buf)
+	var borrow uint64
+	for i := range x {
+		_, borrow = bits.Sub64(x[i], order[i], borrow)
+	}
+	if borrow == 0 {
+		wipeLimbs(&x)
+		return false
+	}
+	montMul(l, &x, &rSquared)
+	wipeLimbs(&x)
+	return true
+}
+
+// setWideBytes sets l to the Montgomery form of the big-endian integer b
+// reduced modulo order.
+func (l *limbs) setWideBytes(b *[NumBytesWide]byte) {
+	var buf [NumBytesScalar]byte
+	copy(buf[:], b[:NumBytesScalar])
+	h := limbsFromBytes(&buf)
+	copy(buf[:], b[NumBytesScalar:])
+	x := limbsFromBytes(&buf)
+	// b = h*2^256 + x, so its Montgomery form is h*R^2 + x*R
+	montMul(&h, &h, &rCubed)
+	montMul(&x, &x, &rSquared)
+	addMod(l, &h, &x)
+	wipeBytes(buf[:])
+	wipeLimbs(&h)
+	wipeLimbs(&x)
+}
+
+// setUint64 sets l to the Montgomery form of x.
+func (l *limbs) setUint64(x uint64) {
+	v := limbs{x}
+	montMul(l, &v, &rSquared)
+}
+
+// fillCanonicalBytes writes the big-endian representation of the value of
+// l, which is in Montgomery form, to buf.
+func (l *limbs) fillCanonicalBytes(buf *[NumBytesScalar]byte) {
+	var x limbs
+	montMul(&x, l, &one)
+	x.fillBytes(buf)
+	wipeLimbs(&x)
+}
+
+// equal reports whether x and y are equal.
+func (x *limbs) equal(y *limbs) bool {
+	var acc uint64
+	for i := range x {
+		acc |= x[i] ^ y[i]
+	}
+	return isZero(acc) == 1
+}
+
+// isZero reports whether x is zero.
+func (x *limbs) isZero() bool {
+	var acc uint64
+	for i := range x {
+		acc |= x[i]
+	}
+	return isZero(acc) == 1
+}
+
+// bigInt returns the value of l, which is in Montgomery form, as a big.Int.
+// If l is secret, the result should be wiped with wipeBig after use.
+func (l *limbs) bigInt() *big.Int {
+	var buf [NumBytesScalar]byte
+	l.fillCanonicalBytes(&buf)
+	b := new(big.Int).SetBytes(buf[:])
+	wipeBytes(buf[:])
+	return b
+}
+
+// montMul sets z to x*y/R mod order, with R = 2^256, using the CIOS
+// method. x and y should be below R, and at least one of them below order.
+func montMul(z, x, y *limbs) {
+	var t [numLimbs + 2]uint64
+	for i := 0; i < numLimbs; i++ {
+		// t += x*y[i]
+		var c uint64
+		for j := 0; j < numLimbs; j++ {
+			hi, lo := bits.Mul64(x[j], y[i])
+			var carry uint64
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j], c = lo, hi
+		}
+		var carry uint64
+		t[numLimbs], carry = bits.Add64(t[numLimbs], c, 0)
+		t[numLimbs+1] = carry
+
+		// t = (t + m*order) / 2^64, where m is chosen so that the division
+		// is exact
+		m := t[0] * orderInv
+		hi, lo := bits.Mul64(m, order[0])
+		_, carry = bits.Add64(lo, t[0], 0)
+		c = hi + carry
+		for j := 1; j < numLimbs; j++ {
+			hi, lo := bits.Mul64(m, order[j])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j-1], c = lo, hi
+		}
+		t[numLimbs-1], carry = bits.Add64(t[numLimbs], c, 0)
+		t[numLimbs] = t[numLimbs+1] + carry
+	}
+	var res limbs
+	copy(res[:], t[:numLimbs])
+	reduceOnce(&res, t[numLimbs])
+	*z = res
+	for i := range t {
+		t[i] = 0
+	}
+	wipeLimbs(&res)
+}
+
+// reduceOnce subtracts order from hi*2^256 + z if it is not below order.
+// The value should be below 2*order.
+func reduceOnce(z *limbs, hi uint64) {
+	var d limbs
+	var borrow uint64
+	for i := range z {
+		d[i], borrow = bits.Sub64(z[i], order[i], borrow)
+	}
+	_, borrow = bits.Sub64(hi, 0, borrow)
+	// borrow is 1 if the value is below order, in which case z is kept
+	mask := -borrow
+	for i := range z {
+		z[i] = (z[i] & mask) | (d[i] &^ mask)
+	}
+}
+
+func addMod(z, x, y *limbs) {
+	var carry uint64
+	for i := range z {
+		z[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+	reduceOnce(z, carry)
+}
+
+func subMod(z, x, y *limbs) {
+	var borrow uint64
+	for i := range z {
+		z[i], borrow = bits.Sub64(x[i], y[i], borrow)
+	}
+	// add order back if the subtraction underflowed
+	mask := -borrow
+	var carry uint64
+	for i := range z {
+		z[i], carry = bits.Add64(z[i], order[i]&mask, carry)
+	}
+}
+
+// isZero returns 1 if x is zero and 0 otherwise, in constant time.
+func isZero(x uint64) uint64 {
+	return 1 ^ ((x | -x) >> 63)
+}
+
+func wipeLimbs(l *limbs) {
+	for i := range l {
+		l[i] = 0
+	}
+}
+
+func wipeBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+func wipeBig(b *big.Int) {
+	words := b.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	b.SetInt64(0)
+}
@@ -11,77 +11,106 @@ import (
 
 const NumBytesScalar = 256 / 8
 
+// Scalar is an integer modulo the order of bn256.G1. It is stored in fixed
+// size limbs in Montgomery form, so that arithmetic never allocates.
+// The zero value is the scalar 0.
 type Scalar struct {
-	val big.Int
+	m limbs
 }
 
 func (e *Scalar) String() string {
-	return e.val.String()
+	return e.m.bigInt().String()
 }
 
 func NewScalar(val *big.Int) *Scalar {
-	scalar := new(Scalar)
-	if val.CmpAbs(bn256.Order) < 0 {
-		if val.Sign() >= 0 {
-			scalar.val.Set(val)
-		} else {
-			scalar.val.Set(new(big.Int).Add(val, bn256.Order))
-		}
-	} else {
-		scalar.val.Set(new(big.Int).Mod(val, bn256.Order))
+	if val.Sign() < 0 || val.Cmp(bn256.Order) >= 0 {
+		val = new(big.Int).Mod(val, bn256.Order)
 	}
+	var buf [NumBytesScalar]byte
+	val.FillBytes(buf[:])
+	scalar := new(Scalar)
+	scalar.m.setBytes(&buf)
 	return scalar
 }
 
+// SetUint64 sets e to x and returns e.
+func (e *Scalar) SetUint64(x uint64) *Scalar {
+	e.m.setUint64(x)
+	return e
+}
+
+// SetInt64 sets e to x modulo the order of bn256.G1 and returns e.
+func (e *Scalar) SetInt64(x int64) *Scalar {
+	if x >= 0 {
+		return e.SetUint64(uint64(x))
+	}
+	e.SetUint64(uint64(-(x + 1)) + 1)
+	return e.Neg(e)
+}
+
+// SetChallenge sets e to c and returns e.
+func (e *Scalar) SetChallenge(c *Challenge) *Scalar {
+	v := limbs{c.v[0], c.v[1]}
+	montMul(&e.m, &v, &rSquared)
+	return e
+}
+
 func (e *Scalar) Set(a *Scalar) *Scalar {
-	e.val.Set(&a.val)
+	e.m = a.m
 	return e
 }
 
 func (e *Scalar) Add(a, b *Scalar) *Scalar {
-	c := new(big.Int).Add(&a.val, &b.val)
-	if c.Cmp(bn256.Order) > 0 {
-		c.Sub(c, bn256.Order)
-	}
-	e.val.Set(c)
+	addMod(&e.m, &a.m, &b.m)
+	return e
+}
+
+// Sub sets e to a - b and returns e.
+func (e *Scalar) Sub(a, b *Scalar) *Scalar {
+	subMod(&e.m, &a.m, &b.m)
 	return e
 }
 
 func (e *Scalar) Mul(a, b *Scalar) *Scalar {
-	e.val.Mul(&a.val, &b.val)
-	e.val.Mod(&e.val, bn256.Order)
+	montMul(&e.m, &a.m, &b.m)
 	return e
 }
 
 func (e *Scalar) Neg(a *Scalar) *Scalar {
-	e.val.Set(new(big.Int).Sub(bn256.Order, &a.val))
+	var zero limbs
+	subMod(&e.m, &zero, &a.m)
 	return e
 }
 
 func (a *Scalar) Equal(b *Scalar) bool {
-	return a.val.Cmp(&b.val) == 0
+	return a.m.equal(&b.m)
+}
+
+// IsZero reports whether e is zero.
+func (e *Scalar) IsZero() bool {
+	return e.m.isZero()
 }
 
 func (e Scalar) MarshalBinary() ([]byte, error) {
-	buf := make([]byte, NumBytesScalar)
-	e.val.FillBytes(buf)
-	return buf, nil
+	var buf [NumBytesScalar]byte
+	e.m.fillCanonicalBytes(&buf)
+	return buf[:], nil
 }
 
 func (e *Scalar) UnmarshalBinary(m []byte) error {
 	if len(m) != NumBytesScalar {
 		return fmt.Errorf("scalar should be represented with %d bytes", NumBytesScalar)
 	}
-	ret := new(big.Int).SetBytes(m)
-	if ret.CmpAbs(bn256.Order) >= 0 {
+	var buf [NumBytesScalar]byte
+	copy(buf[:], m)
+	if !e.m.setBytes(&buf) {
 		return errors.New("scalar is over the field modulus")
 	}
-	e.val.Set(ret)
 	return nil
 }
 
 func (e Scalar) MarshalJSON() ([]byte, error) {
-	return e.val.MarshalJSON()
+	return e.m.bigInt().MarshalJSON()
 }
 
 func (e *Scalar) UnmarshalJSON(data []byte) error {
@@ -1,6 +1,7 @@
 package arith
 
 import (
+	"crypto/rand"
 	"encoding/json"
 	"math/big"
 	"testing"
@@ -128,3 +129,76 @@ func TestMarshalledScalarSize(t *testing.T) {
 		})
 	}
 }
+
+func TestScalarArithmetic(t *testing.T) {
+	orderMinusOne := new(big.Int).Sub(bn256.Order, big.NewInt(1))
+	tests := map[string]struct {
+		a, b *big.Int
+	}{
+		"zero":              {a: big.NewInt(0), b: big.NewInt(0)},
+		"sum is order":      {a: orderMinusOne, b: big.NewInt(1)},
+		"both order - 1":    {a: orderMinusOne, b: orderMinusOne},
+		"negative operands": {a: big.NewInt(-5), b: big.NewInt(-7)},
+		"large":             {a: new(big.Int).Rsh(bn256.Order, 1), b: big.NewInt(3)},
+	}
+	mod := func(x *big.Int) *big.Int { return new(big.Int).Mod(x, bn256.Order) }
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, b := NewScalar(tc.a), NewScalar(tc.b)
+			results := map[string]struct {
+				got      *Scalar
+				expected *big.Int
+			}{
+				"add": {new(Scalar).Add(a, b), mod(new(big.Int).Add(tc.a, tc.b))},
+				"sub": {new(Scalar).Sub(a, b), mod(new(big.Int).Sub(tc.a, tc.b))},
+				"mul": {new(Scalar).Mul(a, b), mod(new(big.Int).Mul(tc.a, tc.b))},
+				"neg": {new(Scalar).Neg(a), mod(new(big.Int).Neg(tc.a))},
+			}
+			for op, r := range results {
+				if r.got.String() != r.expected.String() {
+					t.Fatalf("%s: expected %s, got %s", op, r.expected, r.got)
+				}
+			}
+		})
+	}
+}
+
+func TestScalarSetInt64(t *testing.T) {
+	for _, x := range []int64{0, 1, -1, 1 << 62, -(1 << 63), 1<<63 - 1} {
+		got := new(Scalar).SetInt64(x)
+		if expected := NewScalar(big.NewInt(x)); !got.Equal(expected) {
+			t.Fatalf("expected %s, got %s", expected, got)
+		}
+	}
+}
+
+func BenchmarkScalarAdd(b *testing.B) {
+	x, y := benchmarkScalars(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Add(x, y)
+	}
+}
+
+func BenchmarkScalarMul(b *testing.B) {
+	x, y := benchmarkScalars(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Mul(x, y)
+	}
+}
+
+func benchmarkScalars(b *testing.B) (*Scalar, *Scalar) {
+	x, _, err := RandomCurvePoint(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	y, _, err := RandomCurvePoint(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return x, y
+}
@@ -0,0 +1,185 @@
+package arith
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// SecretScalar is a scalar modulo the order of bn256.G1 meant to hold
+// secrets, such as secret keys, encryption randomness and proof nonces.
+//
+// A SecretScalar has the same fixed size representation as Scalar, and its
+// arithmetic runs in constant time: there are no branches nor memory
+// accesses depending on its value. Unlike Scalar, it never prints its
+// value, and Destroy reliably wipes it from memory.
+//
+// Conversions to big.Int, which are needed by the curve arithmetic of
+// bn256 and by the JSON encoding, use temporary values which are wiped
+// right after use. Note that bn256 scalar multiplication itself is not
+// constant time.
+//
+// The zero value is the scalar 0.
+type SecretScalar struct {
+	m limbs
+}
+
+// String does not reveal the value of e, so that secrets do not end up in
+// logs by mistake. Use Declassify to print the value.
+func (e *SecretScalar) String() string {
+	return "SecretScalar(redacted)"
+}
+
+// RandomSecretScalar returns a uniformly distributed non-zero secret
+// scalar, obtained by reducing NumBytesWide bytes read from r.
+func RandomSecretScalar(r io.Reader) (*SecretScalar, error) {
+	var buf [NumBytesWide]byte
+	defer wipeBytes(buf[:])
+	e := new(SecretScalar)
+	for {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return nil, err
+		}
+		e.SetWideBytes(&buf)
+		if !e.IsZero() {
+			return e, nil
+		}
+	}
+}
+
+// NewSecretScalar returns the public scalar a as a SecretScalar.
+func NewSecretScalar(a *Scalar) *SecretScalar {
+	return new(SecretScalar).SetScalar(a)
+}
+
+// SetScalar sets e to the public scalar a and returns e.
+func (e *SecretScalar) SetScalar(a *Scalar) *SecretScalar {
+	e.m = a.m
+	return e
+}
+
+// Declassify returns the value of e as a public Scalar. It should only be
+// called on values which are meant to be published, like the responses of
+// a proof.
+func (e *SecretScalar) Declassify() *Scalar {
+	return &Scalar{m: e.m}
+}
+
+// SetWideBytes sets e to the big-endian integer b reduced modulo the order
+// of bn256.G1, and returns e. Since b is twice as long as a scalar, the
+// reduction of uniformly random bytes has a negligible bias.
+func (e *SecretScalar) SetWideBytes(b *[NumBytesWide]byte) *SecretScalar {
+	e.m.setWideBytes(b)
+	return e
+}
+
+// Set sets e to a and returns e.
+func (e *SecretScalar) Set(a *SecretScalar) *SecretScalar {
+	e.m = a.m
+	return e
+}
+
+// Add sets e to a + b and returns e.
+func (e *SecretScalar) Add(a, b *SecretScalar) *SecretScalar {
+	addMod(&e.m, &a.m, &b.m)
+	return e
+}
+
+// Sub sets e to a - b and returns e.
+func (e *SecretScalar) Sub(a, b *SecretScalar) *SecretScalar {
+	subMod(&e.m, &a.m, &b.m)
+	return e
+}
+
+// Neg sets e to -a and returns e.
+func (e *SecretScalar) Neg(a *SecretScalar) *SecretScalar {
+	var zero limbs
+	subMod(&e.m, &zero, &a.m)
+	return e
+}
+
+// Mul sets e to a * b and returns e.
+func (e *SecretScalar) Mul(a, b *SecretScalar) *SecretScalar {
+	montMul(&e.m, &a.m, &b.m)
+	return e
+}
+
+// Equal reports whether a and b are equal, in constant time.
+func (a *SecretScalar) Equal(b *SecretScalar) bool {
+	return a.m.equal(&b.m)
+}
+
+// IsZero reports whether e is zero, in constant time.
+func (e *SecretScalar) IsZero() bool {
+	return e.m.isZero()
+}
+
+// Destroy overwrites e with zero. A destroyed SecretScalar is the scalar 0.
+func (e *SecretScalar) Destroy() {
+	wipeLimbs(&e.m)
+}
+
+// FillBytes writes the 32 bytes big-endian representation of e to buf.
+func (e *SecretScalar) FillBytes(buf *[NumBytesScalar]byte) {
+	e.m.fillCanonicalBytes(buf)
+}
+
+// SetBytes sets e to the big-endian integer buf and returns e. It fails if
+// the value is not below the order of bn256.G1. The check leaks nothing
+// but its outcome.
+func (e *SecretScalar) SetBytes(buf *[NumBytesScalar]byte) (*SecretScalar, error) {
+	if !e.m.setBytes(buf) {
+		return nil, errors.New("scalar is over the field modulus")
+	}
+	return e, nil
+}
+
+func (e SecretScalar) MarshalBinary() ([]byte, error) {
+	var buf [NumBytesScalar]byte
+	e.FillBytes(&buf)
+	res := make([]byte, NumBytesScalar)
+	copy(res, buf[:])
+	wipeBytes(buf[:])
+	// e is a copy of the receiver
+	e.Destroy()
+	return res, nil
+}
+
+func (e *SecretScalar) UnmarshalBinary(m []byte) error {
+	if len(m) != NumBytesScalar {
+		return fmt.Errorf("scalar should be represented with %d bytes", NumBytesScalar)
+	}
+	var buf [NumBytesScalar]byte
+	defer wipeBytes(buf[:])
+	copy(buf[:], m)
+	_, err := e.SetBytes(&buf)
+	return err
+}
+
+// MarshalJSON encodes e like a Scalar, i.e. as a decimal number.
+func (e SecretScalar) MarshalJSON() ([]byte, error) {
+	b := e.m.bigInt()
+	defer wipeBig(b)
+	// e is a copy of the receiver
+	e.Destroy()
+	return b.MarshalJSON()
+}
+
+func (e *SecretScalar) UnmarshalJSON(data []byte) error {
+	var s = new(big.Int)
+	defer wipeBig(s)
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return err
+	}
+	if s.Sign() < 0 || s.BitLen() > 8*NumBytesScalar {
+		return fmt.Errorf("scalar is too big")
+	}
+	var buf [NumBytesScalar]byte
+	defer wipeBytes(buf[:])
+	s.FillBytes(buf[:])
+	_, err = e.SetBytes(&buf)
+	return err
+}
@@ -0,0 +1,177 @@
+package arith
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+func TestSecretScalarArithmetic(t *testing.T) {
+	orderMinusOne := new(big.Int).Sub(bn256.Order, big.NewInt(1))
+	values := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), orderMinusOne}
+	for i := 0; i < 20; i++ {
+		v, err := rand.Int(rand.Reader, bn256.Order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, v)
+	}
+
+	ops := map[string]struct {
+		secret func(a, b *SecretScalar) *SecretScalar
+		public func(a, b *big.Int) *big.Int
+	}{
+		"add": {
+			secret: func(a, b *SecretScalar) *SecretScalar { return new(SecretScalar).Add(a, b) },
+			public: func(a, b *big.Int) *big.Int { return new(big.Int).Add(a, b) },
+		},
+		"sub": {
+			secret: func(a, b *SecretScalar) *SecretScalar { return new(SecretScalar).Sub(a, b) },
+			public: func(a, b *big.Int) *big.Int { return new(big.Int).Sub(a, b) },
+		},
+		"neg": {
+			secret: func(a, b *SecretScalar) *SecretScalar { return new(SecretScalar).Neg(a) },
+			public: func(a, b *big.Int) *big.Int { return new(big.Int).Neg(a) },
+		},
+		"mul": {
+			secret: func(a, b *SecretScalar) *SecretScalar { return new(SecretScalar).Mul(a, b) },
+			public: func(a, b *big.Int) *big.Int { return new(big.Int).Mul(a, b) },
+		},
+	}
+
+	for name, op := range ops {
+		t.Run(name, func(t *testing.T) {
+			for _, a := range values {
+				for _, b := range values {
+					got := op.secret(NewSecretScalar(NewScalar(a)), NewSecretScalar(NewScalar(b)))
+					expected := NewScalar(op.public(a, b))
+					if !got.Declassify().Equal(expected) {
+						t.Fatalf("%s(%s, %s): expected %s, got %s", name, a, b, expected, got.Declassify())
+					}
+					if !got.Equal(NewSecretScalar(expected)) {
+						t.Fatalf("%s(%s, %s): Equal() does not match", name, a, b)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSecretScalarSetWideBytes(t *testing.T) {
+	tests := map[string][]byte{
+		"zero":    make([]byte, NumBytesWide),
+		"order":   new(big.Int).Mul(bn256.Order, big.NewInt(3)).Bytes(),
+		"max":     bytes.Repeat([]byte{0xff}, NumBytesWide),
+		"low one": append(make([]byte, NumBytesWide-1), 1),
+	}
+	random := make([]byte, NumBytesWide)
+	if _, err := rand.Read(random); err != nil {
+		t.Fatal(err)
+	}
+	tests["random"] = random
+
+	for name, b := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf [NumBytesWide]byte
+			copy(buf[NumBytesWide-len(b):], b)
+			got := new(SecretScalar).SetWideBytes(&buf)
+			expected := NewScalar(new(big.Int).SetBytes(b))
+			if !got.Declassify().Equal(expected) {
+				t.Fatalf("expected %s, got %s", expected, got.Declassify())
+			}
+		})
+	}
+}
+
+func TestSecretScalarScalarMult(t *testing.T) {
+	k, err := RandomSecretScalar(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, p, err := RandomCurvePoint(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !new(CurvePoint).ScalarBaseMultSecret(k).Equal(new(CurvePoint).ScalarBaseMult(k.Declassify())) {
+		t.Fatal("ScalarBaseMultSecret() does not match ScalarBaseMult()")
+	}
+	if !new(CurvePoint).ScalarMultSecret(p, k).Equal(new(CurvePoint).ScalarMult(p, k.Declassify())) {
+		t.Fatal("ScalarMultSecret() does not match ScalarMult()")
+	}
+}
+
+func TestMarshalUnmarshalSecretScalar(t *testing.T) {
+	k, err := RandomSecretScalar(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the encodings are the ones of Scalar
+	kJSON, err := json.Marshal(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scalarJSON, _ := json.Marshal(k.Declassify())
+	if !bytes.Equal(kJSON, scalarJSON) {
+		t.Fatalf("expected JSON %s, got %s", scalarJSON, kJSON)
+	}
+	fromJSON := new(SecretScalar)
+	if err := json.Unmarshal(kJSON, fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if !fromJSON.Equal(k) {
+		t.Fatal("JSON encoding does not round trip")
+	}
+
+	kBinary, _ := k.MarshalBinary()
+	scalarBinary, _ := k.Declassify().MarshalBinary()
+	if !bytes.Equal(kBinary, scalarBinary) {
+		t.Fatalf("expected binary %x, got %x", scalarBinary, kBinary)
+	}
+	fromBinary := new(SecretScalar)
+	if err := fromBinary.UnmarshalBinary(kBinary); err != nil {
+		t.Fatal(err)
+	}
+	if !fromBinary.Equal(k) {
+		t.Fatal("binary encoding does not round trip")
+	}
+}
+
+func TestUnmarshalInvalidSecretScalar(t *testing.T) {
+	tests := map[string][]byte{
+		"too short":    make([]byte, NumBytesScalar-1),
+		"too long":     make([]byte, NumBytesScalar+1),
+		"over modulus": bn256.Order.Bytes(),
+	}
+	for name, m := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := new(SecretScalar).UnmarshalBinary(m); err == nil {
+				t.Fatalf("should be impossible to unmarshal a secret scalar %s", name)
+			}
+		})
+	}
+	if err := json.Unmarshal([]byte("-1"), new(SecretScalar)); err == nil {
+		t.Fatal("should be impossible to unmarshal a negative secret scalar")
+	}
+}
+
+func TestSecretScalarDestroy(t *testing.T) {
+	k, err := RandomSecretScalar(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.IsZero() {
+		t.Fatal("random secret scalar is zero")
+	}
+	k.Destroy()
+	if !k.IsZero() || k.m != (limbs{}) {
+		t.Fatal("secret scalar has not been wiped")
+	}
+	if k.String() == k.Declassify().String() {
+		t.Fatal("String() reveals the value of a secret scalar")
+	}
+}
@@ -0,0 +1,277 @@
+// Package bulletin implements an append-only bulletin board for encrypted
+// ballots, which can be used to collect votes off-chain (or on a L2) while
+// retaining the auditability of the on-chain protocol.
+//
+// Every ballot is checked with crypto.VerifyVoteWellFormedness before being
+// appended to a Merkle log (see MerkleLog), and the board keeps a running
+// homomorphic tally of the accepted ballots. The board commits to its state
+// by signing tree heads on request, which bind together the size of the
+// log, its root hash and the encrypted tally. Inclusion and consistency
+// proofs allow anyone to check that a ballot has been counted, and that the
+// board never rewrote its history.
+package bulletin
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	// ErrDuplicateBallot is returned when appending a ballot which is already on the board.
+	ErrDuplicateBallot = errors.New("ballot already on the board")
+	// ErrIndexOutOfRange is returned when requesting a ballot or a proof beyond the size of the log.
+	ErrIndexOutOfRange = errors.New("index out of range")
+)
+
+// InvalidBallotError is returned when appending a ballot whose proof of
+// well-formedness does not verify.
+type InvalidBallotError struct {
+	Err error
+}
+
+func (e *InvalidBallotError) Error() string {
+	return fmt.Sprintf("invalid ballot: %v", e.Err)
+}
+
+func (e *InvalidBallotError) Unwrap() error {
+	return e.Err
+}
+
+// treeHeadDomain separates tree head signatures from any other ed25519
+// signature produced with the same key.
+const treeHeadDomain = "e-voting bulletin board tree head v1"
+
+// Ballot is an encrypted vote, together with its proof of well-formedness.
+type Ballot struct {
+	EncryptedVote crypto.EncryptedVote           `json:"encryptedVote"`
+	Proof         crypto.ProofVoteWellFormedness `json:"proof"`
+}
+
+// Set sets the receiver to a and returns it.
+func (b *Ballot) Set(a *Ballot) *Ballot {
+	b.EncryptedVote.Set(&a.EncryptedVote)
+	b.Proof.Set(&a.Proof)
+	return b
+}
+
+// MarshalBinary returns the encoding of the ballot which is stored in the
+// leaves of the Merkle log, i.e. the concatenation of a, b, r0, r1, c0, c1.
+func (b *Ballot) MarshalBinary() ([]byte, error) {
+	var data []byte
+	for _, m := range []interface{ MarshalBinary() ([]byte, error) }{
+		&b.EncryptedVote.A,
+		&b.EncryptedVote.B,
+		&b.Proof.R0,
+		&b.Proof.R1,
+		&b.Proof.C0,
+		&b.Proof.C1,
+	} {
+		bytesM, err := m.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, bytesM...)
+	}
+	return data, nil
+}
+
+// TreeHead is a commitment to the content of the board at a given size.
+type TreeHead struct {
+	Size      uint64               `json:"size"`
+	Root      hexutil.Bytes        `json:"root"`
+	Tally     crypto.EncryptedVote `json:"tally"`
+	Timestamp int64                `json:"timestamp"` // milliseconds since the Unix epoch
+}
+
+// SignedTreeHead is a TreeHead signed by the board.
+type SignedTreeHead struct {
+	TreeHead
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+func (h *TreeHead) signingPayload() ([]byte, error) {
+	bytesA, err := h.Tally.A.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	bytesB, err := h.Tally.B.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	payload := []byte(treeHeadDomain)
+	payload = binary.BigEndian.AppendUint64(payload, h.Size)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(h.Timestamp))
+	payload = append(payload, h.Root...)
+	payload = append(payload, bytesA...)
+	payload = append(payload, bytesB...)
+	return payload, nil
+}
+
+// VerifyTreeHead verifies the signature of a signed tree head.
+func VerifyTreeHead(sth *SignedTreeHead, key ed25519.PublicKey) error {
+	payload, err := sth.signingPayload()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(key, payload, sth.Signature) {
+		return errors.New("tree head signature verification failed")
+	}
+	return nil
+}
+
+// Board is an append-only bulletin board of encrypted ballots.
+// It is safe for concurrent use.
+type Board struct {
+	mu      sync.RWMutex
+	pk      arith.CurvePoint
+	signer  ed25519.PrivateKey
+	log     MerkleLog
+	ballots []Ballot
+	seen    map[string]bool
+	// tallies[i] is the encrypted tally of the first i ballots.
+	tallies []crypto.EncryptedVote
+	now     func() time.Time
+}
+
+// NewBoard returns an empty board collecting ballots encrypted with pk.
+// The proof of knowledge of the secret key behind pk is verified, and tree
+// heads are signed with signer.
+func NewBoard(pk *arith.CurvePoint, proof *crypto.ProofSkKnowledge, signer ed25519.PrivateKey) (*Board, error) {
+	if err := crypto.VerifySkKnowledge(proof, pk); err != nil {
+		return nil, err
+	}
+	if len(signer) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid signing key")
+	}
+	board := &Board{
+		signer:  signer,
+		seen:    make(map[string]bool),
+		tallies: []crypto.EncryptedVote{*crypto.NewEncryptedVote()},
+		now:     time.Now,
+	}
+	board.pk.Set(pk)
+	return board, nil
+}
+
+// Pk returns the election public key.
+func (b *Board) Pk() *arith.CurvePoint {
+	return new(arith.CurvePoint).Set(&b.pk)
+}
+
+// SignerPublicKey returns the key which verifies the tree heads signed by the board.
+func (b *Board) SignerPublicKey() ed25519.PublicKey {
+	return b.signer.Public().(ed25519.PublicKey)
+}
+
+// Append verifies a ballot and, if valid, appends it to the board and adds
+// it to the tally. It returns the index of the ballot in the log.
+//
+// Ballots already on the board are rejected, in the same way as Voting.sol
+// rejects already seen proofs, to prevent replaying someone else's vote.
+func (b *Board) Append(ballot *Ballot) (uint64, error) {
+	if err := crypto.VerifyVoteWellFormedness(&ballot.Proof, &ballot.EncryptedVote, &b.pk); err != nil {
+		return 0, &InvalidBallotError{Err: err}
+	}
+	data, err := ballot.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	leaf := string(LeafHash(data))
+	if b.seen[leaf] {
+		return 0, ErrDuplicateBallot
+	}
+	b.seen[leaf] = true
+	index := b.log.Append(data)
+	b.ballots = append(b.ballots, *new(Ballot).Set(ballot))
+	tally := new(crypto.EncryptedVote).Add(&b.tallies[len(b.tallies)-1], &ballot.EncryptedVote)
+	b.tallies = append(b.tallies, *tally)
+	return index, nil
+}
+
+// Size returns the number of ballots on the board.
+func (b *Board) Size() uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.log.Size()
+}
+
+// Ballot returns the ballot at position index.
+func (b *Board) Ballot(index uint64) (*Ballot, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if index >= b.log.Size() {
+		return nil, ErrIndexOutOfRange
+	}
+	return new(Ballot).Set(&b.ballots[index]), nil
+}
+
+// Tally returns the encrypted tally of the first size ballots.
+func (b *Board) Tally(size uint64) (*crypto.EncryptedVote, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if size > b.log.Size() {
+		return nil, ErrIndexOutOfRange
+	}
+	return new(crypto.EncryptedVote).Set(&b.tallies[size]), nil
+}
+
+// TreeHead returns a signed tree head for the current content of the board.
+func (b *Board) TreeHead() (*SignedTreeHead, error) {
+	b.mu.RLock()
+	size := b.log.Size()
+	root, err := b.log.Root(size)
+	tally := new(crypto.EncryptedVote).Set(&b.tallies[size])
+	b.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	sth := new(SignedTreeHead)
+	sth.Size = size
+	sth.Root = root
+	sth.Tally.Set(tally)
+	sth.Timestamp = b.now().UnixMilli()
+	payload, err := sth.signingPayload()
+	if err != nil {
+		return nil, err
+	}
+	sth.Signature = ed25519.Sign(b.signer, payload)
+	return sth, nil
+}
+
+// InclusionProof returns the audit path of the ballot at position index in
+// the tree of the given size.
+func (b *Board) InclusionProof(index, size uint64) ([][]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if size > b.log.Size() || index >= size {
+		return nil, ErrIndexOutOfRange
+	}
+	return b.log.InclusionProof(index, size)
+}
+
+// ConsistencyProof returns a proof that the tree of size first is a prefix
+// of the tree of size second.
+func (b *Board) ConsistencyProof(first, second uint64) ([][]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if second > b.log.Size() {
+		return nil, ErrIndexOutOfRange
+	}
+	if first > second {
+		return nil, fmt.Errorf("first tree size %d exceeds second tree size %d", first, second)
+	}
+	return b.log.ConsistencyProof(first, second)
+}
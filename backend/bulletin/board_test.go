@@ -0,0 +1,163 @@
+package bulletin
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+)
+
+// testVoterSecret is the voter secret of the encryptions of the tests.
+var testVoterSecret, _ = crypto.NewVoterSecret([]byte("e-voting test voter"))
+
+func TestBoardAppendAndTally(t *testing.T) {
+	board, keyPair := generateBoard(t)
+	votes := []crypto.Vote{crypto.Yes, crypto.No, crypto.Yes, crypto.Yes, crypto.No}
+	for i, vote := range votes {
+		index, err := board.Append(generateBallot(t, board, vote))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if index != uint64(i) {
+			t.Fatalf("expected index %d, got %d", i, index)
+		}
+	}
+
+	tally, err := board.Tally(board.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := tally.Decrypt(&keyPair.Sk, int64(len(votes)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != 3 {
+		t.Fatalf("expected 3 yes, got %d", result)
+	}
+}
+
+func TestBoardRejectsInvalidBallots(t *testing.T) {
+	board, _ := generateBoard(t)
+	ballot := generateBallot(t, board, crypto.Yes)
+	if _, err := board.Append(ballot); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := board.Append(ballot); !errors.Is(err, ErrDuplicateBallot) {
+		t.Fatalf("expected %v, got %v", ErrDuplicateBallot, err)
+	}
+
+	var invalidBallot *InvalidBallotError
+	if _, err := board.Append(generateWrongKeyBallot(t)); !errors.As(err, &invalidBallot) {
+		t.Fatalf("expected an InvalidBallotError for a ballot encrypted with the wrong key, got %v", err)
+	}
+	if board.Size() != 1 {
+		t.Fatalf("expected board size 1, got %d", board.Size())
+	}
+}
+
+func TestBoardTreeHead(t *testing.T) {
+	board, _ := generateBoard(t)
+	first := appendBallots(t, board, 3)
+	second := appendBallots(t, board, 4)
+
+	for _, sth := range []*SignedTreeHead{first, second} {
+		if err := VerifyTreeHead(sth, board.SignerPublicKey()); err != nil {
+			t.Fatal(err)
+		}
+		tally, err := board.Tally(sth.Size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !tally.A.Equal(&sth.Tally.A) || !tally.B.Equal(&sth.Tally.B) {
+			t.Fatal("tree head tally differs from board tally")
+		}
+	}
+
+	path, err := board.ConsistencyProof(first.Size, second.Size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyConsistency(first.Size, second.Size, first.Root, second.Root, path); err != nil {
+		t.Fatal(err)
+	}
+
+	for index := uint64(0); index < second.Size; index++ {
+		ballot, err := board.Ballot(index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ballot.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		path, err := board.InclusionProof(index, second.Size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifyInclusion(LeafHash(data), index, second.Size, path, second.Root); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	forged := *second
+	forged.Size++
+	if err := VerifyTreeHead(&forged, board.SignerPublicKey()); err == nil {
+		t.Fatal("successfully verified a forged tree head")
+	}
+}
+
+func generateBoard(t *testing.T) (*Board, *crypto.KeyPair) {
+	keyPair, proof, err := crypto.NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, signer, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	board, err := NewBoard(&keyPair.Pk, proof, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return board, keyPair
+}
+
+func generateBallot(t *testing.T, board *Board, vote crypto.Vote) *Ballot {
+	encryptedVote, proof, err := crypto.EncryptVoteWithProof(rand.Reader, int64(vote), testVoterSecret, board.Pk())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ballot := new(Ballot)
+	ballot.EncryptedVote.Set(encryptedVote)
+	ballot.Proof.Set(proof)
+	return ballot
+}
+
+// generateWrongKeyBallot returns a ballot encrypted with a key other than
+// the one of any board.
+func generateWrongKeyBallot(t *testing.T) *Ballot {
+	otherKeyPair, err := crypto.NewKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedVote, proof, err := crypto.EncryptVoteWithProof(rand.Reader, int64(crypto.Yes), testVoterSecret, &otherKeyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Ballot{EncryptedVote: *encryptedVote, Proof: *proof}
+}
+
+func appendBallots(t *testing.T, board *Board, n int) *SignedTreeHead {
+	for i := 0; i < n; i++ {
+		if _, err := board.Append(generateBallot(t, board, crypto.Vote(i%2))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sth, err := board.TreeHead()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sth
+}
@@ -0,0 +1,269 @@
+package bulletin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// maxBallotSize bounds the size of the body of a ballot submission.
+const maxBallotSize = 1 << 12
+
+// PkResponse is the body of the response to GET /pk.
+type PkResponse struct {
+	Pk     arith.CurvePoint `json:"pk"`
+	Signer hexutil.Bytes    `json:"signer"` // ed25519 key verifying tree heads
+}
+
+// AppendResponse is the body of the response to POST /ballots.
+type AppendResponse struct {
+	Index    uint64        `json:"index"`
+	LeafHash hexutil.Bytes `json:"leafHash"`
+}
+
+// TallyResponse is the body of the response to GET /tally.
+type TallyResponse struct {
+	Size  uint64               `json:"size"`
+	Tally crypto.EncryptedVote `json:"tally"`
+}
+
+// InclusionProofResponse is the body of the response to GET /proofs/inclusion.
+type InclusionProofResponse struct {
+	Index uint64          `json:"index"`
+	Size  uint64          `json:"size"`
+	Path  []hexutil.Bytes `json:"path"`
+}
+
+// ConsistencyProofResponse is the body of the response to GET /proofs/consistency.
+type ConsistencyProofResponse struct {
+	First  uint64          `json:"first"`
+	Second uint64          `json:"second"`
+	Path   []hexutil.Bytes `json:"path"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHandler returns an http.Handler exposing board through the following
+// JSON API:
+//
+//	GET  /pk                                      election public key and tree head signing key
+//	POST /ballots                                 submit a Ballot
+//	GET  /ballots/{index}                         ballot at position index
+//	GET  /tree-head                               current SignedTreeHead
+//	GET  /tally?size={size}                       encrypted tally of the first size ballots
+//	GET  /proofs/inclusion?index={i}&size={n}     inclusion proof of ballot i in tree of size n
+//	GET  /proofs/consistency?first={m}&second={n} consistency proof between trees of size m and n
+//
+// When size, or second, is omitted, the current size of the board is used.
+func NewHandler(board *Board) http.Handler {
+	h := &handler{board: board}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pk", h.method(http.MethodGet, h.getPk))
+	mux.HandleFunc("/ballots", limitBody(maxBallotSize, h.method(http.MethodPost, h.postBallot)))
+	mux.HandleFunc("/ballots/", h.method(http.MethodGet, h.getBallot))
+	mux.HandleFunc("/tree-head", h.method(http.MethodGet, h.getTreeHead))
+	mux.HandleFunc("/tally", h.method(http.MethodGet, h.getTally))
+	mux.HandleFunc("/proofs/inclusion", h.method(http.MethodGet, h.getInclusionProof))
+	mux.HandleFunc("/proofs/consistency", h.method(http.MethodGet, h.getConsistencyProof))
+	return mux
+}
+
+type handler struct {
+	board *Board
+}
+
+func (h *handler) method(method string, f func(*http.Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+			return
+		}
+		result, err := f(r)
+		if err != nil {
+			writeJSON(w, statusCode(err), errorResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// limitBody bounds the size of the request body to n bytes. The
+// ResponseWriter is passed to http.MaxBytesReader, so that the server
+// closes the connection after an oversized body.
+func limitBody(n int64, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, n)
+		next(w, r)
+	}
+}
+
+func (h *handler) getPk(r *http.Request) (interface{}, error) {
+	res := PkResponse{Signer: hexutil.Bytes(h.board.SignerPublicKey())}
+	res.Pk.Set(h.board.Pk())
+	return res, nil
+}
+
+func (h *handler) postBallot(r *http.Request) (interface{}, error) {
+	ballot := new(Ballot)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(ballot); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, newStatusError(http.StatusRequestEntityTooLarge, err)
+		}
+		return nil, newBadRequestError(err)
+	}
+	index, err := h.board.Append(ballot)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ballot.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return AppendResponse{Index: index, LeafHash: LeafHash(data)}, nil
+}
+
+func (h *handler) getBallot(r *http.Request) (interface{}, error) {
+	index, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/ballots/"), 10, 64)
+	if err != nil {
+		return nil, newBadRequestError(fmt.Errorf("invalid ballot index: %v", err))
+	}
+	return h.board.Ballot(index)
+}
+
+func (h *handler) getTreeHead(r *http.Request) (interface{}, error) {
+	return h.board.TreeHead()
+}
+
+func (h *handler) getTally(r *http.Request) (interface{}, error) {
+	size, err := queryUint(r, "size", h.board.Size())
+	if err != nil {
+		return nil, err
+	}
+	tally, err := h.board.Tally(size)
+	if err != nil {
+		return nil, err
+	}
+	res := TallyResponse{Size: size}
+	res.Tally.Set(tally)
+	return res, nil
+}
+
+func (h *handler) getInclusionProof(r *http.Request) (interface{}, error) {
+	if !r.URL.Query().Has("index") {
+		return nil, newBadRequestError(errors.New("missing parameter index"))
+	}
+	index, err := queryUint(r, "index", 0)
+	if err != nil {
+		return nil, err
+	}
+	size, err := queryUint(r, "size", h.board.Size())
+	if err != nil {
+		return nil, err
+	}
+	path, err := h.board.InclusionProof(index, size)
+	if err != nil {
+		return nil, err
+	}
+	return InclusionProofResponse{Index: index, Size: size, Path: hexPath(path)}, nil
+}
+
+func (h *handler) getConsistencyProof(r *http.Request) (interface{}, error) {
+	if !r.URL.Query().Has("first") {
+		return nil, newBadRequestError(errors.New("missing parameter first"))
+	}
+	first, err := queryUint(r, "first", 0)
+	if err != nil {
+		return nil, err
+	}
+	second, err := queryUint(r, "second", h.board.Size())
+	if err != nil {
+		return nil, err
+	}
+	if first > second {
+		return nil, newBadRequestError(errors.New("first should not exceed second"))
+	}
+	path, err := h.board.ConsistencyProof(first, second)
+	if err != nil {
+		return nil, err
+	}
+	return ConsistencyProofResponse{First: first, Second: second, Path: hexPath(path)}, nil
+}
+
+func queryUint(r *http.Request, key string, defaultValue uint64) (uint64, error) {
+	query := r.URL.Query()
+	if !query.Has(key) {
+		return defaultValue, nil
+	}
+	val, err := strconv.ParseUint(query.Get(key), 10, 64)
+	if err != nil {
+		return 0, newBadRequestError(fmt.Errorf("invalid parameter %s: %v", key, err))
+	}
+	return val, nil
+}
+
+func hexPath(path [][]byte) []hexutil.Bytes {
+	res := make([]hexutil.Bytes, len(path))
+	for i, p := range path {
+		res[i] = p
+	}
+	return res
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func statusCode(err error) int {
+	var status *statusError
+	var invalidBallot *InvalidBallotError
+	switch {
+	case errors.As(err, &status):
+		return status.status
+	case errors.Is(err, ErrDuplicateBallot):
+		return http.StatusConflict
+	case errors.Is(err, ErrIndexOutOfRange):
+		return http.StatusNotFound
+	case errors.As(err, &invalidBallot):
+		return http.StatusUnprocessableEntity
+	default:
+		// e.g. failures to marshal or sign a tree head
+		return http.StatusInternalServerError
+	}
+}
+
+// statusError is an error caused by the request, answered with status.
+type statusError struct {
+	status int
+	err    error
+}
+
+func newStatusError(status int, err error) *statusError {
+	return &statusError{status: status, err: err}
+}
+
+func newBadRequestError(err error) *statusError {
+	return newStatusError(http.StatusBadRequest, err)
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s: %v", strings.ToLower(http.StatusText(e.status)), e.err)
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
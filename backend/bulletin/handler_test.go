@@ -0,0 +1,106 @@
+package bulletin
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+)
+
+func TestHandler(t *testing.T) {
+	board, _ := generateBoard(t)
+	server := httptest.NewServer(NewHandler(board))
+	defer server.Close()
+
+	pkRes := new(PkResponse)
+	getJSON(t, server.URL+"/pk", http.StatusOK, pkRes)
+	if !pkRes.Pk.Equal(board.Pk()) {
+		t.Fatal("wrong public key")
+	}
+
+	var appendRes AppendResponse
+	for i := 0; i < 3; i++ {
+		ballot := generateBallot(t, board, crypto.Vote(i%2))
+		appendRes = AppendResponse{}
+		postJSON(t, server.URL+"/ballots", ballot, http.StatusOK, &appendRes)
+		if appendRes.Index != uint64(i) {
+			t.Fatalf("expected index %d, got %d", i, appendRes.Index)
+		}
+		postJSON(t, server.URL+"/ballots", ballot, http.StatusConflict, nil)
+	}
+
+	sth := new(SignedTreeHead)
+	getJSON(t, server.URL+"/tree-head", http.StatusOK, sth)
+	if err := VerifyTreeHead(sth, ed25519.PublicKey(pkRes.Signer)); err != nil {
+		t.Fatal(err)
+	}
+
+	inclusion := new(InclusionProofResponse)
+	url := fmt.Sprintf("%s/proofs/inclusion?index=%d&size=%d", server.URL, appendRes.Index, sth.Size)
+	getJSON(t, url, http.StatusOK, inclusion)
+	path := make([][]byte, len(inclusion.Path))
+	for i, p := range inclusion.Path {
+		path[i] = p
+	}
+	if err := VerifyInclusion(appendRes.LeafHash, appendRes.Index, sth.Size, path, sth.Root); err != nil {
+		t.Fatal(err)
+	}
+
+	ballot := new(Ballot)
+	getJSON(t, fmt.Sprintf("%s/ballots/%d", server.URL, appendRes.Index), http.StatusOK, ballot)
+	if err := crypto.VerifyVoteWellFormedness(&ballot.Proof, &ballot.EncryptedVote, board.Pk()); err != nil {
+		t.Fatal(err)
+	}
+
+	tally := new(TallyResponse)
+	getJSON(t, server.URL+"/tally", http.StatusOK, tally)
+	if tally.Size != sth.Size || !tally.Tally.A.Equal(&sth.Tally.A) || !tally.Tally.B.Equal(&sth.Tally.B) {
+		t.Fatal("tally differs from tree head")
+	}
+
+	getJSON(t, server.URL+"/ballots/3", http.StatusNotFound, nil)
+	getJSON(t, server.URL+"/proofs/inclusion?size=3", http.StatusBadRequest, nil)
+	getJSON(t, server.URL+"/proofs/consistency?first=3&second=2", http.StatusBadRequest, nil)
+	getJSON(t, server.URL+"/proofs/consistency?first=1&second=4", http.StatusNotFound, nil)
+	postJSON(t, server.URL+"/ballots", map[string]string{"encryptedVote": "0"}, http.StatusBadRequest, nil)
+	oversized := map[string]string{"padding": string(bytes.Repeat([]byte{'0'}, maxBallotSize))}
+	postJSON(t, server.URL+"/ballots", oversized, http.StatusRequestEntityTooLarge, nil)
+	postJSON(t, server.URL+"/ballots", generateWrongKeyBallot(t), http.StatusUnprocessableEntity, nil)
+}
+
+func getJSON(t *testing.T, url string, status int, v interface{}) {
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodeResponse(t, res, status, v)
+}
+
+func postJSON(t *testing.T, url string, body interface{}, status int, v interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodeResponse(t, res, status, v)
+}
+
+func decodeResponse(t *testing.T, res *http.Response, status int, v interface{}) {
+	defer res.Body.Close()
+	if res.StatusCode != status {
+		t.Fatalf("%s: expected status %d, got %d", res.Request.URL, status, res.StatusCode)
+	}
+	if v != nil {
+		if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package bulletin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Domain separation prefixes, as defined in RFC 6962, section 2.1.
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+// HashSize is the size in bytes of the hashes stored in a MerkleLog.
+const HashSize = 256 / 8
+
+// MerkleLog is an append-only Merkle tree, whose structure follows
+// RFC 6962. Keccak256 is used in place of SHA-256, so that proofs can be
+// cheaply verified on an EVM chain.
+type MerkleLog struct {
+	leaves [][]byte
+}
+
+// LeafHash returns the hash of a leaf containing data.
+func LeafHash(data []byte) []byte {
+	return crypto.Keccak256([]byte{leafPrefix}, data)
+}
+
+func nodeHash(left, right []byte) []byte {
+	return crypto.Keccak256([]byte{nodePrefix}, left, right)
+}
+
+// Size returns the number of leaves in the log.
+func (l *MerkleLog) Size() uint64 {
+	return uint64(len(l.leaves))
+}
+
+// Append appends a leaf containing data to the log, and returns its index.
+func (l *MerkleLog) Append(data []byte) uint64 {
+	l.leaves = append(l.leaves, LeafHash(data))
+	return uint64(len(l.leaves) - 1)
+}
+
+// LeafHashAt returns the hash of the leaf at position index.
+func (l *MerkleLog) LeafHashAt(index uint64) ([]byte, error) {
+	if index >= l.Size() {
+		return nil, fmt.Errorf("leaf index %d is out of range", index)
+	}
+	return l.leaves[index], nil
+}
+
+// Root returns the root hash of the tree made of the first size leaves.
+func (l *MerkleLog) Root(size uint64) ([]byte, error) {
+	if size > l.Size() {
+		return nil, fmt.Errorf("tree size %d is out of range", size)
+	}
+	return subtreeRoot(l.leaves[:size]), nil
+}
+
+// InclusionProof returns the audit path of the leaf at position index in
+// the tree made of the first size leaves (RFC 6962, section 2.1.1).
+func (l *MerkleLog) InclusionProof(index, size uint64) ([][]byte, error) {
+	if size > l.Size() {
+		return nil, fmt.Errorf("tree size %d is out of range", size)
+	}
+	if index >= size {
+		return nil, fmt.Errorf("leaf index %d is out of range", index)
+	}
+	return inclusionPath(index, l.leaves[:size]), nil
+}
+
+// ConsistencyProof returns a proof that the tree made of the first first
+// leaves is a prefix of the tree made of the first second leaves
+// (RFC 6962, section 2.1.2).
+func (l *MerkleLog) ConsistencyProof(first, second uint64) ([][]byte, error) {
+	if second > l.Size() {
+		return nil, fmt.Errorf("tree size %d is out of range", second)
+	}
+	if first > second {
+		return nil, errors.New("first tree size should not exceed second tree size")
+	}
+	if first == 0 || first == second {
+		return [][]byte{}, nil
+	}
+	return consistencyPath(first, l.leaves[:second], true), nil
+}
+
+func subtreeRoot(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		return crypto.Keccak256()
+	case 1:
+		return leaves[0]
+	}
+	k := splitPoint(uint64(len(leaves)))
+	return nodeHash(subtreeRoot(leaves[:k]), subtreeRoot(leaves[k:]))
+}
+
+func inclusionPath(index uint64, leaves [][]byte) [][]byte {
+	if len(leaves) <= 1 {
+		return [][]byte{}
+	}
+	k := splitPoint(uint64(len(leaves)))
+	if index < k {
+		return append(inclusionPath(index, leaves[:k]), subtreeRoot(leaves[k:]))
+	}
+	return append(inclusionPath(index-k, leaves[k:]), subtreeRoot(leaves[:k]))
+}
+
+func consistencyPath(m uint64, leaves [][]byte, complete bool) [][]byte {
+	n := uint64(len(leaves))
+	if m == n {
+		if complete {
+			return [][]byte{}
+		}
+		return [][]byte{subtreeRoot(leaves)}
+	}
+	k := splitPoint(n)
+	if m <= k {
+		return append(consistencyPath(m, leaves[:k], complete), subtreeRoot(leaves[k:]))
+	}
+	return append(consistencyPath(m-k, leaves[k:], false), subtreeRoot(leaves[:k]))
+}
+
+// splitPoint returns the largest power of two smaller than n, for n > 1.
+func splitPoint(n uint64) uint64 {
+	return 1 << (bits.Len64(n-1) - 1)
+}
+
+// VerifyInclusion verifies that leafHash is the hash of the leaf at position
+// index of a tree of the given size and root, using the algorithm of
+// RFC 9162, section 2.1.3.2.
+func VerifyInclusion(leafHash []byte, index, size uint64, path [][]byte, root []byte) error {
+	if index >= size {
+		return errors.New("leaf index is out of range")
+	}
+	fn, sn := index, size-1
+	r := leafHash
+	for _, p := range path {
+		if sn == 0 {
+			return errors.New("inclusion proof is too long")
+		}
+		if fn&1 == 1 || fn == sn {
+			r = nodeHash(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = nodeHash(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 {
+		return errors.New("inclusion proof is too short")
+	}
+	if !bytes.Equal(r, root) {
+		return errors.New("inclusion proof verification failed")
+	}
+	return nil
+}
+
+// VerifyConsistency verifies that the tree of size first and root
+// firstRoot is a prefix of the tree of size second and root secondRoot,
+// using the algorithm of RFC 9162, section 2.1.4.2.
+func VerifyConsistency(first, second uint64, firstRoot, secondRoot []byte, path [][]byte) error {
+	switch {
+	case first > second:
+		return errors.New("first tree size should not exceed second tree size")
+	case first == second:
+		if len(path) != 0 || !bytes.Equal(firstRoot, secondRoot) {
+			return errors.New("consistency proof verification failed")
+		}
+		return nil
+	case first == 0:
+		if len(path) != 0 {
+			return errors.New("consistency proof from an empty tree should be empty")
+		}
+		return nil
+	}
+	if first&(first-1) == 0 {
+		path = append([][]byte{firstRoot}, path...)
+	}
+	if len(path) == 0 {
+		return errors.New("consistency proof is too short")
+	}
+	fn, sn := first-1, second-1
+	for fn&1 == 1 {
+		fn >>= 1
+		sn >>= 1
+	}
+	fr, sr := path[0], path[0]
+	for _, c := range path[1:] {
+		if sn == 0 {
+			return errors.New("consistency proof is too long")
+		}
+		if fn&1 == 1 || fn == sn {
+			fr = nodeHash(c, fr)
+			sr = nodeHash(c, sr)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			sr = nodeHash(sr, c)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 {
+		return errors.New("consistency proof is too short")
+	}
+	if !bytes.Equal(fr, firstRoot) || !bytes.Equal(sr, secondRoot) {
+		return errors.New("consistency proof verification failed")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package bulletin
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestMerkleRootKnownValues(t *testing.T) {
+	l := generateMerkleLog(3)
+	h0, _ := l.LeafHashAt(0)
+	h1, _ := l.LeafHashAt(1)
+	h2, _ := l.LeafHashAt(2)
+
+	tests := map[string]struct {
+		size uint64
+		want []byte
+	}{
+		"1 leaf":   {size: 1, want: h0},
+		"2 leaves": {size: 2, want: nodeHash(h0, h1)},
+		"3 leaves": {size: 3, want: nodeHash(nodeHash(h0, h1), h2)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := l.Root(tc.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tc.want) {
+				t.Fatalf("want: %x, got: %x", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestInclusionProof(t *testing.T) {
+	const numLeaves = 17
+	l := generateMerkleLog(numLeaves)
+
+	for size := uint64(1); size <= numLeaves; size++ {
+		root, err := l.Root(size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for index := uint64(0); index < size; index++ {
+			path, err := l.InclusionProof(index, size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			leaf, _ := l.LeafHashAt(index)
+			err = VerifyInclusion(leaf, index, size, path, root)
+			if err != nil {
+				t.Fatalf("index %d, size %d: %v", index, size, err)
+			}
+			other, _ := l.LeafHashAt((index + 1) % numLeaves)
+			err = VerifyInclusion(other, index, size, path, root)
+			if err == nil {
+				t.Fatalf("index %d, size %d: successfully verified inclusion of wrong leaf", index, size)
+			}
+		}
+	}
+}
+
+func TestConsistencyProof(t *testing.T) {
+	const numLeaves = 17
+	l := generateMerkleLog(numLeaves)
+
+	for second := uint64(1); second <= numLeaves; second++ {
+		secondRoot, err := l.Root(second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for first := uint64(1); first <= second; first++ {
+			firstRoot, err := l.Root(first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path, err := l.ConsistencyProof(first, second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = VerifyConsistency(first, second, firstRoot, secondRoot, path)
+			if err != nil {
+				t.Fatalf("first %d, second %d: %v", first, second, err)
+			}
+			if first != second {
+				err = VerifyConsistency(first, second, secondRoot, secondRoot, path)
+				if err == nil {
+					t.Fatalf("first %d, second %d: successfully verified consistency with wrong root", first, second)
+				}
+			}
+		}
+	}
+}
+
+func TestMerkleLogOutOfRange(t *testing.T) {
+	l := generateMerkleLog(4)
+	if _, err := l.Root(5); err == nil {
+		t.Fatal("computed root of a tree bigger than the log")
+	}
+	if _, err := l.InclusionProof(4, 4); err == nil {
+		t.Fatal("computed inclusion proof for a leaf outside the tree")
+	}
+	if _, err := l.ConsistencyProof(3, 5); err == nil {
+		t.Fatal("computed consistency proof for a tree bigger than the log")
+	}
+}
+
+func generateMerkleLog(numLeaves int) *MerkleLog {
+	l := new(MerkleLog)
+	for i := 0; i < numLeaves; i++ {
+		l.Append([]byte(fmt.Sprintf("leaf %d", i)))
+	}
+	return l
+}
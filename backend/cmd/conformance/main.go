@@ -0,0 +1,75 @@
+// Command conformance generates the conformance test vectors of package
+// conformance, or checks existing ones.
+//
+// Usage:
+//
+//	conformance [-seed seed] [-out vectors.json]
+//	conformance -check vectors.json
+//
+// The vectors are written to standard output unless -out is given. The
+// checked-in vectors are regenerated, from the backend directory, with
+//
+//	go run ./cmd/conformance -out conformance/testdata/vectors.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/conformance"
+)
+
+func main() {
+	seed := flag.String("seed", conformance.DefaultSeed, "seed of the vectors")
+	out := flag.String("out", "", "output file (default standard output)")
+	check := flag.String("check", "", "check the vectors in this file instead of generating them")
+	flag.Parse()
+
+	var err error
+	if *check != "" {
+		err = runCheck(*check)
+	} else {
+		err = runGenerate(*seed, *out)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "conformance: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runGenerate(seed, out string) error {
+	vectors, err := conformance.Generate(seed)
+	if err != nil {
+		return err
+	}
+	// never write vectors which do not pass their own checks
+	if err := conformance.Check(vectors); err != nil {
+		return err
+	}
+	if out == "" {
+		return conformance.Write(os.Stdout, vectors)
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := conformance.Write(f, vectors); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func runCheck(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	vectors, err := conformance.Read(f)
+	if err != nil {
+		return err
+	}
+	return conformance.Check(vectors)
+}
@@ -0,0 +1,398 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/bulletin"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+)
+
+// electionKey is the content of the public file: the election public key,
+// and the proof of knowledge of its secret key.
+type electionKey struct {
+	Pk    arith.CurvePoint        `json:"pk"`
+	Proof crypto.ProofSkKnowledge `json:"proof"`
+}
+
+// encryptedTally is the output of command tally.
+type encryptedTally struct {
+	Tally crypto.EncryptedVote `json:"tally"`
+	// Weight is the total weight of the ballots added to Tally, which
+	// bounds the result of decryption.
+	Weight uint64 `json:"weight"`
+}
+
+// decryptedTally is the output of command decrypt.
+type decryptedTally struct {
+	Tally  crypto.EncryptedVote          `json:"tally"`
+	Weight uint64                        `json:"weight"`
+	Result int64                         `json:"result"`
+	Proof  crypto.ProofCorrectDecryption `json:"proof"`
+}
+
+func runKeygen(args []string, stdout io.Writer) error {
+	fs := newFlagSet("keygen")
+	secretFile := fs.String("secret", "", "output file for the key pair (keep it private)")
+	publicFile := fs.String("public", "", "output file for the public key and its proof")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "secret", "public"); err != nil {
+		return err
+	}
+
+	keyPair, proof, err := crypto.NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		return err
+	}
+	defer keyPair.Destroy()
+	key := &electionKey{}
+	key.Pk.Set(&keyPair.Pk)
+	key.Proof.Set(proof)
+
+	if err := writeJSONFile(*secretFile, keyPair, 0600); err != nil {
+		return err
+	}
+	return writeJSONFile(*publicFile, key, 0644)
+}
+
+func runVerifyKey(args []string, stdout io.Writer) error {
+	fs := newFlagSet("verify-key")
+	publicFile := fs.String("public", "", "file containing the public key and its proof")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "public"); err != nil {
+		return err
+	}
+
+	key := new(electionKey)
+	if err := readJSONFile(*publicFile, key); err != nil {
+		return err
+	}
+	if err := crypto.VerifySkKnowledge(&key.Proof, &key.Pk); err != nil {
+		return fmt.Errorf("%s: %w", *publicFile, err)
+	}
+	fmt.Fprintf(stdout, "%s: valid\n", *publicFile)
+	return nil
+}
+
+func runEncrypt(args []string, stdout io.Writer) error {
+	fs := newFlagSet("encrypt")
+	publicFile := fs.String("public", "", "file containing the public key and its proof")
+	voterKeyFile := fs.String("voter-key", "", "file containing secret key material of the voter (keep it private)")
+	vote := fs.Int64("vote", -1, "vote to encrypt: 0 (no) or 1 (yes)")
+	out := fs.String("out", "", "output file for the ballot (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "public", "voter-key", "vote"); err != nil {
+		return err
+	}
+	if *vote != int64(crypto.No) && *vote != int64(crypto.Yes) {
+		return fmt.Errorf("vote should be 0 or 1, got %d", *vote)
+	}
+
+	key, err := readElectionKey(*publicFile)
+	if err != nil {
+		return err
+	}
+	voterSecret, err := readVoterSecret(*voterKeyFile)
+	if err != nil {
+		return err
+	}
+	defer voterSecret.Destroy()
+	encryptedVote, proof, err := crypto.EncryptVoteWithProof(rand.Reader, *vote, voterSecret, &key.Pk)
+	if err != nil {
+		return err
+	}
+	ballot := new(bulletin.Ballot)
+	ballot.EncryptedVote.Set(encryptedVote)
+	ballot.Proof.Set(proof)
+	return writeJSON(*out, ballot, stdout)
+}
+
+func runVerifyBallot(args []string, stdout io.Writer) error {
+	fs := newFlagSet("verify-ballot")
+	publicFile := fs.String("public", "", "file containing the public key and its proof")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "public"); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("no ballot files given")
+	}
+
+	key, err := readElectionKey(*publicFile)
+	if err != nil {
+		return err
+	}
+	invalid := 0
+	for _, path := range fs.Args() {
+		if _, err := readBallot(path, &key.Pk); err != nil {
+			fmt.Fprintf(stdout, "%s: invalid: %v\n", path, err)
+			invalid++
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: valid\n", path)
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d ballots are invalid", invalid, fs.NArg())
+	}
+	return nil
+}
+
+func runTally(args []string, stdout io.Writer) error {
+	fs := newFlagSet("tally")
+	publicFile := fs.String("public", "", "file containing the public key and its proof")
+	weightsFile := fs.String("weights", "", "JSON object mapping each ballot file to the weight of its vote (default weight 1 for all ballots)")
+	out := fs.String("out", "", "output file for the encrypted tally (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "public"); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("no ballot files given")
+	}
+
+	key, err := readElectionKey(*publicFile)
+	if err != nil {
+		return err
+	}
+	var weights map[string]uint64
+	if *weightsFile != "" {
+		if err := readJSONFile(*weightsFile, &weights); err != nil {
+			return err
+		}
+	}
+
+	var ballots []*crypto.WeightedBallot
+	var total uint64
+	seen := make(map[string]string)
+	for _, path := range fs.Args() {
+		ballot := new(bulletin.Ballot)
+		if err := readJSONFile(path, ballot); err != nil {
+			return err
+		}
+		id, _ := ballot.MarshalBinary()
+		if other, ok := seen[string(id)]; ok {
+			return fmt.Errorf("%s: duplicate of ballot %s", path, other)
+		}
+		seen[string(id)] = path
+
+		weight := uint64(1)
+		if weights != nil {
+			w, ok := weights[path]
+			if !ok {
+				return fmt.Errorf("%s: missing weight", path)
+			}
+			weight = w
+		}
+		if weight > math.MaxInt64-total {
+			return errors.New("total weight overflows int64")
+		}
+		total += weight
+
+		weighted := &crypto.WeightedBallot{Weight: weight}
+		weighted.EncryptedVote.Set(&ballot.EncryptedVote)
+		weighted.Proof.Set(&ballot.Proof)
+		ballots = append(ballots, weighted)
+	}
+
+	// ballots are verified in parallel
+	ch := make(chan *crypto.WeightedBallot, len(ballots))
+	for _, ballot := range ballots {
+		ch <- ballot
+	}
+	close(ch)
+	tally, err := crypto.VerifyBallots(context.Background(), &key.Pk, ch)
+	if err != nil {
+		return err
+	}
+	if len(tally.Errors) > 0 {
+		ballotErr := tally.Errors[0]
+		return fmt.Errorf("%s: %w", fs.Arg(ballotErr.Index), ballotErr.Err)
+	}
+	res := &encryptedTally{Weight: tally.Weight}
+	res.Tally.Set(&tally.Tally)
+	return writeJSON(*out, res, stdout)
+}
+
+func runDecrypt(args []string, stdout io.Writer) error {
+	fs := newFlagSet("decrypt")
+	secretFile := fs.String("secret", "", "file containing the key pair")
+	tallyFile := fs.String("tally", "", "file containing the encrypted tally")
+	out := fs.String("out", "", "output file for the result and its proof (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "secret", "tally"); err != nil {
+		return err
+	}
+
+	keyPair := new(crypto.KeyPair)
+	if err := readJSONFile(*secretFile, keyPair); err != nil {
+		return err
+	}
+	defer keyPair.Destroy()
+	if !new(arith.CurvePoint).ScalarBaseMultSecret(&keyPair.Sk).Equal(&keyPair.Pk) {
+		return fmt.Errorf("%s: public key does not match secret key", *secretFile)
+	}
+	tally := new(encryptedTally)
+	if err := readJSONFile(*tallyFile, tally); err != nil {
+		return err
+	}
+	if tally.Weight > math.MaxInt64 {
+		return fmt.Errorf("%s: weight overflows int64", *tallyFile)
+	}
+
+	result, proof, err := crypto.DecryptTallyWithProof(rand.Reader, &tally.Tally, int64(tally.Weight), keyPair)
+	if err != nil {
+		return err
+	}
+	res := &decryptedTally{
+		Weight: tally.Weight,
+		Result: result,
+	}
+	res.Tally.Set(&tally.Tally)
+	res.Proof.Set(proof)
+	return writeJSON(*out, res, stdout)
+}
+
+func runVerifyDecryption(args []string, stdout io.Writer) error {
+	fs := newFlagSet("verify-decryption")
+	publicFile := fs.String("public", "", "file containing the public key and its proof")
+	resultFile := fs.String("result", "", "file containing the result and its proof")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "public", "result"); err != nil {
+		return err
+	}
+
+	key, err := readElectionKey(*publicFile)
+	if err != nil {
+		return err
+	}
+	res := new(decryptedTally)
+	if err := readJSONFile(*resultFile, res); err != nil {
+		return err
+	}
+	if res.Result < 0 || uint64(res.Result) > res.Weight {
+		return fmt.Errorf("%s: result %d is outside [0, %d]", *resultFile, res.Result, res.Weight)
+	}
+	if err := crypto.VerifyCorrectDecryption(&res.Proof, &res.Tally, crypto.Vote(res.Result), &key.Pk); err != nil {
+		return fmt.Errorf("%s: %w", *resultFile, err)
+	}
+	fmt.Fprintf(stdout, "%s: valid, result %d out of %d\n", *resultFile, res.Result, res.Weight)
+	return nil
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("evote "+name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+func requireFlags(fs *flag.FlagSet, names ...string) error {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, name := range names {
+		if !set[name] {
+			return fmt.Errorf("missing required option -%s", name)
+		}
+	}
+	return nil
+}
+
+// readElectionKey reads the public file, and verifies the proof of
+// knowledge of the secret key.
+func readElectionKey(path string) (*electionKey, error) {
+	key := new(electionKey)
+	if err := readJSONFile(path, key); err != nil {
+		return nil, err
+	}
+	if err := crypto.VerifySkKnowledge(&key.Proof, &key.Pk); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// readVoterSecret derives the voter secret from the content of the voter
+// key file.
+func readVoterSecret(path string) (*arith.SecretScalar, error) {
+	keyMaterial, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(keyMaterial)
+	voterSecret, err := crypto.NewVoterSecret(keyMaterial)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return voterSecret, nil
+}
+
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// readBallot reads a ballot file, and verifies the proof of well-formedness
+// of the encrypted vote.
+func readBallot(path string, pk *arith.CurvePoint) (*bulletin.Ballot, error) {
+	ballot := new(bulletin.Ballot)
+	if err := readJSONFile(path, ballot); err != nil {
+		return nil, err
+	}
+	if err := crypto.VerifyVoteWellFormedness(&ballot.Proof, &ballot.EncryptedVote, pk); err != nil {
+		return nil, err
+	}
+	return ballot, nil
+}
+
+func readJSONFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func writeJSONFile(path string, v interface{}, perm os.FileMode) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), perm)
+}
+
+// writeJSON writes v to the file at path, or to stdout if path is empty.
+func writeJSON(path string, v interface{}, stdout io.Writer) error {
+	if path != "" {
+		return writeJSONFile(path, v, 0644)
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(append(data, '\n'))
+	return err
+}
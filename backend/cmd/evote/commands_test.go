@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestElectionWorkflow(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "key.json")
+	public := filepath.Join(dir, "election.json")
+	run(t, runKeygen, "-secret", secret, "-public", public)
+	run(t, runVerifyKey, "-public", public)
+	voterKey := writeVoterKey(t, filepath.Join(dir, "voter.key"))
+
+	votes := []int{1, 0, 1, 1}
+	weights := make(map[string]uint64)
+	var ballots []string
+	forWeight := 0
+	for i, vote := range votes {
+		ballot := filepath.Join(dir, fmt.Sprintf("ballot%d.json", i))
+		run(t, runEncrypt, "-public", public, "-voter-key", voterKey, "-vote", fmt.Sprint(vote), "-out", ballot)
+		ballots = append(ballots, ballot)
+		weights[ballot] = uint64(i + 1)
+		forWeight += vote * (i + 1)
+	}
+	run(t, runVerifyBallot, append([]string{"-public", public}, ballots...)...)
+
+	weightsFile := filepath.Join(dir, "weights.json")
+	writeTestJSON(t, weightsFile, weights)
+	tally := filepath.Join(dir, "tally.json")
+	run(t, runTally, append([]string{"-public", public, "-weights", weightsFile, "-out", tally}, ballots...)...)
+
+	result := filepath.Join(dir, "result.json")
+	run(t, runDecrypt, "-secret", secret, "-tally", tally, "-out", result)
+	out := run(t, runVerifyDecryption, "-public", public, "-result", result)
+	if !strings.Contains(out, fmt.Sprintf("result %d out of 10", forWeight)) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	decrypted := new(decryptedTally)
+	if err := readJSONFile(result, decrypted); err != nil {
+		t.Fatal(err)
+	}
+	decrypted.Result++
+	writeTestJSON(t, result, decrypted)
+	if err := runVerifyDecryption([]string{"-public", public, "-result", result}, new(bytes.Buffer)); err == nil {
+		t.Fatal("successfully verified a decryption with a wrong result")
+	}
+}
+
+func TestRejectInvalidBallots(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "key.json")
+	public := filepath.Join(dir, "election.json")
+	otherPublic := filepath.Join(dir, "other.json")
+	run(t, runKeygen, "-secret", secret, "-public", public)
+	run(t, runKeygen, "-secret", filepath.Join(dir, "other-key.json"), "-public", otherPublic)
+	voterKey := writeVoterKey(t, filepath.Join(dir, "voter.key"))
+
+	valid := filepath.Join(dir, "valid.json")
+	run(t, runEncrypt, "-public", public, "-voter-key", voterKey, "-vote", "1", "-out", valid)
+	wrongKey := filepath.Join(dir, "wrong-key.json")
+	run(t, runEncrypt, "-public", otherPublic, "-voter-key", voterKey, "-vote", "1", "-out", wrongKey)
+	emptyKey := filepath.Join(dir, "empty.key")
+	if err := os.WriteFile(emptyKey, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		cmd  func([]string, io.Writer) error
+		args []string
+	}{
+		"ballot for another key": {
+			cmd:  runVerifyBallot,
+			args: []string{"-public", public, valid, wrongKey},
+		},
+		"duplicate ballot": {
+			cmd:  runTally,
+			args: []string{"-public", public, valid, valid},
+		},
+		"missing weight": {
+			cmd:  runTally,
+			args: []string{"-public", public, "-weights", writeTestJSON(t, filepath.Join(dir, "w.json"), map[string]uint64{}), valid},
+		},
+		"vote out of range": {
+			cmd:  runEncrypt,
+			args: []string{"-public", public, "-voter-key", voterKey, "-vote", "2"},
+		},
+		"missing option": {
+			cmd:  runEncrypt,
+			args: []string{"-voter-key", voterKey, "-vote", "1"},
+		},
+		"missing voter key": {
+			cmd:  runEncrypt,
+			args: []string{"-public", public, "-vote", "1"},
+		},
+		"empty voter key": {
+			cmd:  runEncrypt,
+			args: []string{"-public", public, "-voter-key", emptyKey, "-vote", "1"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.cmd(tc.args, new(bytes.Buffer)); err == nil {
+				t.Fatalf("command succeeded: %s", name)
+			}
+		})
+	}
+}
+
+func run(t *testing.T, cmd func([]string, io.Writer) error, args ...string) string {
+	t.Helper()
+	out := new(bytes.Buffer)
+	if err := cmd(args, out); err != nil {
+		t.Fatal(err)
+	}
+	return out.String()
+}
+
+// writeVoterKey writes random key material of a voter to path.
+func writeVoterKey(t *testing.T, path string) string {
+	t.Helper()
+	keyMaterial := make([]byte, 32)
+	if _, err := rand.Read(keyMaterial); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, keyMaterial, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func writeTestJSON(t *testing.T, path string, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
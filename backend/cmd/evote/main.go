@@ -0,0 +1,101 @@
+// Command evote runs all the off-chain steps of an election from the
+// command line, so that they can be scripted and audited without writing
+// Go or javascript code.
+//
+// Usage:
+//
+//	evote keygen -secret key.json -public election.json
+//	evote verify-key -public election.json
+//	evote encrypt -public election.json -voter-key voter.key -vote 0|1 [-out ballot.json]
+//	evote verify-ballot -public election.json ballot.json...
+//	evote tally -public election.json [-weights weights.json] [-out tally.json] ballot.json...
+//	evote decrypt -secret key.json -tally tally.json [-out result.json]
+//	evote verify-decryption -public election.json -result result.json
+//
+// All files use the JSON encodings of packages arith and crypto. The public
+// file contains the election public key together with the proof of
+// knowledge of its secret key, and can be passed as is to the -pk option of
+// the wasm server. Ballot files can be posted as is to the bulletin board.
+// The voter key file holds secret key material of the voter, e.g. their
+// private key, from which the voter secret hedging the encryption is
+// derived (see crypto.NewVoterSecret).
+//
+// Commands exit with a non-zero status if any input is malformed or any
+// proof does not verify.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+type command struct {
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands = map[string]command{
+	"keygen": {
+		usage: "generate an election key pair and a proof of knowledge of its secret key",
+		run:   runKeygen,
+	},
+	"verify-key": {
+		usage: "verify the proof of knowledge of the secret key of an election public key",
+		run:   runVerifyKey,
+	},
+	"encrypt": {
+		usage: "encrypt a vote and generate a proof of its well-formedness",
+		run:   runEncrypt,
+	},
+	"verify-ballot": {
+		usage: "verify the proofs of well-formedness of a set of ballots",
+		run:   runVerifyBallot,
+	},
+	"tally": {
+		usage: "verify a set of ballots and homomorphically add them, optionally weighted",
+		run:   runTally,
+	},
+	"decrypt": {
+		usage: "decrypt an encrypted tally and generate a proof of correct decryption",
+		run:   runDecrypt,
+	},
+	"verify-decryption": {
+		usage: "verify the proof of correct decryption of a tally",
+		run:   runVerifyDecryption,
+	},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage(os.Stderr)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "evote: unknown command %q\n", os.Args[1])
+		printUsage(os.Stderr)
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "evote %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: evote <command> [options]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-18s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'evote <command> -h' for the options of a command.")
+}
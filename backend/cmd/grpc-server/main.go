@@ -0,0 +1,45 @@
+package main
+
+import (
+	"crypto/rand"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/HorizenLabs/e-voting-poc/backend/rpc"
+	"github.com/HorizenLabs/e-voting-poc/backend/rpc/evotingpb"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", ":9091", "address to listen on")
+	voterKeyFile := flag.String("voter-key", "", "file containing secret key material of the voter, hedging the encryptions")
+	flag.Parse()
+
+	keyMaterial, err := os.ReadFile(*voterKeyFile)
+	if err != nil {
+		fmt.Println("Failed to read the voter key", err)
+		return
+	}
+	voterSecret, err := crypto.NewVoterSecret(keyMaterial)
+	if err != nil {
+		fmt.Println("Failed to derive the voter secret", err)
+		return
+	}
+	defer voterSecret.Destroy()
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Println("Failed to listen", err)
+		return
+	}
+	server := grpc.NewServer()
+	evotingpb.RegisterCryptoServiceServer(server, rpc.NewServer(rand.Reader, voterSecret))
+	err = server.Serve(listener)
+	if err != nil {
+		fmt.Println("Failed to start server", err)
+		return
+	}
+}
@@ -0,0 +1,367 @@
+package conformance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto/cryptopb"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/protobuf/proto"
+)
+
+// Check verifies the vectors against package crypto: every encoding must
+// decode to the same value and be canonical, every valid proof must
+// verify, the tallies must be the weighted sums of their ballots and decrypt
+// to their result, and every invalid case must be rejected.
+func Check(v *Vectors) error {
+	if v.Version != Version {
+		return fmt.Errorf("unsupported version %d", v.Version)
+	}
+	keyPairs := make([]*crypto.KeyPair, len(v.Keys))
+	for i := range v.Keys {
+		keyPair, err := checkKey(&v.Keys[i])
+		if err != nil {
+			return fmt.Errorf("key %d: %w", i, err)
+		}
+		defer keyPair.Destroy()
+		keyPairs[i] = keyPair
+	}
+	encryptedVotes := make([]*crypto.EncryptedVote, len(v.Ballots))
+	for i := range v.Ballots {
+		encryptedVote, err := checkBallot(&v.Ballots[i], keyPairs)
+		if err != nil {
+			return fmt.Errorf("ballot %d: %w", i, err)
+		}
+		encryptedVotes[i] = encryptedVote
+	}
+	for i := range v.Tallies {
+		if err := checkTally(&v.Tallies[i], keyPairs, encryptedVotes); err != nil {
+			return fmt.Errorf("tally %d: %w", i, err)
+		}
+	}
+	for i := range v.Invalid {
+		if err := checkInvalid(&v.Invalid[i]); err != nil {
+			return fmt.Errorf("invalid case %d (%s): %w", i, v.Invalid[i].Description, err)
+		}
+	}
+	return nil
+}
+
+func checkKey(k *Key) (*crypto.KeyPair, error) {
+	pk, err := decodeCurvePoint(&k.Pk)
+	if err != nil {
+		return nil, fmt.Errorf("pk: %w", err)
+	}
+	sk, err := hexutil.Decode(k.Sk)
+	if err != nil {
+		return nil, fmt.Errorf("sk: %w", err)
+	}
+	keyPair := new(crypto.KeyPair)
+	if err := keyPair.Sk.UnmarshalBinary(sk); err != nil {
+		return nil, fmt.Errorf("sk: %w", err)
+	}
+	keyPair.Pk.Set(pk)
+	if !new(arith.CurvePoint).ScalarBaseMultSecret(&keyPair.Sk).Equal(pk) {
+		keyPair.Destroy()
+		return nil, errors.New("public key does not match secret key")
+	}
+	proof, err := decodeProofSkKnowledge(&k.Proof)
+	if err != nil {
+		keyPair.Destroy()
+		return nil, fmt.Errorf("proof: %w", err)
+	}
+	if err := crypto.VerifySkKnowledge(proof, pk); err != nil {
+		keyPair.Destroy()
+		return nil, err
+	}
+	return keyPair, nil
+}
+
+func checkBallot(b *Ballot, keyPairs []*crypto.KeyPair) (*crypto.EncryptedVote, error) {
+	if b.Key < 0 || b.Key >= len(keyPairs) {
+		return nil, fmt.Errorf("unknown key %d", b.Key)
+	}
+	keyPair := keyPairs[b.Key]
+	encryptedVote, err := decodeEncryptedVote(&b.EncryptedVote)
+	if err != nil {
+		return nil, fmt.Errorf("encrypted vote: %w", err)
+	}
+	proof, err := decodeProofVoteWellFormedness(&b.Proof)
+	if err != nil {
+		return nil, fmt.Errorf("proof: %w", err)
+	}
+	if hexutil.Encode(crypto.EncodeCastVoteParams(encryptedVote, proof)) != b.CastVoteParams {
+		return nil, errors.New("wrong cast vote params")
+	}
+	if err := crypto.VerifyVoteWellFormedness(proof, encryptedVote, &keyPair.Pk); err != nil {
+		return nil, err
+	}
+	vote, err := encryptedVote.Decrypt(&keyPair.Sk, 1)
+	if err != nil {
+		return nil, err
+	}
+	if int64(vote) != b.Vote {
+		return nil, fmt.Errorf("ballot encrypts %d, not %d", vote, b.Vote)
+	}
+	return encryptedVote, nil
+}
+
+func checkTally(t *Tally, keyPairs []*crypto.KeyPair, encryptedVotes []*crypto.EncryptedVote) error {
+	if t.Key < 0 || t.Key >= len(keyPairs) {
+		return fmt.Errorf("unknown key %d", t.Key)
+	}
+	if len(t.Ballots) != len(t.Weights) {
+		return errors.New("ballots and weights should have the same length")
+	}
+	sum := crypto.NewEncryptedVote()
+	for i, ballot := range t.Ballots {
+		if ballot < 0 || ballot >= len(encryptedVotes) {
+			return fmt.Errorf("unknown ballot %d", ballot)
+		}
+		scaled := new(crypto.EncryptedVote).Scale(encryptedVotes[ballot], new(arith.Scalar).SetUint64(t.Weights[i]))
+		sum.Add(sum, scaled)
+	}
+	encryptedTally, err := decodeEncryptedVote(&t.EncryptedTally)
+	if err != nil {
+		return fmt.Errorf("encrypted tally: %w", err)
+	}
+	if !encryptedTally.A.Equal(&sum.A) || !encryptedTally.B.Equal(&sum.B) {
+		return errors.New("encrypted tally is not the weighted sum of its ballots")
+	}
+	proof, err := decodeProofCorrectDecryption(&t.Proof)
+	if err != nil {
+		return fmt.Errorf("proof: %w", err)
+	}
+	return crypto.VerifyCorrectDecryption(proof, encryptedTally, crypto.Vote(t.Result), &keyPairs[t.Key].Pk)
+}
+
+// checkInvalid checks that c is rejected, either by the ABI decoders, or by
+// the verification of the proof.
+func checkInvalid(c *Invalid) error {
+	var err error
+	switch c.Kind {
+	case KindSkKnowledge:
+		err = verifyInvalidSkKnowledge(c)
+	case KindVoteWellFormedness:
+		err = verifyInvalidVoteWellFormedness(c)
+	case KindCorrectDecryption:
+		err = verifyInvalidCorrectDecryption(c)
+	default:
+		return fmt.Errorf("unknown kind %q", c.Kind)
+	}
+	if err == nil {
+		return errors.New("successfully verified an invalid proof")
+	}
+	return nil
+}
+
+func verifyInvalidSkKnowledge(c *Invalid) error {
+	pk, err := unmarshalABICurvePoint(c.Pk)
+	if err != nil {
+		return err
+	}
+	proof := new(crypto.ProofSkKnowledge)
+	if err := unmarshalABIHex(proof, c.Proof); err != nil {
+		return err
+	}
+	return crypto.VerifySkKnowledge(proof, pk)
+}
+
+func verifyInvalidVoteWellFormedness(c *Invalid) error {
+	pk, err := unmarshalABICurvePoint(c.Pk)
+	if err != nil {
+		return err
+	}
+	encryptedVote := new(crypto.EncryptedVote)
+	if err := unmarshalABIHex(encryptedVote, c.EncryptedVote); err != nil {
+		return err
+	}
+	proof := new(crypto.ProofVoteWellFormedness)
+	if err := unmarshalABIHex(proof, c.Proof); err != nil {
+		return err
+	}
+	return crypto.VerifyVoteWellFormedness(proof, encryptedVote, pk)
+}
+
+func verifyInvalidCorrectDecryption(c *Invalid) error {
+	pk, err := unmarshalABICurvePoint(c.Pk)
+	if err != nil {
+		return err
+	}
+	encryptedVote := new(crypto.EncryptedVote)
+	if err := unmarshalABIHex(encryptedVote, c.EncryptedVote); err != nil {
+		return err
+	}
+	proof := new(crypto.ProofCorrectDecryption)
+	if err := unmarshalABIHex(proof, c.Proof); err != nil {
+		return err
+	}
+	return crypto.VerifyCorrectDecryption(proof, encryptedVote, crypto.Vote(c.Result), pk)
+}
+
+// The decoders below decode each encoding of e, check that they all yield
+// the same value, and that e is the canonical encoding of that value.
+
+func decodeCurvePoint(e *Encoding) (*arith.CurvePoint, error) {
+	p, err := unmarshalABICurvePoint(e.ABI)
+	if err != nil {
+		return nil, fmt.Errorf("abi: %w", err)
+	}
+	fromJSON := new(arith.CurvePoint)
+	if err := json.Unmarshal([]byte(e.JSON), fromJSON); err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+	fromBinary, err := unmarshalABICurvePoint(e.Binary)
+	if err != nil {
+		return nil, fmt.Errorf("binary: %w", err)
+	}
+	if !fromJSON.Equal(p) || !fromBinary.Equal(p) {
+		return nil, errors.New("encodings of different values")
+	}
+	return p, checkCanonical(e, encodeCurvePoint(p))
+}
+
+func decodeEncryptedVote(e *Encoding) (*crypto.EncryptedVote, error) {
+	v := new(crypto.EncryptedVote)
+	if err := unmarshalABIHex(v, e.ABI); err != nil {
+		return nil, fmt.Errorf("abi: %w", err)
+	}
+	fromJSON := new(crypto.EncryptedVote)
+	if err := json.Unmarshal([]byte(e.JSON), fromJSON); err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+	m := new(cryptopb.EncryptedVote)
+	if err := unmarshalProtoHex(m, e.Binary); err != nil {
+		return nil, fmt.Errorf("binary: %w", err)
+	}
+	fromBinary, err := m.Decode()
+	if err != nil {
+		return nil, fmt.Errorf("binary: %w", err)
+	}
+	want := encodeEncryptedVote(v)
+	if encodeEncryptedVote(fromJSON) != want || encodeEncryptedVote(fromBinary) != want {
+		return nil, errors.New("encodings of different values")
+	}
+	return v, checkCanonical(e, want)
+}
+
+func decodeProofSkKnowledge(e *Encoding) (*crypto.ProofSkKnowledge, error) {
+	p := new(crypto.ProofSkKnowledge)
+	if err := unmarshalABIHex(p, e.ABI); err != nil {
+		return nil, fmt.Errorf("abi: %w", err)
+	}
+	fromJSON := new(crypto.ProofSkKnowledge)
+	if err := json.Unmarshal([]byte(e.JSON), fromJSON); err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+	m := new(cryptopb.ProofSkKnowledge)
+	if err := unmarshalProtoHex(m, e.Binary); err != nil {
+		return nil, fmt.Errorf("binary: %w", err)
+	}
+	fromBinary, err := m.Decode()
+	if err != nil {
+		return nil, fmt.Errorf("binary: %w", err)
+	}
+	want := encodeProofSkKnowledge(p)
+	if encodeProofSkKnowledge(fromJSON) != want || encodeProofSkKnowledge(fromBinary) != want {
+		return nil, errors.New("encodings of different values")
+	}
+	return p, checkCanonical(e, want)
+}
+
+func decodeProofVoteWellFormedness(e *Encoding) (*crypto.ProofVoteWellFormedness, error) {
+	p := new(crypto.ProofVoteWellFormedness)
+	if err := unmarshalABIHex(p, e.ABI); err != nil {
+		return nil, fmt.Errorf("abi: %w", err)
+	}
+	fromJSON := new(crypto.ProofVoteWellFormedness)
+	if err := json.Unmarshal([]byte(e.JSON), fromJSON); err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+	m := new(cryptopb.ProofVoteWellFormedness)
+	if err := unmarshalProtoHex(m, e.Binary); err != nil {
+		return nil, fmt.Errorf("binary: %w", err)
+	}
+	fromBinary, err := m.Decode()
+	if err != nil {
+		return nil, fmt.Errorf("binary: %w", err)
+	}
+	want := encodeProofVoteWellFormedness(p)
+	if encodeProofVoteWellFormedness(fromJSON) != want || encodeProofVoteWellFormedness(fromBinary) != want {
+		return nil, errors.New("encodings of different values")
+	}
+	return p, checkCanonical(e, want)
+}
+
+func decodeProofCorrectDecryption(e *Encoding) (*crypto.ProofCorrectDecryption, error) {
+	p := new(crypto.ProofCorrectDecryption)
+	if err := unmarshalABIHex(p, e.ABI); err != nil {
+		return nil, fmt.Errorf("abi: %w", err)
+	}
+	fromJSON := new(crypto.ProofCorrectDecryption)
+	if err := json.Unmarshal([]byte(e.JSON), fromJSON); err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+	m := new(cryptopb.ProofCorrectDecryption)
+	if err := unmarshalProtoHex(m, e.Binary); err != nil {
+		return nil, fmt.Errorf("binary: %w", err)
+	}
+	fromBinary, err := m.Decode()
+	if err != nil {
+		return nil, fmt.Errorf("binary: %w", err)
+	}
+	want := encodeProofCorrectDecryption(p)
+	if encodeProofCorrectDecryption(fromJSON) != want || encodeProofCorrectDecryption(fromBinary) != want {
+		return nil, errors.New("encodings of different values")
+	}
+	return p, checkCanonical(e, want)
+}
+
+func checkCanonical(got *Encoding, want Encoding) error {
+	switch {
+	case got.JSON != want.JSON:
+		return errors.New("non-canonical json encoding")
+	case got.Binary != want.Binary:
+		return errors.New("non-canonical binary encoding")
+	case got.ABI != want.ABI:
+		return errors.New("non-canonical abi encoding")
+	}
+	return nil
+}
+
+type abiUnmarshaler interface {
+	UnmarshalABI(m []byte) error
+}
+
+func unmarshalABIHex(v abiUnmarshaler, s string) error {
+	data, err := hexutil.Decode(s)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalABI(data)
+}
+
+// unmarshalABICurvePoint decodes an IGroup.GroupElement, which is encoded
+// like MarshalBinary.
+func unmarshalABICurvePoint(s string) (*arith.CurvePoint, error) {
+	data, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	p := new(arith.CurvePoint)
+	if err := p.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func unmarshalProtoHex(m proto.Message, s string) error {
+	data, err := hexutil.Decode(s)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, m)
+}
@@ -0,0 +1,94 @@
+package conformance
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestCheckVectors(t *testing.T) {
+	vectors := readVectors(t)
+	if err := Check(vectors); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckTamperedVectors(t *testing.T) {
+	tests := map[string]func(v *Vectors){
+		"version": func(v *Vectors) {
+			v.Version++
+		},
+		"pk json": func(v *Vectors) {
+			v.Keys[0].Pk.JSON = v.Keys[1].Pk.JSON
+		},
+		"pk abi": func(v *Vectors) {
+			v.Keys[0].Pk.ABI = v.Keys[1].Pk.ABI
+		},
+		"sk": func(v *Vectors) {
+			v.Keys[0].Sk = v.Keys[1].Sk
+		},
+		"sk proof": func(v *Vectors) {
+			v.Keys[0].Proof = v.Keys[1].Proof
+		},
+		"ballot key": func(v *Vectors) {
+			v.Ballots[0].Key = len(v.Keys)
+		},
+		"ballot vote": func(v *Vectors) {
+			v.Ballots[0].Vote = 1 - v.Ballots[0].Vote
+		},
+		"ballot binary": func(v *Vectors) {
+			v.Ballots[0].EncryptedVote.Binary = v.Ballots[1].EncryptedVote.Binary
+		},
+		"ballot proof": func(v *Vectors) {
+			v.Ballots[0].Proof = v.Ballots[1].Proof
+		},
+		"cast vote params": func(v *Vectors) {
+			v.Ballots[0].CastVoteParams = v.Ballots[1].CastVoteParams
+		},
+		"tally weight": func(v *Vectors) {
+			v.Tallies[0].Weights[0]++
+		},
+		"tally result": func(v *Vectors) {
+			v.Tallies[0].Result++
+		},
+		"tally ballots": func(v *Vectors) {
+			v.Tallies[0].Ballots = v.Tallies[0].Ballots[1:]
+		},
+		"valid case as invalid": func(v *Vectors) {
+			v.Invalid[0].Pk = v.Keys[0].Pk.ABI
+		},
+		"unknown kind": func(v *Vectors) {
+			v.Invalid[0].Kind = "unknown"
+		},
+	}
+
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			vectors := readVectors(t)
+			tamper(vectors)
+			if err := Check(vectors); err == nil {
+				t.Fatal("successfully checked tampered vectors")
+			}
+		})
+	}
+}
+
+func TestReadUnknownField(t *testing.T) {
+	if _, err := Read(bytes.NewReader([]byte(`{"version":2,"extra":0}`))); err == nil {
+		t.Fatal("successfully read vectors with an unknown field")
+	}
+}
+
+func readVectors(t *testing.T) *Vectors {
+	t.Helper()
+	f, err := os.Open(vectorsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	vectors, err := Read(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vectors
+}
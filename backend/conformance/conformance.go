@@ -0,0 +1,393 @@
+// Package conformance generates and checks the test vectors which pin down
+// the encodings and the proofs of package crypto, so that every other
+// implementation of the protocol, in particular Cryptography.sol, can be
+// tested against the Go backend.
+//
+// The vectors are generated by Generate from a seed, through
+// crypto.NewSeededReader, so that they are reproducible. They contain key
+// pairs, ballots and weighted tallies, with the three proofs of the
+// protocol, and a set of invalid proofs which must be rejected. Every value
+// is given in three encodings: the JSON encoding of packages arith and
+// crypto, the protobuf encoding of package cryptopb, and abi.encode of the
+// corresponding struct of Cryptography.sol.
+//
+// The checked-in vectors are in testdata/vectors.json, and can be
+// regenerated with
+//
+//	go run ./cmd/conformance -out conformance/testdata/vectors.json
+//
+// from the backend directory.
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto/cryptopb"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"google.golang.org/protobuf/proto"
+)
+
+// Version is the version of the format of the vectors. It changes whenever
+// the format, or the content generated from the same seed, changes.
+const Version = 2
+
+// DefaultSeed is the seed of the checked-in vectors.
+const DefaultSeed = "e-voting conformance vectors"
+
+// Kinds of proofs of the invalid cases.
+const (
+	KindSkKnowledge        = "skKnowledge"
+	KindVoteWellFormedness = "voteWellFormedness"
+	KindCorrectDecryption  = "correctDecryption"
+)
+
+// Vectors are the conformance test vectors. Ballots and tallies refer to
+// keys, and tallies refer to ballots, by their index.
+type Vectors struct {
+	Version int       `json:"version"`
+	Seed    string    `json:"seed"`
+	Keys    []Key     `json:"keys"`
+	Ballots []Ballot  `json:"ballots"`
+	Tallies []Tally   `json:"tallies"`
+	Invalid []Invalid `json:"invalid"`
+}
+
+// Encoding is a value in each of its encodings. JSON is the JSON encoding
+// of packages arith and crypto, Binary is the hex of the protobuf encoding
+// of package cryptopb (or of MarshalBinary for a curve point), and ABI is
+// the hex of abi.encode of the value in Cryptography.sol.
+type Encoding struct {
+	JSON   string `json:"json"`
+	Binary string `json:"binary"`
+	ABI    string `json:"abi"`
+}
+
+// Key is a key pair, with the proof of knowledge of its secret key. Sk is
+// the hex of the binary encoding of the secret key.
+type Key struct {
+	Sk    string   `json:"sk"`
+	Pk    Encoding `json:"pk"`
+	Proof Encoding `json:"proof"`
+}
+
+// Ballot is an encrypted vote for a key, with its proof of
+// well-formedness. CastVoteParams is the hex of the params of
+// GovernorEncrypted.castVoteWithReasonAndParams.
+type Ballot struct {
+	Key            int      `json:"key"`
+	Vote           int64    `json:"vote"`
+	EncryptedVote  Encoding `json:"encryptedVote"`
+	Proof          Encoding `json:"proof"`
+	CastVoteParams string   `json:"castVoteParams"`
+}
+
+// Tally is the sum of ballots for the same key, each one scaled by its
+// weight, with its decryption and the proof of correct decryption.
+type Tally struct {
+	Key            int      `json:"key"`
+	Ballots        []int    `json:"ballots"`
+	Weights        []uint64 `json:"weights"`
+	EncryptedTally Encoding `json:"encryptedTally"`
+	Result         int64    `json:"result"`
+	Proof          Encoding `json:"proof"`
+}
+
+// Invalid is a proof of the given kind which must be rejected, either
+// because it does not verify, or because it is not a valid encoding. All
+// the fields are ABI encodings, and EncryptedVote and Result are only set
+// for the kinds which need them.
+type Invalid struct {
+	Description   string `json:"description"`
+	Kind          string `json:"kind"`
+	Pk            string `json:"pk"`
+	EncryptedVote string `json:"encryptedVote,omitempty"`
+	Result        int64  `json:"result,omitempty"`
+	Proof         string `json:"proof"`
+}
+
+var (
+	ballotVotes   = [][]int64{{1, 0, 1, 1, 0}, {0, 1}}
+	ballotWeights = [][]uint64{{1, 2, 3, 4, 5}, {7, 1}}
+)
+
+// Generate generates the vectors from seed.
+func Generate(seed string) (*Vectors, error) {
+	reader := crypto.NewSeededReader([]byte(seed))
+	v := &Vectors{
+		Version: Version,
+		Seed:    seed,
+	}
+
+	keyPairs := make([]*crypto.KeyPair, len(ballotVotes))
+	skProofs := make([]*crypto.ProofSkKnowledge, len(ballotVotes))
+	for i := range keyPairs {
+		keyPair, proof, err := crypto.NewKeyPairWithProof(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer keyPair.Destroy()
+		keyPairs[i], skProofs[i] = keyPair, proof
+		sk, _ := keyPair.Sk.MarshalBinary()
+		v.Keys = append(v.Keys, Key{
+			Sk:    hexutil.Encode(sk),
+			Pk:    encodeCurvePoint(&keyPair.Pk),
+			Proof: encodeProofSkKnowledge(proof),
+		})
+	}
+
+	var encryptedVotes []*crypto.EncryptedVote
+	var voteProofs []*crypto.ProofVoteWellFormedness
+	for key, votes := range ballotVotes {
+		tally := Tally{Key: key}
+		encryptedTally := crypto.NewEncryptedVote()
+		var weight uint64
+		for i, vote := range votes {
+			// every ballot is cast by a different voter
+			voterSecret, err := crypto.NewVoterSecret([]byte(fmt.Sprintf("%s voter %d", seed, len(v.Ballots))))
+			if err != nil {
+				return nil, err
+			}
+			encryptedVote, proof, err := crypto.EncryptVoteWithProof(reader, vote, voterSecret, &keyPairs[key].Pk)
+			voterSecret.Destroy()
+			if err != nil {
+				return nil, err
+			}
+			tally.Ballots = append(tally.Ballots, len(v.Ballots))
+			tally.Weights = append(tally.Weights, ballotWeights[key][i])
+			encryptedVotes = append(encryptedVotes, encryptedVote)
+			voteProofs = append(voteProofs, proof)
+			v.Ballots = append(v.Ballots, Ballot{
+				Key:            key,
+				Vote:           vote,
+				EncryptedVote:  encodeEncryptedVote(encryptedVote),
+				Proof:          encodeProofVoteWellFormedness(proof),
+				CastVoteParams: hexutil.Encode(crypto.EncodeCastVoteParams(encryptedVote, proof)),
+			})
+			scaled := new(crypto.EncryptedVote).Scale(encryptedVote, new(arith.Scalar).SetUint64(ballotWeights[key][i]))
+			encryptedTally.Add(encryptedTally, scaled)
+			weight += ballotWeights[key][i]
+		}
+		result, proof, err := crypto.DecryptTallyWithProof(reader, encryptedTally, int64(weight), keyPairs[key])
+		if err != nil {
+			return nil, err
+		}
+		tally.EncryptedTally = encodeEncryptedVote(encryptedTally)
+		tally.Result = result
+		tally.Proof = encodeProofCorrectDecryption(proof)
+		v.Tallies = append(v.Tallies, tally)
+	}
+
+	v.Invalid = invalidCases(v, skProofs, encryptedVotes, voteProofs)
+	return v, nil
+}
+
+// Write writes the JSON encoding of v to w, in the format of the
+// checked-in vectors.
+func Write(w io.Writer, v *Vectors) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// Read reads vectors written by Write from r. Unknown fields are rejected.
+func Read(r io.Reader) (*Vectors, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	v := new(Vectors)
+	if err := dec.Decode(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// invalidCases derives the invalid cases from the valid vectors.
+func invalidCases(
+	v *Vectors,
+	skProofs []*crypto.ProofSkKnowledge,
+	encryptedVotes []*crypto.EncryptedVote,
+	voteProofs []*crypto.ProofVoteWellFormedness) []Invalid {
+	pk0, pk1 := v.Keys[0].Pk.ABI, v.Keys[1].Pk.ABI
+	one := new(arith.Scalar).SetUint64(1)
+	oneChallenge := new(arith.Challenge)
+	_ = oneChallenge.UnmarshalBinary(append(make([]byte, arith.NumBytesChallenge-1), 1))
+
+	tamperedS := new(crypto.ProofSkKnowledge).Set(skProofs[0])
+	tamperedS.S.Add(&tamperedS.S, one)
+	tamperedC := new(crypto.ProofSkKnowledge).Set(skProofs[0])
+	tamperedC.C.Add(&tamperedC.C, oneChallenge)
+	// abi.encode(s + order, c): the same proof, with a response which is not
+	// reduced modulo the group order
+	unreducedS := hexutil.Encode(append(
+		new(big.Int).Add(scalarBigInt(&skProofs[0].S), bn256.Order).FillBytes(make([]byte, crypto.NumBytesABIWord)),
+		skProofs[0].MarshalABI()[crypto.NumBytesABIWord:]...))
+	// abi.encode(GroupElement(1, 3)), which is not on the curve
+	notOnCurve := hexutil.Encode(append(
+		big.NewInt(1).FillBytes(make([]byte, crypto.NumBytesABIWord)),
+		big.NewInt(3).FillBytes(make([]byte, crypto.NumBytesABIWord))...))
+
+	// the encryption of 2 = 1 + 1, with the proof that it encrypts 1
+	two := new(crypto.EncryptedVote).Set(encryptedVotes[0])
+	two.B.Add(&two.B, new(arith.CurvePoint).ScalarBaseMult(one))
+	swapped := new(crypto.ProofVoteWellFormedness).Set(voteProofs[0])
+	swapped.C0.Set(&voteProofs[0].C1)
+	swapped.C1.Set(&voteProofs[0].C0)
+
+	tally0, tally1 := &v.Tallies[0], &v.Tallies[1]
+	return []Invalid{
+		{
+			Description: "proof for another public key",
+			Kind:        KindSkKnowledge,
+			Pk:          pk1,
+			Proof:       v.Keys[0].Proof.ABI,
+		},
+		{
+			Description: "tampered response",
+			Kind:        KindSkKnowledge,
+			Pk:          pk0,
+			Proof:       hexutil.Encode(tamperedS.MarshalABI()),
+		},
+		{
+			Description: "tampered challenge",
+			Kind:        KindSkKnowledge,
+			Pk:          pk0,
+			Proof:       hexutil.Encode(tamperedC.MarshalABI()),
+		},
+		{
+			Description: "response not reduced modulo the group order",
+			Kind:        KindSkKnowledge,
+			Pk:          pk0,
+			Proof:       unreducedS,
+		},
+		{
+			Description: "public key not on the curve",
+			Kind:        KindSkKnowledge,
+			Pk:          notOnCurve,
+			Proof:       v.Keys[0].Proof.ABI,
+		},
+		{
+			Description:   "proof for another ballot",
+			Kind:          KindVoteWellFormedness,
+			Pk:            pk0,
+			EncryptedVote: v.Ballots[1].EncryptedVote.ABI,
+			Proof:         v.Ballots[0].Proof.ABI,
+		},
+		{
+			Description:   "ballot for another public key",
+			Kind:          KindVoteWellFormedness,
+			Pk:            pk1,
+			EncryptedVote: v.Ballots[0].EncryptedVote.ABI,
+			Proof:         v.Ballots[0].Proof.ABI,
+		},
+		{
+			Description:   "ballot encrypting 2",
+			Kind:          KindVoteWellFormedness,
+			Pk:            pk0,
+			EncryptedVote: hexutil.Encode(two.MarshalABI()),
+			Proof:         v.Ballots[0].Proof.ABI,
+		},
+		{
+			Description:   "swapped challenges",
+			Kind:          KindVoteWellFormedness,
+			Pk:            pk0,
+			EncryptedVote: v.Ballots[0].EncryptedVote.ABI,
+			Proof:         hexutil.Encode(swapped.MarshalABI()),
+		},
+		{
+			Description:   "wrong result",
+			Kind:          KindCorrectDecryption,
+			Pk:            pk0,
+			EncryptedVote: tally0.EncryptedTally.ABI,
+			Result:        tally0.Result + 1,
+			Proof:         tally0.Proof.ABI,
+		},
+		{
+			Description:   "proof for another public key",
+			Kind:          KindCorrectDecryption,
+			Pk:            pk1,
+			EncryptedVote: tally0.EncryptedTally.ABI,
+			Result:        tally0.Result,
+			Proof:         tally0.Proof.ABI,
+		},
+		{
+			Description:   "proof for another tally",
+			Kind:          KindCorrectDecryption,
+			Pk:            pk0,
+			EncryptedVote: tally0.EncryptedTally.ABI,
+			Result:        tally0.Result,
+			Proof:         tally1.Proof.ABI,
+		},
+	}
+}
+
+func encodeCurvePoint(p *arith.CurvePoint) Encoding {
+	data, _ := p.MarshalBinary()
+	return Encoding{
+		JSON:   marshalJSON(p),
+		Binary: hexutil.Encode(data),
+		// IGroup.GroupElement is x || y, like MarshalBinary
+		ABI: hexutil.Encode(data),
+	}
+}
+
+func encodeEncryptedVote(e *crypto.EncryptedVote) Encoding {
+	return Encoding{
+		JSON:   marshalJSON(e),
+		Binary: marshalProto(cryptopb.NewEncryptedVote(e)),
+		ABI:    hexutil.Encode(e.MarshalABI()),
+	}
+}
+
+func encodeProofSkKnowledge(p *crypto.ProofSkKnowledge) Encoding {
+	return Encoding{
+		JSON:   marshalJSON(p),
+		Binary: marshalProto(cryptopb.NewProofSkKnowledge(p)),
+		ABI:    hexutil.Encode(p.MarshalABI()),
+	}
+}
+
+func encodeProofVoteWellFormedness(p *crypto.ProofVoteWellFormedness) Encoding {
+	return Encoding{
+		JSON:   marshalJSON(p),
+		Binary: marshalProto(cryptopb.NewProofVoteWellFormedness(p)),
+		ABI:    hexutil.Encode(p.MarshalABI()),
+	}
+}
+
+func encodeProofCorrectDecryption(p *crypto.ProofCorrectDecryption) Encoding {
+	return Encoding{
+		JSON:   marshalJSON(p),
+		Binary: marshalProto(cryptopb.NewProofCorrectDecryption(p)),
+		ABI:    hexutil.Encode(p.MarshalABI()),
+	}
+}
+
+// marshalJSON returns the JSON encoding of v, which cannot fail for the
+// types of packages arith and crypto.
+func marshalJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("conformance: %v", err))
+	}
+	return string(data)
+}
+
+// marshalProto returns the hex of the deterministic protobuf encoding of
+// m, which cannot fail for the messages of package cryptopb.
+func marshalProto(m proto.Message) string {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		panic(fmt.Sprintf("conformance: %v", err))
+	}
+	return hexutil.Encode(data)
+}
+
+func scalarBigInt(s *arith.Scalar) *big.Int {
+	data, _ := s.MarshalBinary()
+	return new(big.Int).SetBytes(data)
+}
@@ -0,0 +1,44 @@
+package conformance
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+const vectorsFile = "testdata/vectors.json"
+
+func TestVectorsUpToDate(t *testing.T) {
+	want, err := os.ReadFile(vectorsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vectors, err := Generate(DefaultSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got bytes.Buffer
+	if err := Write(&got, vectors); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Fatalf("%s is out of date, regenerate it with go run ./cmd/conformance", vectorsFile)
+	}
+}
+
+func TestGenerateOtherSeed(t *testing.T) {
+	vectors, err := Generate("other seed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(vectors); err != nil {
+		t.Fatal(err)
+	}
+	defaultVectors, err := Generate(DefaultSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vectors.Keys[0].Pk == defaultVectors.Keys[0].Pk {
+		t.Fatal("different seeds generated the same keys")
+	}
+}
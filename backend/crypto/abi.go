@@ -0,0 +1,246 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// Sizes of the Ethereum ABI encodings of the structs defined in
+// Cryptography.sol. All of them are static tuples, so their encoding is the
+// concatenation of the 32 bytes words encoding each field.
+const (
+	NumBytesABIWord                    = 32
+	NumBytesABIEncryptedVote           = 4 * NumBytesABIWord
+	NumBytesABIProofSkKnowledge        = 2 * NumBytesABIWord
+	NumBytesABIProofCorrectDecryption  = 2 * NumBytesABIWord
+	NumBytesABIProofVoteWellFormedness = 4 * NumBytesABIWord
+	// NumBytesABICastVoteParams is the length of the params expected by
+	// GovernorEncrypted._countVote.
+	NumBytesABICastVoteParams = NumBytesABIEncryptedVote + NumBytesABIProofVoteWellFormedness
+)
+
+// MarshalABI returns abi.encode(e), where e is a Cryptography.EncryptedVote.
+func (e *EncryptedVote) MarshalABI() []byte {
+	buf := make([]byte, 0, NumBytesABIEncryptedVote)
+	buf = appendABICurvePoint(buf, &e.A)
+	buf = appendABICurvePoint(buf, &e.B)
+	return buf
+}
+
+// UnmarshalABI sets e to the result of abi.decode(m, (Cryptography.EncryptedVote)).
+// Both points must have coordinates below the base field modulus and lie on the curve.
+func (e *EncryptedVote) UnmarshalABI(m []byte) error {
+	if err := checkABILength(m, NumBytesABIEncryptedVote); err != nil {
+		return err
+	}
+	a, err := parseABICurvePoint(m[:2*NumBytesABIWord])
+	if err != nil {
+		return newABIFieldError("a", err)
+	}
+	b, err := parseABICurvePoint(m[2*NumBytesABIWord:])
+	if err != nil {
+		return newABIFieldError("b", err)
+	}
+	e.A.Set(a)
+	e.B.Set(b)
+	return nil
+}
+
+// MarshalABI returns abi.encode(p), where p is a Cryptography.ProofSkKnowledge.
+func (p *ProofSkKnowledge) MarshalABI() []byte {
+	buf := make([]byte, 0, NumBytesABIProofSkKnowledge)
+	buf = appendABIScalar(buf, &p.S)
+	buf = appendABIChallenge(buf, &p.C)
+	return buf
+}
+
+// UnmarshalABI sets p to the result of abi.decode(m, (Cryptography.ProofSkKnowledge)).
+func (p *ProofSkKnowledge) UnmarshalABI(m []byte) error {
+	if err := checkABILength(m, NumBytesABIProofSkKnowledge); err != nil {
+		return err
+	}
+	s, c, err := parseABIScalarChallenge(m)
+	if err != nil {
+		return err
+	}
+	p.S.Set(s)
+	p.C.Set(c)
+	return nil
+}
+
+// MarshalABI returns abi.encode(p), where p is a Cryptography.ProofCorrectDecryption.
+func (p *ProofCorrectDecryption) MarshalABI() []byte {
+	buf := make([]byte, 0, NumBytesABIProofCorrectDecryption)
+	buf = appendABIScalar(buf, &p.S)
+	buf = appendABIChallenge(buf, &p.C)
+	return buf
+}
+
+// UnmarshalABI sets p to the result of abi.decode(m, (Cryptography.ProofCorrectDecryption)).
+func (p *ProofCorrectDecryption) UnmarshalABI(m []byte) error {
+	if err := checkABILength(m, NumBytesABIProofCorrectDecryption); err != nil {
+		return err
+	}
+	s, c, err := parseABIScalarChallenge(m)
+	if err != nil {
+		return err
+	}
+	p.S.Set(s)
+	p.C.Set(c)
+	return nil
+}
+
+// MarshalABI returns abi.encode(p), where p is a Cryptography.ProofVoteWellFormedness.
+func (p *ProofVoteWellFormedness) MarshalABI() []byte {
+	buf := make([]byte, 0, NumBytesABIProofVoteWellFormedness)
+	buf = appendABIScalar(buf, &p.R0)
+	buf = appendABIScalar(buf, &p.R1)
+	buf = appendABIChallenge(buf, &p.C0)
+	buf = appendABIChallenge(buf, &p.C1)
+	return buf
+}
+
+// UnmarshalABI sets p to the result of abi.decode(m, (Cryptography.ProofVoteWellFormedness)).
+func (p *ProofVoteWellFormedness) UnmarshalABI(m []byte) error {
+	if err := checkABILength(m, NumBytesABIProofVoteWellFormedness); err != nil {
+		return err
+	}
+	r0, err := parseABIScalar(m[:NumBytesABIWord])
+	if err != nil {
+		return newABIFieldError("r0", err)
+	}
+	r1, err := parseABIScalar(m[NumBytesABIWord : 2*NumBytesABIWord])
+	if err != nil {
+		return newABIFieldError("r1", err)
+	}
+	c0, err := parseABIChallenge(m[2*NumBytesABIWord : 3*NumBytesABIWord])
+	if err != nil {
+		return newABIFieldError("c0", err)
+	}
+	c1, err := parseABIChallenge(m[3*NumBytesABIWord:])
+	if err != nil {
+		return newABIFieldError("c1", err)
+	}
+	p.R0.Set(r0)
+	p.R1.Set(r1)
+	p.C0.Set(c0)
+	p.C1.Set(c1)
+	return nil
+}
+
+// EncodeCastVoteParams returns abi.encode(vote, proof), i.e. the params that
+// must be passed to GovernorEncrypted.castVoteWithReasonAndParams.
+func EncodeCastVoteParams(vote *EncryptedVote, proof *ProofVoteWellFormedness) []byte {
+	buf := make([]byte, 0, NumBytesABICastVoteParams)
+	buf = append(buf, vote.MarshalABI()...)
+	buf = append(buf, proof.MarshalABI()...)
+	return buf
+}
+
+// DecodeCastVoteParams parses the params of GovernorEncrypted._countVote.
+// The proof is not verified.
+func DecodeCastVoteParams(params []byte) (*EncryptedVote, *ProofVoteWellFormedness, error) {
+	if err := checkABILength(params, NumBytesABICastVoteParams); err != nil {
+		return nil, nil, err
+	}
+	vote := new(EncryptedVote)
+	if err := vote.UnmarshalABI(params[:NumBytesABIEncryptedVote]); err != nil {
+		return nil, nil, newABIFieldError("encryptedVote", err)
+	}
+	proof := new(ProofVoteWellFormedness)
+	if err := proof.UnmarshalABI(params[NumBytesABIEncryptedVote:]); err != nil {
+		return nil, nil, newABIFieldError("proof", err)
+	}
+	return vote, proof, nil
+}
+
+func appendABIScalar(buf []byte, s *arith.Scalar) []byte {
+	// MarshalBinary yields a 32 bytes big-endian integer, i.e. a uint256 word
+	data, _ := s.MarshalBinary()
+	return append(buf, data...)
+}
+
+func appendABIChallenge(buf []byte, c *arith.Challenge) []byte {
+	// Challenges are uint128, left-padded with zeros to a full word
+	data, _ := c.MarshalBinary()
+	buf = append(buf, make([]byte, NumBytesABIWord-arith.NumBytesChallenge)...)
+	return append(buf, data...)
+}
+
+func appendABICurvePoint(buf []byte, p *arith.CurvePoint) []byte {
+	// MarshalBinary yields x || y, each one as a 32 bytes big-endian integer,
+	// which is exactly the encoding of IGroup.GroupElement
+	data, _ := p.MarshalBinary()
+	return append(buf, data...)
+}
+
+func parseABIScalar(word []byte) (*arith.Scalar, error) {
+	s := new(arith.Scalar)
+	if err := s.UnmarshalBinary(word); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func parseABIChallenge(word []byte) (*arith.Challenge, error) {
+	padding := word[:NumBytesABIWord-arith.NumBytesChallenge]
+	for _, b := range padding {
+		if b != 0 {
+			return nil, errors.New("challenge does not fit in uint128")
+		}
+	}
+	c := new(arith.Challenge)
+	if err := c.UnmarshalBinary(word[len(padding):]); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func parseABICurvePoint(m []byte) (*arith.CurvePoint, error) {
+	p := new(arith.CurvePoint)
+	if err := p.UnmarshalBinary(m); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func parseABIScalarChallenge(m []byte) (*arith.Scalar, *arith.Challenge, error) {
+	s, err := parseABIScalar(m[:NumBytesABIWord])
+	if err != nil {
+		return nil, nil, newABIFieldError("s", err)
+	}
+	c, err := parseABIChallenge(m[NumBytesABIWord:])
+	if err != nil {
+		return nil, nil, newABIFieldError("c", err)
+	}
+	return s, c, nil
+}
+
+func checkABILength(m []byte, n int) error {
+	if len(m) != n {
+		return fmt.Errorf("ABI encoding should be %d bytes long, got %d", n, len(m))
+	}
+	return nil
+}
+
+type abiFieldError struct {
+	field string
+	err   error
+}
+
+func newABIFieldError(field string, err error) *abiFieldError {
+	return &abiFieldError{
+		field: field,
+		err:   err,
+	}
+}
+
+func (e *abiFieldError) Error() string {
+	return fmt.Sprintf("error decoding field %s: %v", e.field, e.err)
+}
+
+func (e *abiFieldError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,166 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+func TestCastVoteParamsRoundTrip(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	vote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := EncodeCastVoteParams(vote, proof)
+	if len(params) != NumBytesABICastVoteParams {
+		t.Fatalf("expected %d bytes, got %d", NumBytesABICastVoteParams, len(params))
+	}
+	gotVote, gotProof, err := DecodeCastVoteParams(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyVoteWellFormedness(gotProof, gotVote, &keyPair.Pk); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(EncodeCastVoteParams(gotVote, gotProof), params) {
+		t.Fatal("re-encoding the decoded params yields different bytes")
+	}
+}
+
+func TestCastVoteParamsMatchSolidityABI(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	vote, proof, err := EncryptVoteWithProof(rand.Reader, int64(No), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupElement := []abi.ArgumentMarshaling{
+		{Name: "x", Type: "uint256"},
+		{Name: "y", Type: "uint256"},
+	}
+	encryptedVoteType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "a", Type: "tuple", Components: groupElement},
+		{Name: "b", Type: "tuple", Components: groupElement},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proofType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "r0", Type: "uint256"},
+		{Name: "r1", Type: "uint256"},
+		{Name: "c0", Type: "uint128"},
+		{Name: "c1", Type: "uint128"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	arguments := abi.Arguments{{Type: encryptedVoteType}, {Type: proofType}}
+
+	type groupElementABI struct {
+		X *big.Int
+		Y *big.Int
+	}
+	type encryptedVoteABI struct {
+		A groupElementABI
+		B groupElementABI
+	}
+	type proofABI struct {
+		R0 *big.Int
+		R1 *big.Int
+		C0 *big.Int
+		C1 *big.Int
+	}
+	point := func(data []byte) groupElementABI {
+		return groupElementABI{
+			X: new(big.Int).SetBytes(data[:32]),
+			Y: new(big.Int).SetBytes(data[32:]),
+		}
+	}
+	toBig := func(m interface{ MarshalBinary() ([]byte, error) }) *big.Int {
+		data, _ := m.MarshalBinary()
+		return new(big.Int).SetBytes(data)
+	}
+	a, _ := vote.A.MarshalBinary()
+	b, _ := vote.B.MarshalBinary()
+
+	expected, err := arguments.Pack(
+		encryptedVoteABI{A: point(a), B: point(b)},
+		proofABI{R0: toBig(proof.R0), R1: toBig(proof.R1), C0: toBig(proof.C0), C1: toBig(proof.C1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := EncodeCastVoteParams(vote, proof); !bytes.Equal(got, expected) {
+		t.Fatalf("expected: %x, got: %x", expected, got)
+	}
+}
+
+func TestProofsABIRoundTrip(t *testing.T) {
+	keyPair, proofSk, err := NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotProofSk := new(ProofSkKnowledge)
+	if err := gotProofSk.UnmarshalABI(proofSk.MarshalABI()); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySkKnowledge(gotProofSk, &keyPair.Pk); err != nil {
+		t.Fatal(err)
+	}
+
+	tally, _, err := Vote(1).Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, proofDec, err := DecryptTallyWithProof(rand.Reader, tally, 1, keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotProofDec := new(ProofCorrectDecryption)
+	if err := gotProofDec.UnmarshalABI(proofDec.MarshalABI()); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyCorrectDecryption(gotProofDec, tally, Vote(result), &keyPair.Pk); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDecodeInvalidCastVoteParams(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	vote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := EncodeCastVoteParams(vote, proof)
+	withWord := func(i int, word []byte) []byte {
+		params := append([]byte(nil), valid...)
+		copy(params[i*NumBytesABIWord:(i+1)*NumBytesABIWord], word)
+		return params
+	}
+	word := func(x *big.Int) []byte {
+		return x.FillBytes(make([]byte, NumBytesABIWord))
+	}
+
+	tests := map[string][]byte{
+		"too short":            valid[:NumBytesABICastVoteParams-1],
+		"too long":             append(append([]byte(nil), valid...), 0),
+		"point outside curve":  withWord(1, word(big.NewInt(1))),
+		"coordinate too large": withWord(2, word(bn256.P)),
+		"r0 over modulus":      withWord(4, word(bn256.Order)),
+		"r1 over modulus":      withWord(5, word(new(big.Int).Add(bn256.Order, big.NewInt(1)))),
+		"c0 over uint128":      withWord(6, word(new(big.Int).Lsh(big.NewInt(1), 128))),
+		"c1 over uint128":      withWord(7, word(new(big.Int).Lsh(big.NewInt(1), 200))),
+	}
+	for name, params := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := DecodeCastVoteParams(params); err == nil {
+				t.Fatalf("successfully decoded invalid params: %s", name)
+			}
+		})
+	}
+}
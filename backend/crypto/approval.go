@@ -0,0 +1,146 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// NoApprovalLimit is the limit of approval ballots on which any number of
+// options can be approved.
+const NoApprovalLimit = -1
+
+// ApprovalBallot is a vote which approves any subset of a list of options.
+// Entries[i] encrypts 1 if option i is approved and 0 otherwise, and
+// Proofs[i] is its proof of well-formedness.
+//
+// Elections may limit the number m of options approved by a voter. In that
+// case Limit proves that the sum of the entries is in [0, m].
+type ApprovalBallot struct {
+	Entries []EncryptedVote           `json:"entries"`
+	Proofs  []ProofVoteWellFormedness `json:"proofs"`
+	Limit   *ProofRange               `json:"limit,omitempty"`
+}
+
+// EncryptApprovalBallot encrypts an approval ballot on which option i is
+// approved if approvals[i] is true. If m is not NoApprovalLimit, at most m
+// options should be approved, and the ballot proves it. The randomness of
+// the encryptions is hedged with voterSecret (see NewVoterSecret).
+func EncryptApprovalBallot(
+	reader io.Reader,
+	approvals []bool,
+	m int,
+	voterSecret *arith.SecretScalar,
+	pk *arith.CurvePoint) (*ApprovalBallot, error) {
+	if len(approvals) == 0 {
+		return nil, errors.New("approval ballot should have at least one option")
+	}
+	limited := hasApprovalLimit(m, len(approvals))
+	numApproved := 0
+	for _, approved := range approvals {
+		if approved {
+			numApproved++
+		}
+	}
+	if limited && numApproved > m {
+		return nil, fmt.Errorf("%d options approved, at most %d allowed", numApproved, m)
+	}
+	nonces, err := newVoterReader(reader, voterSecret, pk)
+	if err != nil {
+		return nil, err
+	}
+	defer nonces.destroy()
+
+	ballot := &ApprovalBallot{
+		Entries: make([]EncryptedVote, len(approvals)),
+		Proofs:  make([]ProofVoteWellFormedness, len(approvals)),
+	}
+	rSum := new(arith.SecretScalar)
+	defer rSum.Destroy()
+	for i, approved := range approvals {
+		vote := No
+		if approved {
+			vote = Yes
+		}
+		encryptedVote, r, err := vote.encrypt(nonces, pk)
+		if err != nil {
+			return nil, err
+		}
+		proof, err := ProveVoteWellFormedness(nonces, encryptedVote, vote, r, pk)
+		rSum.Add(rSum, r)
+		r.Destroy()
+		if err != nil {
+			return nil, err
+		}
+		ballot.Entries[i].Set(encryptedVote)
+		ballot.Proofs[i].Set(proof)
+	}
+
+	if !limited {
+		return ballot, nil
+	}
+	limit, err := ProveRange(nonces, sumEntries(ballot.Entries), uint64(numApproved), rSum, uint64(m), pk)
+	if err != nil {
+		return nil, err
+	}
+	ballot.Limit = limit
+	return ballot, nil
+}
+
+// VerifyApprovalBallot verifies an approval ballot on numOptions options,
+// on which at most m options can be approved, or any number if m is
+// NoApprovalLimit.
+func VerifyApprovalBallot(ballot *ApprovalBallot, numOptions int, m int, pk *arith.CurvePoint) error {
+	if ballot == nil {
+		return errors.New("missing approval ballot")
+	}
+	if len(ballot.Entries) != numOptions || len(ballot.Proofs) != numOptions {
+		return fmt.Errorf("approval ballot should have %d entries and proofs", numOptions)
+	}
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	for i := range ballot.Entries {
+		if err := v.VerifyVoteWellFormedness(&ballot.Proofs[i], &ballot.Entries[i], pk); err != nil {
+			return fmt.Errorf("option %d: %w", i, err)
+		}
+	}
+	if !hasApprovalLimit(m, numOptions) {
+		return nil
+	}
+	if ballot.Limit == nil {
+		return fmt.Errorf("approval ballot should prove that at most %d options are approved", m)
+	}
+	if err := VerifyRange(ballot.Limit, sumEntries(ballot.Entries), uint64(m), pk); err != nil {
+		return fmt.Errorf("more than %d options approved: %w", m, err)
+	}
+	return nil
+}
+
+// ApprovalBallotCost returns the cost of the on-chain verification of an
+// approval ballot (see VerificationCost).
+func ApprovalBallotCost(numOptions int, m int) VerificationCost {
+	cost := VoteWellFormednessCost.Times(numOptions)
+	if hasApprovalLimit(m, numOptions) {
+		// sum of the entries, then the range proof
+		cost = cost.Add(VerificationCost{ECAdd: 2 * (numOptions - 1)})
+		cost = cost.Add(RangeProofCost(uint64(m)))
+	}
+	return cost
+}
+
+// hasApprovalLimit reports whether limit m actually restricts ballots on
+// numOptions options.
+func hasApprovalLimit(m int, numOptions int) bool {
+	return m >= 0 && m < numOptions
+}
+
+// sumEntries returns the homomorphic sum of entries.
+func sumEntries(entries []EncryptedVote) *EncryptedVote {
+	sum := NewEncryptedVote()
+	for i := range entries {
+		sum.Add(sum, &entries[i])
+	}
+	return sum
+}
@@ -0,0 +1,117 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestApprovalVoting(t *testing.T) {
+	tests := map[string]struct {
+		ballots [][]bool
+		weights []uint64
+		m       int
+	}{
+		"no limit": {
+			ballots: [][]bool{{true, true, true}, {false, true, false}, {false, false, false}},
+			weights: []uint64{1, 1, 1},
+			m:       NoApprovalLimit,
+		},
+		"limit": {
+			ballots: [][]bool{{true, false, true, false}, {false, true, false, false}, {false, false, false, false}},
+			weights: []uint64{1, 1, 1},
+			m:       2,
+		},
+		"single approval": {
+			ballots: [][]bool{{false, true}, {true, false}},
+			weights: []uint64{1, 1},
+			m:       1,
+		},
+		"weighted": {
+			ballots: [][]bool{{true, false, true}, {true, true, false}},
+			weights: []uint64{30, 12},
+			m:       2,
+		},
+	}
+	keyPair := generateKeyPair(t, rand.Reader)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			numOptions := len(tc.ballots[0])
+			tally := NewOptionTally(numOptions)
+			expected := make([]int64, numOptions)
+			var totalWeight int64
+			for i, approvals := range tc.ballots {
+				ballot, err := EncryptApprovalBallot(rand.Reader, approvals, tc.m, testVoterSecret, &keyPair.Pk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := VerifyApprovalBallot(ballot, numOptions, tc.m, &keyPair.Pk); err != nil {
+					t.Fatal(err)
+				}
+				if err := tally.Add(ballot.Entries, tc.weights[i]); err != nil {
+					t.Fatal(err)
+				}
+				for j, approved := range approvals {
+					if approved {
+						expected[j] += int64(tc.weights[i])
+					}
+				}
+				totalWeight += int64(tc.weights[i])
+			}
+
+			results, proofs, err := DecryptOptionTallyWithProof(rand.Reader, tally, totalWeight, keyPair)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for j := range expected {
+				if results[j] != expected[j] {
+					t.Fatalf("expected: %v, got: %v", expected, results)
+				}
+			}
+			if err := VerifyOptionTally(tally, results, proofs, &keyPair.Pk); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestApprovalBallotLimit(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	approvals := []bool{true, true, false, true}
+	if _, err := EncryptApprovalBallot(rand.Reader, approvals, 2, testVoterSecret, &keyPair.Pk); err == nil {
+		t.Fatal("encrypted an approval ballot over the limit")
+	}
+
+	ballot, err := EncryptApprovalBallot(rand.Reader, approvals, NoApprovalLimit, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyApprovalBallot(ballot, len(approvals), 2, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified an approval ballot without the limit proof")
+	}
+	// a limit proof of another ballot
+	other, err := EncryptApprovalBallot(rand.Reader, []bool{true, false, false, true}, 2, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ballot.Limit = other.Limit
+	if err := VerifyApprovalBallot(ballot, len(approvals), 2, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified an approval ballot over the limit")
+	}
+	if err := VerifyApprovalBallot(ballot, len(approvals), NoApprovalLimit, &keyPair.Pk); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyApprovalBallot(ballot, len(approvals)+1, NoApprovalLimit, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified an approval ballot with a missing option")
+	}
+}
+
+func TestApprovalBallotCost(t *testing.T) {
+	if got, expected := ApprovalBallotCost(5, NoApprovalLimit), VoteWellFormednessCost.Times(5); got != expected {
+		t.Fatalf("expected: %+v, got: %+v", expected, got)
+	}
+	expected := VoteWellFormednessCost.Times(5).Add(VerificationCost{ECAdd: 8}).Add(RangeProofCost(2))
+	if got := ApprovalBallotCost(5, 2); got != expected {
+		t.Fatalf("expected: %+v, got: %+v", expected, got)
+	}
+}
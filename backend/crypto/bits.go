@@ -0,0 +1,135 @@
+package crypto
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// powersOfTwo returns the weights of the binary representation of the
+// integers below 2^numBits.
+func powersOfTwo(numBits int) []uint64 {
+	weights := make([]uint64, numBits)
+	for i := range weights {
+		weights[i] = 1 << i
+	}
+	return weights
+}
+
+// decompose returns the bits b such that x = sum(weights[i] * b[i]), found
+// greedily from the last weight, which is correct for the weights returned
+// by powersOfTwo and RangeWeights. It fails if x is out of their range.
+func decompose(x uint64, weights []uint64) ([]Vote, error) {
+	bits := make([]Vote, len(weights))
+	rest := x
+	for i := len(weights) - 1; i >= 0; i-- {
+		if rest >= weights[i] {
+			bits[i] = Yes
+			rest -= weights[i]
+		}
+	}
+	if rest != 0 {
+		return nil, fmt.Errorf("value %d out of range", x)
+	}
+	return bits, nil
+}
+
+// encryptBits encrypts the bits of the decomposition of x according to
+// weights, each one with a proof of well-formedness. It also returns the
+// randomness of the recombination of the bits (see recombineBits), which
+// the caller should Destroy. reader should be hedged with the secrets of
+// the caller, since the bits alone are easily guessed.
+func encryptBits(
+	reader io.Reader,
+	x uint64,
+	weights []uint64,
+	pk *arith.CurvePoint) ([]EncryptedVote, []ProofVoteWellFormedness, *arith.SecretScalar, error) {
+	digits, err := decompose(x, weights)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bits := make([]EncryptedVote, len(weights))
+	proofs := make([]ProofVoteWellFormedness, len(weights))
+	sum := new(arith.SecretScalar)
+	weighted := new(arith.SecretScalar)
+	defer weighted.Destroy()
+	for i, bit := range digits {
+		encryptedBit, r, err := bit.encrypt(reader, pk)
+		if err != nil {
+			sum.Destroy()
+			return nil, nil, nil, err
+		}
+		proof, err := ProveVoteWellFormedness(reader, encryptedBit, bit, r, pk)
+		if err != nil {
+			r.Destroy()
+			sum.Destroy()
+			return nil, nil, nil, err
+		}
+		// sum += weights[i] * r
+		weighted.SetScalar(new(arith.Scalar).SetUint64(weights[i]))
+		weighted.Mul(weighted, r)
+		sum.Add(sum, weighted)
+		r.Destroy()
+		bits[i].Set(encryptedBit)
+		proofs[i].Set(proof)
+	}
+	return bits, proofs, sum, nil
+}
+
+// verifyBits verifies that each one of bits encrypts 0 or 1.
+func verifyBits(v *Verifier, bits []EncryptedVote, proofs []ProofVoteWellFormedness, pk *arith.CurvePoint) error {
+	if len(bits) != len(proofs) {
+		return fmt.Errorf("%d encrypted bits but %d proofs", len(bits), len(proofs))
+	}
+	for i := range bits {
+		if err := v.VerifyVoteWellFormedness(&proofs[i], &bits[i], pk); err != nil {
+			return fmt.Errorf("bit %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// recombineBits returns the encryption of sum(weights[i] * bits[i]).
+func recombineBits(bits []EncryptedVote, weights []uint64) *EncryptedVote {
+	res := NewEncryptedVote()
+	scaled := new(EncryptedVote)
+	k := new(arith.Scalar)
+	for i := range bits {
+		scaled.Scale(&bits[i], k.SetUint64(weights[i]))
+		res.Add(res, scaled)
+	}
+	return res
+}
+
+// proveEncryptsZero proves that encryptedVote is an encryption of 0 under
+// pk with randomness r, i.e. that log_g(A) = log_pk(B) = r. This is the
+// same statement as a proof of correct decryption to 0 of (pk, B) under
+// public key A, with r in place of the secret key, so the proof is a
+// ProofCorrectDecryption.
+func proveEncryptsZero(
+	reader io.Reader,
+	encryptedVote *EncryptedVote,
+	r *arith.SecretScalar,
+	pk *arith.CurvePoint) (*ProofCorrectDecryption, error) {
+	keyPair := new(KeyPair)
+	defer keyPair.Destroy()
+	keyPair.Pk.Set(&encryptedVote.A)
+	keyPair.Sk.Set(r)
+	swapped := new(EncryptedVote)
+	swapped.A.Set(pk)
+	swapped.B.Set(&encryptedVote.B)
+	return ProveCorrectDecryption(reader, swapped, keyPair)
+}
+
+// verifyEncryptsZero verifies a proof generated by proveEncryptsZero.
+func verifyEncryptsZero(
+	v *Verifier,
+	proof *ProofCorrectDecryption,
+	encryptedVote *EncryptedVote,
+	pk *arith.CurvePoint) error {
+	swapped := new(EncryptedVote)
+	swapped.A.Set(pk)
+	swapped.B.Set(&encryptedVote.B)
+	return v.VerifyCorrectDecryption(proof, swapped, No, &encryptedVote.A)
+}
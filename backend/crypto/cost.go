@@ -0,0 +1,77 @@
+package crypto
+
+// Gas costs of the EVM precompiles for bn256 after EIP-1108, and of the
+// KECCAK256 opcode.
+const (
+	ECAddGas         = 150
+	ECMulGas         = 6000
+	Keccak256Gas     = 30
+	Keccak256WordGas = 6
+)
+
+// keccakWordsPerPoint is the number of 32 bytes words hashed for each curve
+// point, i.e. its two coordinates.
+const keccakWordsPerPoint = 2
+
+// VerificationCost counts the operations performed by an on-chain verifier
+// of a proof, such as the Cryptography contract: calls to the ECADD (0x06)
+// and ECMUL (0x07) precompiles, and Fiat-Shamir hashes with KECCAK256.
+// Negations of points are free, since they only change the y coordinate.
+// Calldata, memory expansion and the overhead of the calls are not
+// counted.
+type VerificationCost struct {
+	ECAdd       int `json:"ecAdd"`
+	ECMul       int `json:"ecMul"`
+	Keccak      int `json:"keccak"`
+	KeccakWords int `json:"keccakWords"`
+}
+
+// Costs of the verification of the proofs of the Helios protocol, as
+// implemented by the Cryptography contract, and of ProofSquare and
+// ProofPlaintextEquality.
+var (
+	SkKnowledgeCost = VerificationCost{
+		ECAdd: 1, ECMul: 2, Keccak: 1, KeccakWords: 2 * keccakWordsPerPoint,
+	}
+	CorrectDecryptionCost = VerificationCost{
+		ECAdd: 3, ECMul: 5, Keccak: 1, KeccakWords: 5 * keccakWordsPerPoint,
+	}
+	VoteWellFormednessCost = VerificationCost{
+		ECAdd: 5, ECMul: 8, Keccak: 1, KeccakWords: 7 * keccakWordsPerPoint,
+	}
+	SquareCost = VerificationCost{
+		ECAdd: 7, ECMul: 11, Keccak: 1, KeccakWords: 9 * keccakWordsPerPoint,
+	}
+	PlaintextEqualityCost = VerificationCost{
+		ECAdd: 6, ECMul: 9, Keccak: 1, KeccakWords: 10 * keccakWordsPerPoint,
+	}
+)
+
+// Add returns the cost of performing the operations of both c and d.
+func (c VerificationCost) Add(d VerificationCost) VerificationCost {
+	return VerificationCost{
+		ECAdd:       c.ECAdd + d.ECAdd,
+		ECMul:       c.ECMul + d.ECMul,
+		Keccak:      c.Keccak + d.Keccak,
+		KeccakWords: c.KeccakWords + d.KeccakWords,
+	}
+}
+
+// Times returns the cost of performing the operations of c n times.
+func (c VerificationCost) Times(n int) VerificationCost {
+	return VerificationCost{
+		ECAdd:       n * c.ECAdd,
+		ECMul:       n * c.ECMul,
+		Keccak:      n * c.Keccak,
+		KeccakWords: n * c.KeccakWords,
+	}
+}
+
+// Gas returns the gas spent by the precompiles and the hashes counted by
+// c.
+func (c VerificationCost) Gas() uint64 {
+	return uint64(c.ECAdd)*ECAddGas +
+		uint64(c.ECMul)*ECMulGas +
+		uint64(c.Keccak)*Keccak256Gas +
+		uint64(c.KeccakWords)*Keccak256WordGas
+}
@@ -0,0 +1,27 @@
+package crypto
+
+import "testing"
+
+func TestVerificationCostGas(t *testing.T) {
+	tests := map[string]struct {
+		cost     VerificationCost
+		expected uint64
+	}{
+		"nothing":              {cost: VerificationCost{}, expected: 0},
+		"sk knowledge":         {cost: SkKnowledgeCost, expected: 150 + 2*6000 + 30 + 4*6},
+		"correct decryption":   {cost: CorrectDecryptionCost, expected: 3*150 + 5*6000 + 30 + 10*6},
+		"vote well-formedness": {cost: VoteWellFormednessCost, expected: 5*150 + 8*6000 + 30 + 14*6},
+		"plaintext equality":   {cost: PlaintextEqualityCost, expected: 6*150 + 9*6000 + 30 + 20*6},
+		"sum": {
+			cost:     SkKnowledgeCost.Add(CorrectDecryptionCost.Times(2)),
+			expected: 7*150 + 12*6000 + 3*30 + 24*6,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.cost.Gas(); got != tc.expected {
+				t.Fatalf("expected: %d, got: %d", tc.expected, got)
+			}
+		})
+	}
+}
@@ -5,9 +5,13 @@
 // that ElGamal cryptosystem is instantiated using elliptic curves
 // instead of integer factorization.
 //
-// At the moment the package has the following limitations:
-//   - only yes-no votes are supported (no multi-choice voting)
-//   - tallying is performed by a single entity (no threshold decryption)
+// Besides yes-no votes, the package supports ballots with several options:
+// fractional votes (FractionalBallot), approval votes (ApprovalBallot),
+// score votes (ScoreBallot) and quadratic votes (QuadraticBallot), whose
+// entries are proven in range with ProofRange and tallied per option.
+//
+// At the moment tallying is performed by a single entity (no threshold
+// decryption).
 //
 // [Cryptographic Voting]: https://eprint.iacr.org/2016/765.pdf
 package crypto
@@ -30,12 +34,24 @@ func NewKeyPairWithProof(r io.Reader) (*KeyPair, *ProofSkKnowledge, error) {
 	return keyPair, proof, nil
 }
 
-func EncryptVoteWithProof(r io.Reader, vote int64, pk *arith.CurvePoint) (*EncryptedVote, *ProofVoteWellFormedness, error) {
-	encryptedVote, secret, err := Vote(vote).Encrypt(r, pk)
+// EncryptVoteWithProof encrypts vote, hedging the randomness with
+// voterSecret (see NewVoterSecret), and proves it well-formed.
+func EncryptVoteWithProof(
+	r io.Reader,
+	vote int64,
+	voterSecret *arith.SecretScalar,
+	pk *arith.CurvePoint) (*EncryptedVote, *ProofVoteWellFormedness, error) {
+	nonces, err := newVoterReader(r, voterSecret, pk)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer nonces.destroy()
+	encryptedVote, secret, err := Vote(vote).encrypt(nonces, pk)
 	if err != nil {
 		return nil, nil, err
 	}
-	proof, err := ProveVoteWellFormedness(r, encryptedVote, Vote(vote), secret, pk)
+	defer secret.Destroy()
+	proof, err := ProveVoteWellFormedness(nonces, encryptedVote, Vote(vote), secret, pk)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,327 @@
+package cryptopb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+)
+
+const numBytesCoordinate = arith.NumBytesCurvePoint / 2
+
+// NewScalar returns the protobuf encoding of s.
+func NewScalar(s *arith.Scalar) *Scalar {
+	return &Scalar{Value: marshal(s)}
+}
+
+// Decode returns the scalar encoded by m. It fails if the value is not
+// exactly 32 bytes long, or if it is not below the order of bn256.G1.
+func (m *Scalar) Decode() (*arith.Scalar, error) {
+	if m == nil {
+		return nil, errors.New("missing scalar")
+	}
+	res := new(arith.Scalar)
+	if err := res.UnmarshalBinary(m.Value); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// NewSecretScalar returns the protobuf encoding of s.
+func NewSecretScalar(s *arith.SecretScalar) *Scalar {
+	return &Scalar{Value: marshal(s)}
+}
+
+// DecodeSecret is like Decode, but returns the scalar as a SecretScalar.
+func (m *Scalar) DecodeSecret() (*arith.SecretScalar, error) {
+	if m == nil {
+		return nil, errors.New("missing scalar")
+	}
+	res := new(arith.SecretScalar)
+	if err := res.UnmarshalBinary(m.Value); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// NewChallenge returns the protobuf encoding of c.
+func NewChallenge(c *arith.Challenge) *Challenge {
+	return &Challenge{Value: marshal(c)}
+}
+
+// Decode returns the challenge encoded by m. It fails if the value is not
+// exactly 16 bytes long.
+func (m *Challenge) Decode() (*arith.Challenge, error) {
+	if m == nil {
+		return nil, errors.New("missing challenge")
+	}
+	res := new(arith.Challenge)
+	if err := res.UnmarshalBinary(m.Value); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// NewCurvePoint returns the protobuf encoding of p.
+func NewCurvePoint(p *arith.CurvePoint) *CurvePoint {
+	data := marshal(p)
+	return &CurvePoint{
+		X: data[:numBytesCoordinate],
+		Y: data[numBytesCoordinate:],
+	}
+}
+
+// Decode returns the curve point encoded by m. It fails if any coordinate
+// is not exactly 32 bytes long or is not below the base field modulus, or
+// if the point is not on the curve.
+func (m *CurvePoint) Decode() (*arith.CurvePoint, error) {
+	if m == nil {
+		return nil, errors.New("missing curve point")
+	}
+	if len(m.X) != numBytesCoordinate || len(m.Y) != numBytesCoordinate {
+		return nil, fmt.Errorf("curve point coordinates should be represented with %d bytes", numBytesCoordinate)
+	}
+	data := make([]byte, 0, arith.NumBytesCurvePoint)
+	data = append(data, m.X...)
+	data = append(data, m.Y...)
+	res := new(arith.CurvePoint)
+	if err := res.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// NewKeyPair returns the protobuf encoding of keyPair.
+func NewKeyPair(keyPair *crypto.KeyPair) *KeyPair {
+	return &KeyPair{
+		Pk: NewCurvePoint(&keyPair.Pk),
+		Sk: NewSecretScalar(&keyPair.Sk),
+	}
+}
+
+// Decode returns the key pair encoded by m. Besides validating each field,
+// it checks that pk matches sk.
+func (m *KeyPair) Decode() (*crypto.KeyPair, error) {
+	if m == nil {
+		return nil, errors.New("missing key pair")
+	}
+	pk, err := m.Pk.Decode()
+	if err != nil {
+		return nil, newFieldParsingError("pk", err)
+	}
+	sk, err := m.Sk.DecodeSecret()
+	if err != nil {
+		return nil, newFieldParsingError("sk", err)
+	}
+	defer sk.Destroy()
+	if !new(arith.CurvePoint).ScalarBaseMultSecret(sk).Equal(pk) {
+		return nil, errors.New("public key does not match secret key")
+	}
+	res := new(crypto.KeyPair)
+	res.Pk.Set(pk)
+	res.Sk.Set(sk)
+	return res, nil
+}
+
+// NewEncryptedVote returns the protobuf encoding of vote.
+func NewEncryptedVote(vote *crypto.EncryptedVote) *EncryptedVote {
+	return &EncryptedVote{
+		A: NewCurvePoint(&vote.A),
+		B: NewCurvePoint(&vote.B),
+	}
+}
+
+// Decode returns the encrypted vote encoded by m.
+func (m *EncryptedVote) Decode() (*crypto.EncryptedVote, error) {
+	if m == nil {
+		return nil, errors.New("missing encrypted vote")
+	}
+	a, err := m.A.Decode()
+	if err != nil {
+		return nil, newFieldParsingError("a", err)
+	}
+	b, err := m.B.Decode()
+	if err != nil {
+		return nil, newFieldParsingError("b", err)
+	}
+	res := new(crypto.EncryptedVote)
+	res.A.Set(a)
+	res.B.Set(b)
+	return res, nil
+}
+
+// NewProofSkKnowledge returns the protobuf encoding of proof.
+func NewProofSkKnowledge(proof *crypto.ProofSkKnowledge) *ProofSkKnowledge {
+	return &ProofSkKnowledge{
+		S: NewScalar(&proof.S),
+		C: NewChallenge(&proof.C),
+	}
+}
+
+// Decode returns the proof encoded by m.
+func (m *ProofSkKnowledge) Decode() (*crypto.ProofSkKnowledge, error) {
+	if m == nil {
+		return nil, errors.New("missing proof")
+	}
+	s, err := m.S.Decode()
+	if err != nil {
+		return nil, newFieldParsingError("s", err)
+	}
+	c, err := m.C.Decode()
+	if err != nil {
+		return nil, newFieldParsingError("c", err)
+	}
+	res := new(crypto.ProofSkKnowledge)
+	res.S.Set(s)
+	res.C.Set(c)
+	return res, nil
+}
+
+// NewProofCorrectDecryption returns the protobuf encoding of proof.
+func NewProofCorrectDecryption(proof *crypto.ProofCorrectDecryption) *ProofCorrectDecryption {
+	return &ProofCorrectDecryption{
+		S: NewScalar(&proof.S),
+		C: NewChallenge(&proof.C),
+	}
+}
+
+// Decode returns the proof encoded by m.
+func (m *ProofCorrectDecryption) Decode() (*crypto.ProofCorrectDecryption, error) {
+	if m == nil {
+		return nil, errors.New("missing proof")
+	}
+	s, err := m.S.Decode()
+	if err != nil {
+		return nil, newFieldParsingError("s", err)
+	}
+	c, err := m.C.Decode()
+	if err != nil {
+		return nil, newFieldParsingError("c", err)
+	}
+	res := new(crypto.ProofCorrectDecryption)
+	res.S.Set(s)
+	res.C.Set(c)
+	return res, nil
+}
+
+// NewProofVoteWellFormedness returns the protobuf encoding of proof.
+func NewProofVoteWellFormedness(proof *crypto.ProofVoteWellFormedness) *ProofVoteWellFormedness {
+	return &ProofVoteWellFormedness{
+		R0: NewScalar(&proof.R0),
+		R1: NewScalar(&proof.R1),
+		C0: NewChallenge(&proof.C0),
+		C1: NewChallenge(&proof.C1),
+	}
+}
+
+// Decode returns the proof encoded by m.
+func (m *ProofVoteWellFormedness) Decode() (*crypto.ProofVoteWellFormedness, error) {
+	if m == nil {
+		return nil, errors.New("missing proof")
+	}
+	r0, err := m.R0.Decode()
+	if err != nil {
+		return nil, newFieldParsingError("r0", err)
+	}
+	r1, err := m.R1.Decode()
+	if err != nil {
+		return nil, newFieldParsingError("r1", err)
+	}
+	c0, err := m.C0.Decode()
+	if err != nil {
+		return nil, newFieldParsingError("c0", err)
+	}
+	c1, err := m.C1.Decode()
+	if err != nil {
+		return nil, newFieldParsingError("c1", err)
+	}
+	res := new(crypto.ProofVoteWellFormedness)
+	res.R0.Set(r0)
+	res.R1.Set(r1)
+	res.C0.Set(c0)
+	res.C1.Set(c1)
+	return res, nil
+}
+
+// NewBallot returns the protobuf encoding of an encrypted vote together
+// with its proof of well-formedness.
+func NewBallot(vote *crypto.EncryptedVote, proof *crypto.ProofVoteWellFormedness) *Ballot {
+	return &Ballot{
+		EncryptedVote: NewEncryptedVote(vote),
+		Proof:         NewProofVoteWellFormedness(proof),
+	}
+}
+
+// Decode returns the encrypted vote and the proof encoded by m. The proof
+// is not verified.
+func (m *Ballot) Decode() (*crypto.EncryptedVote, *crypto.ProofVoteWellFormedness, error) {
+	if m == nil {
+		return nil, nil, errors.New("missing ballot")
+	}
+	vote, err := m.EncryptedVote.Decode()
+	if err != nil {
+		return nil, nil, newFieldParsingError("encrypted_vote", err)
+	}
+	proof, err := m.Proof.Decode()
+	if err != nil {
+		return nil, nil, newFieldParsingError("proof", err)
+	}
+	return vote, proof, nil
+}
+
+// NewDecryptedTally returns the protobuf encoding of the result of tallying,
+// together with its proof of correct decryption.
+func NewDecryptedTally(tally *crypto.EncryptedVote, result int64, proof *crypto.ProofCorrectDecryption) *DecryptedTally {
+	return &DecryptedTally{
+		Tally:  NewEncryptedVote(tally),
+		Result: result,
+		Proof:  NewProofCorrectDecryption(proof),
+	}
+}
+
+// Decode returns the encrypted tally, the result and the proof encoded by
+// m. The proof is not verified.
+func (m *DecryptedTally) Decode() (*crypto.EncryptedVote, int64, *crypto.ProofCorrectDecryption, error) {
+	if m == nil {
+		return nil, 0, nil, errors.New("missing decrypted tally")
+	}
+	tally, err := m.Tally.Decode()
+	if err != nil {
+		return nil, 0, nil, newFieldParsingError("tally", err)
+	}
+	if m.Result < 0 {
+		return nil, 0, nil, newFieldParsingError("result", errors.New("result should be non-negative"))
+	}
+	proof, err := m.Proof.Decode()
+	if err != nil {
+		return nil, 0, nil, newFieldParsingError("proof", err)
+	}
+	return tally, m.Result, proof, nil
+}
+
+func marshal(m interface{ MarshalBinary() ([]byte, error) }) []byte {
+	// MarshalBinary never fails for the types in package arith
+	data, _ := m.MarshalBinary()
+	return data
+}
+
+type fieldParsingError struct {
+	key string
+	err error
+}
+
+func newFieldParsingError(key string, err error) *fieldParsingError {
+	return &fieldParsingError{
+		key: key,
+		err: err,
+	}
+}
+
+func (e *fieldParsingError) Error() string {
+	return fmt.Sprintf("error parsing field %s: %v", e.key, e.err)
+}
+
+func (e *fieldParsingError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,181 @@
+package cryptopb
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"google.golang.org/protobuf/proto"
+)
+
+// testVoterSecret is the voter secret of the encryptions of the tests.
+var testVoterSecret, _ = crypto.NewVoterSecret([]byte("e-voting test voter"))
+
+func TestEncodeDecodeBallot(t *testing.T) {
+	keyPair, _, err := crypto.NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedVote, proof, err := crypto.EncryptVoteWithProof(rand.Reader, int64(crypto.Yes), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := proto.Marshal(NewBallot(encryptedVote, proof))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ballot := new(Ballot)
+	if err := proto.Unmarshal(m, ballot); err != nil {
+		t.Fatal(err)
+	}
+	gotVote, gotProof, err := ballot.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := crypto.VerifyVoteWellFormedness(gotProof, gotVote, &keyPair.Pk); err != nil {
+		t.Fatal(err)
+	}
+	if !gotVote.A.Equal(&encryptedVote.A) || !gotVote.B.Equal(&encryptedVote.B) {
+		t.Fatal("decoded encrypted vote is different from the original one")
+	}
+}
+
+func TestEncodeDecodeDecryptedTally(t *testing.T) {
+	keyPair, _, err := crypto.NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tally, _, err := crypto.Vote(3).Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, proof, err := crypto.DecryptTallyWithProof(rand.Reader, tally, 5, keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := proto.Marshal(NewDecryptedTally(tally, result, proof))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decryptedTally := new(DecryptedTally)
+	if err := proto.Unmarshal(m, decryptedTally); err != nil {
+		t.Fatal(err)
+	}
+	gotTally, gotResult, gotProof, err := decryptedTally.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := crypto.VerifyCorrectDecryption(gotProof, gotTally, crypto.Vote(gotResult), &keyPair.Pk); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEncodeDecodeKeyPair(t *testing.T) {
+	keyPair, proof, err := crypto.NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotKeyPair, err := NewKeyPair(keyPair).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotKeyPair.Pk.Equal(&keyPair.Pk) || !gotKeyPair.Sk.Equal(&keyPair.Sk) {
+		t.Fatal("decoded key pair is different from the original one")
+	}
+	gotProof, err := NewProofSkKnowledge(proof).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := crypto.VerifySkKnowledge(gotProof, &gotKeyPair.Pk); err != nil {
+		t.Fatal(err)
+	}
+
+	otherKeyPair, err := crypto.NewKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mismatched := NewKeyPair(keyPair)
+	mismatched.Sk = NewSecretScalar(&otherKeyPair.Sk)
+	if _, err := mismatched.Decode(); err == nil {
+		t.Fatal("successfully decoded a key pair whose pk does not match sk")
+	}
+}
+
+func TestDecodeInvalidScalar(t *testing.T) {
+	tests := map[string]*Scalar{
+		"missing":      nil,
+		"too short":    {Value: make([]byte, arith.NumBytesScalar-1)},
+		"too long":     {Value: make([]byte, arith.NumBytesScalar+1)},
+		"over modulus": {Value: bn256.Order.Bytes()},
+	}
+	for name, m := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := m.Decode(); err == nil {
+				t.Fatalf("successfully decoded invalid scalar: %s", name)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalidChallenge(t *testing.T) {
+	tests := map[string]*Challenge{
+		"missing":   nil,
+		"too short": {Value: make([]byte, arith.NumBytesChallenge-1)},
+		"too long":  {Value: make([]byte, arith.NumBytesChallenge+1)},
+	}
+	for name, m := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := m.Decode(); err == nil {
+				t.Fatalf("successfully decoded invalid challenge: %s", name)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalidCurvePoint(t *testing.T) {
+	g := NewCurvePoint(new(arith.CurvePoint).ScalarBaseMult(arith.NewScalar(big.NewInt(1))))
+	p := make([]byte, numBytesCoordinate)
+	bn256.P.FillBytes(p)
+
+	tests := map[string]*CurvePoint{
+		"missing":             nil,
+		"short x":             {X: g.X[1:], Y: g.Y},
+		"long y":              {X: g.X, Y: append([]byte{0}, g.Y...)},
+		"outside curve":       {X: g.X, Y: g.X},
+		"x equal to modulus":  {X: p, Y: g.Y},
+		"missing coordinates": {},
+	}
+	for name, m := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := m.Decode(); err == nil {
+				t.Fatalf("successfully decoded invalid curve point: %s", name)
+			}
+		})
+	}
+
+	infinity := &CurvePoint{X: make([]byte, numBytesCoordinate), Y: make([]byte, numBytesCoordinate)}
+	if _, err := infinity.Decode(); err != nil {
+		t.Fatalf("cannot decode point at infinity: %v", err)
+	}
+}
+
+func TestDecodeIncompleteProof(t *testing.T) {
+	keyPair, err := crypto.NewKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, proof, err := crypto.EncryptVoteWithProof(rand.Reader, int64(crypto.No), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewProofVoteWellFormedness(proof)
+	m.C1 = nil
+	if _, err := m.Decode(); err == nil {
+		t.Fatal("successfully decoded a proof with a missing field")
+	}
+}
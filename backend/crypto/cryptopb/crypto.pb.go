@@ -0,0 +1,861 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: crypto/cryptopb/crypto.proto
+
+package cryptopb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Scalar is an element of the scalar field of bn256.G1 (32 bytes).
+type Scalar struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Scalar) Reset() {
+	*x = Scalar{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_cryptopb_crypto_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Scalar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scalar) ProtoMessage() {}
+
+func (x *Scalar) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_cryptopb_crypto_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scalar.ProtoReflect.Descriptor instead.
+func (*Scalar) Descriptor() ([]byte, []int) {
+	return file_crypto_cryptopb_crypto_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Scalar) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Challenge is a 128-bit Fiat-Shamir challenge (16 bytes).
+type Challenge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Challenge) Reset() {
+	*x = Challenge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_cryptopb_crypto_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Challenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Challenge) ProtoMessage() {}
+
+func (x *Challenge) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_cryptopb_crypto_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Challenge.ProtoReflect.Descriptor instead.
+func (*Challenge) Descriptor() ([]byte, []int) {
+	return file_crypto_cryptopb_crypto_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Challenge) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// CurvePoint is a point of bn256.G1, in affine coordinates (32 bytes each).
+// The point at infinity is encoded as (0, 0).
+type CurvePoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X []byte `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y []byte `protobuf:"bytes,2,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *CurvePoint) Reset() {
+	*x = CurvePoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_cryptopb_crypto_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CurvePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurvePoint) ProtoMessage() {}
+
+func (x *CurvePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_cryptopb_crypto_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurvePoint.ProtoReflect.Descriptor instead.
+func (*CurvePoint) Descriptor() ([]byte, []int) {
+	return file_crypto_cryptopb_crypto_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CurvePoint) GetX() []byte {
+	if x != nil {
+		return x.X
+	}
+	return nil
+}
+
+func (x *CurvePoint) GetY() []byte {
+	if x != nil {
+		return x.Y
+	}
+	return nil
+}
+
+type KeyPair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pk *CurvePoint `protobuf:"bytes,1,opt,name=pk,proto3" json:"pk,omitempty"`
+	Sk *Scalar     `protobuf:"bytes,2,opt,name=sk,proto3" json:"sk,omitempty"`
+}
+
+func (x *KeyPair) Reset() {
+	*x = KeyPair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_cryptopb_crypto_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyPair) ProtoMessage() {}
+
+func (x *KeyPair) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_cryptopb_crypto_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyPair.ProtoReflect.Descriptor instead.
+func (*KeyPair) Descriptor() ([]byte, []int) {
+	return file_crypto_cryptopb_crypto_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *KeyPair) GetPk() *CurvePoint {
+	if x != nil {
+		return x.Pk
+	}
+	return nil
+}
+
+func (x *KeyPair) GetSk() *Scalar {
+	if x != nil {
+		return x.Sk
+	}
+	return nil
+}
+
+type EncryptedVote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	A *CurvePoint `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B *CurvePoint `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+}
+
+func (x *EncryptedVote) Reset() {
+	*x = EncryptedVote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_cryptopb_crypto_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncryptedVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptedVote) ProtoMessage() {}
+
+func (x *EncryptedVote) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_cryptopb_crypto_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptedVote.ProtoReflect.Descriptor instead.
+func (*EncryptedVote) Descriptor() ([]byte, []int) {
+	return file_crypto_cryptopb_crypto_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EncryptedVote) GetA() *CurvePoint {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *EncryptedVote) GetB() *CurvePoint {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+type ProofSkKnowledge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	S *Scalar    `protobuf:"bytes,1,opt,name=s,proto3" json:"s,omitempty"`
+	C *Challenge `protobuf:"bytes,2,opt,name=c,proto3" json:"c,omitempty"`
+}
+
+func (x *ProofSkKnowledge) Reset() {
+	*x = ProofSkKnowledge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_cryptopb_crypto_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProofSkKnowledge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofSkKnowledge) ProtoMessage() {}
+
+func (x *ProofSkKnowledge) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_cryptopb_crypto_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofSkKnowledge.ProtoReflect.Descriptor instead.
+func (*ProofSkKnowledge) Descriptor() ([]byte, []int) {
+	return file_crypto_cryptopb_crypto_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProofSkKnowledge) GetS() *Scalar {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+func (x *ProofSkKnowledge) GetC() *Challenge {
+	if x != nil {
+		return x.C
+	}
+	return nil
+}
+
+type ProofCorrectDecryption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	S *Scalar    `protobuf:"bytes,1,opt,name=s,proto3" json:"s,omitempty"`
+	C *Challenge `protobuf:"bytes,2,opt,name=c,proto3" json:"c,omitempty"`
+}
+
+func (x *ProofCorrectDecryption) Reset() {
+	*x = ProofCorrectDecryption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_cryptopb_crypto_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProofCorrectDecryption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofCorrectDecryption) ProtoMessage() {}
+
+func (x *ProofCorrectDecryption) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_cryptopb_crypto_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofCorrectDecryption.ProtoReflect.Descriptor instead.
+func (*ProofCorrectDecryption) Descriptor() ([]byte, []int) {
+	return file_crypto_cryptopb_crypto_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProofCorrectDecryption) GetS() *Scalar {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+func (x *ProofCorrectDecryption) GetC() *Challenge {
+	if x != nil {
+		return x.C
+	}
+	return nil
+}
+
+type ProofVoteWellFormedness struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	R0 *Scalar    `protobuf:"bytes,1,opt,name=r0,proto3" json:"r0,omitempty"`
+	R1 *Scalar    `protobuf:"bytes,2,opt,name=r1,proto3" json:"r1,omitempty"`
+	C0 *Challenge `protobuf:"bytes,3,opt,name=c0,proto3" json:"c0,omitempty"`
+	C1 *Challenge `protobuf:"bytes,4,opt,name=c1,proto3" json:"c1,omitempty"`
+}
+
+func (x *ProofVoteWellFormedness) Reset() {
+	*x = ProofVoteWellFormedness{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_cryptopb_crypto_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProofVoteWellFormedness) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofVoteWellFormedness) ProtoMessage() {}
+
+func (x *ProofVoteWellFormedness) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_cryptopb_crypto_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofVoteWellFormedness.ProtoReflect.Descriptor instead.
+func (*ProofVoteWellFormedness) Descriptor() ([]byte, []int) {
+	return file_crypto_cryptopb_crypto_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProofVoteWellFormedness) GetR0() *Scalar {
+	if x != nil {
+		return x.R0
+	}
+	return nil
+}
+
+func (x *ProofVoteWellFormedness) GetR1() *Scalar {
+	if x != nil {
+		return x.R1
+	}
+	return nil
+}
+
+func (x *ProofVoteWellFormedness) GetC0() *Challenge {
+	if x != nil {
+		return x.C0
+	}
+	return nil
+}
+
+func (x *ProofVoteWellFormedness) GetC1() *Challenge {
+	if x != nil {
+		return x.C1
+	}
+	return nil
+}
+
+// Ballot is an encrypted vote, together with its proof of well-formedness.
+type Ballot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EncryptedVote *EncryptedVote           `protobuf:"bytes,1,opt,name=encrypted_vote,json=encryptedVote,proto3" json:"encrypted_vote,omitempty"`
+	Proof         *ProofVoteWellFormedness `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *Ballot) Reset() {
+	*x = Ballot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_cryptopb_crypto_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ballot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ballot) ProtoMessage() {}
+
+func (x *Ballot) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_cryptopb_crypto_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ballot.ProtoReflect.Descriptor instead.
+func (*Ballot) Descriptor() ([]byte, []int) {
+	return file_crypto_cryptopb_crypto_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Ballot) GetEncryptedVote() *EncryptedVote {
+	if x != nil {
+		return x.EncryptedVote
+	}
+	return nil
+}
+
+func (x *Ballot) GetProof() *ProofVoteWellFormedness {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// DecryptedTally is the result of tallying, together with its proof of
+// correct decryption.
+type DecryptedTally struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tally  *EncryptedVote          `protobuf:"bytes,1,opt,name=tally,proto3" json:"tally,omitempty"`
+	Result int64                   `protobuf:"varint,2,opt,name=result,proto3" json:"result,omitempty"`
+	Proof  *ProofCorrectDecryption `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *DecryptedTally) Reset() {
+	*x = DecryptedTally{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_cryptopb_crypto_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecryptedTally) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecryptedTally) ProtoMessage() {}
+
+func (x *DecryptedTally) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_cryptopb_crypto_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecryptedTally.ProtoReflect.Descriptor instead.
+func (*DecryptedTally) Descriptor() ([]byte, []int) {
+	return file_crypto_cryptopb_crypto_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DecryptedTally) GetTally() *EncryptedVote {
+	if x != nil {
+		return x.Tally
+	}
+	return nil
+}
+
+func (x *DecryptedTally) GetResult() int64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *DecryptedTally) GetProof() *ProofCorrectDecryption {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+var File_crypto_cryptopb_crypto_proto protoreflect.FileDescriptor
+
+var file_crypto_cryptopb_crypto_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x70,
+	0x62, 0x2f, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11,
+	0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76,
+	0x31, 0x22, 0x1e, 0x0a, 0x06, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x21, 0x0a, 0x09, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x28, 0x0a, 0x0a, 0x43, 0x75, 0x72, 0x76, 0x65, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x78,
+	0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x79, 0x22, 0x63,
+	0x0a, 0x07, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x69, 0x72, 0x12, 0x2d, 0x0a, 0x02, 0x70, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x02, 0x70, 0x6b, 0x12, 0x29, 0x0a, 0x02, 0x73, 0x6b, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x52,
+	0x02, 0x73, 0x6b, 0x22, 0x69, 0x0a, 0x0d, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64,
+	0x56, 0x6f, 0x74, 0x65, 0x12, 0x2b, 0x0a, 0x01, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x01,
+	0x61, 0x12, 0x2b, 0x0a, 0x01, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65,
+	0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x01, 0x62, 0x22, 0x67,
+	0x0a, 0x10, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x53, 0x6b, 0x4b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x12, 0x27, 0x0a, 0x01, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x52, 0x01, 0x73, 0x12, 0x2a, 0x0a, 0x01, 0x63,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c,
+	0x65, 0x6e, 0x67, 0x65, 0x52, 0x01, 0x63, 0x22, 0x6d, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x44, 0x65, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x27, 0x0a, 0x01, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65,
+	0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x52, 0x01, 0x73, 0x12, 0x2a, 0x0a, 0x01, 0x63, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x52, 0x01, 0x63, 0x22, 0xcb, 0x01, 0x0a, 0x17, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x56, 0x6f, 0x74, 0x65, 0x57, 0x65, 0x6c, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x6e, 0x65,
+	0x73, 0x73, 0x12, 0x29, 0x0a, 0x02, 0x72, 0x30, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x52, 0x02, 0x72, 0x30, 0x12, 0x29, 0x0a,
+	0x02, 0x72, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65, 0x76, 0x6f, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63,
+	0x61, 0x6c, 0x61, 0x72, 0x52, 0x02, 0x72, 0x31, 0x12, 0x2c, 0x0a, 0x02, 0x63, 0x30, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x52, 0x02, 0x63, 0x30, 0x12, 0x2c, 0x0a, 0x02, 0x63, 0x31, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x52, 0x02, 0x63, 0x31, 0x22, 0x93, 0x01, 0x0a, 0x06, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x12,
+	0x47, 0x0a, 0x0e, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x6f, 0x74,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x0d, 0x65, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x40, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x56, 0x6f, 0x74, 0x65, 0x57, 0x65, 0x6c, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x6e,
+	0x65, 0x73, 0x73, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0xa1, 0x01, 0x0a, 0x0e, 0x44,
+	0x65, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x54, 0x61, 0x6c, 0x6c, 0x79, 0x12, 0x36, 0x0a,
+	0x05, 0x74, 0x61, 0x6c, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65,
+	0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x05,
+	0x74, 0x61, 0x6c, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x3f, 0x0a,
+	0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x65,
+	0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x44, 0x65, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x3d,
+	0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x48, 0x6f, 0x72,
+	0x69, 0x7a, 0x65, 0x6e, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x2d, 0x76, 0x6f, 0x74, 0x69, 0x6e,
+	0x67, 0x2d, 0x70, 0x6f, 0x63, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x2f, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_crypto_cryptopb_crypto_proto_rawDescOnce sync.Once
+	file_crypto_cryptopb_crypto_proto_rawDescData = file_crypto_cryptopb_crypto_proto_rawDesc
+)
+
+func file_crypto_cryptopb_crypto_proto_rawDescGZIP() []byte {
+	file_crypto_cryptopb_crypto_proto_rawDescOnce.Do(func() {
+		file_crypto_cryptopb_crypto_proto_rawDescData = protoimpl.X.CompressGZIP(file_crypto_cryptopb_crypto_proto_rawDescData)
+	})
+	return file_crypto_cryptopb_crypto_proto_rawDescData
+}
+
+var file_crypto_cryptopb_crypto_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_crypto_cryptopb_crypto_proto_goTypes = []interface{}{
+	(*Scalar)(nil),                  // 0: evoting.crypto.v1.Scalar
+	(*Challenge)(nil),               // 1: evoting.crypto.v1.Challenge
+	(*CurvePoint)(nil),              // 2: evoting.crypto.v1.CurvePoint
+	(*KeyPair)(nil),                 // 3: evoting.crypto.v1.KeyPair
+	(*EncryptedVote)(nil),           // 4: evoting.crypto.v1.EncryptedVote
+	(*ProofSkKnowledge)(nil),        // 5: evoting.crypto.v1.ProofSkKnowledge
+	(*ProofCorrectDecryption)(nil),  // 6: evoting.crypto.v1.ProofCorrectDecryption
+	(*ProofVoteWellFormedness)(nil), // 7: evoting.crypto.v1.ProofVoteWellFormedness
+	(*Ballot)(nil),                  // 8: evoting.crypto.v1.Ballot
+	(*DecryptedTally)(nil),          // 9: evoting.crypto.v1.DecryptedTally
+}
+var file_crypto_cryptopb_crypto_proto_depIdxs = []int32{
+	2,  // 0: evoting.crypto.v1.KeyPair.pk:type_name -> evoting.crypto.v1.CurvePoint
+	0,  // 1: evoting.crypto.v1.KeyPair.sk:type_name -> evoting.crypto.v1.Scalar
+	2,  // 2: evoting.crypto.v1.EncryptedVote.a:type_name -> evoting.crypto.v1.CurvePoint
+	2,  // 3: evoting.crypto.v1.EncryptedVote.b:type_name -> evoting.crypto.v1.CurvePoint
+	0,  // 4: evoting.crypto.v1.ProofSkKnowledge.s:type_name -> evoting.crypto.v1.Scalar
+	1,  // 5: evoting.crypto.v1.ProofSkKnowledge.c:type_name -> evoting.crypto.v1.Challenge
+	0,  // 6: evoting.crypto.v1.ProofCorrectDecryption.s:type_name -> evoting.crypto.v1.Scalar
+	1,  // 7: evoting.crypto.v1.ProofCorrectDecryption.c:type_name -> evoting.crypto.v1.Challenge
+	0,  // 8: evoting.crypto.v1.ProofVoteWellFormedness.r0:type_name -> evoting.crypto.v1.Scalar
+	0,  // 9: evoting.crypto.v1.ProofVoteWellFormedness.r1:type_name -> evoting.crypto.v1.Scalar
+	1,  // 10: evoting.crypto.v1.ProofVoteWellFormedness.c0:type_name -> evoting.crypto.v1.Challenge
+	1,  // 11: evoting.crypto.v1.ProofVoteWellFormedness.c1:type_name -> evoting.crypto.v1.Challenge
+	4,  // 12: evoting.crypto.v1.Ballot.encrypted_vote:type_name -> evoting.crypto.v1.EncryptedVote
+	7,  // 13: evoting.crypto.v1.Ballot.proof:type_name -> evoting.crypto.v1.ProofVoteWellFormedness
+	4,  // 14: evoting.crypto.v1.DecryptedTally.tally:type_name -> evoting.crypto.v1.EncryptedVote
+	6,  // 15: evoting.crypto.v1.DecryptedTally.proof:type_name -> evoting.crypto.v1.ProofCorrectDecryption
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_crypto_cryptopb_crypto_proto_init() }
+func file_crypto_cryptopb_crypto_proto_init() {
+	if File_crypto_cryptopb_crypto_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_crypto_cryptopb_crypto_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Scalar); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_cryptopb_crypto_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Challenge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_cryptopb_crypto_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CurvePoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_cryptopb_crypto_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyPair); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_cryptopb_crypto_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EncryptedVote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_cryptopb_crypto_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProofSkKnowledge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_cryptopb_crypto_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProofCorrectDecryption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_cryptopb_crypto_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProofVoteWellFormedness); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_cryptopb_crypto_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ballot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_cryptopb_crypto_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecryptedTally); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_crypto_cryptopb_crypto_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_crypto_cryptopb_crypto_proto_goTypes,
+		DependencyIndexes: file_crypto_cryptopb_crypto_proto_depIdxs,
+		MessageInfos:      file_crypto_cryptopb_crypto_proto_msgTypes,
+	}.Build()
+	File_crypto_cryptopb_crypto_proto = out.File
+	file_crypto_cryptopb_crypto_proto_rawDesc = nil
+	file_crypto_cryptopb_crypto_proto_goTypes = nil
+	file_crypto_cryptopb_crypto_proto_depIdxs = nil
+}
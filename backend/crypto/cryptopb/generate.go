@@ -0,0 +1,10 @@
+// Package cryptopb defines a canonical protobuf encoding of the types of
+// packages arith and crypto, which is compact, can evolve over time, and is
+// supported by most programming languages. It is the recommended format for
+// archiving ballots and proofs.
+//
+// For each message, a constructor converts from the corresponding Go type,
+// and method Decode converts back to it, strictly validating its content.
+package cryptopb
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative crypto/cryptopb/crypto.proto
@@ -0,0 +1,202 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MinSeedSize is the minimum size of the seed of a MasterKey. 32 bytes are
+// recommended.
+const MinSeedSize = 16
+
+const (
+	masterKeyDomain = "e-voting master key"
+	keyPairDomain   = "e-voting election key"
+)
+
+// MasterKey is the root of a tree of election key pairs, which lets a
+// tallying authority derive a separate KeyPair for each proposal from a
+// single secret.
+//
+// Derivation is similar to BIP-32, but every step is hardened: each node
+// holds a 32 bytes key and a 32 bytes chain code, and the child labeled s is
+// given by HMAC-SHA512(chainCode, 0x00 || key || len(s) || s). Therefore
+// the key pair of a node reveals nothing about its parent or its siblings,
+// and a compromised proposal key does not expose any other proposal.
+//
+// A MasterKey is fully determined by its seed, which is the only secret to
+// back up.
+type MasterKey struct {
+	key       [32]byte
+	chainCode [32]byte
+}
+
+// NewMasterKey derives the master key from seed, which must be at least
+// MinSeedSize bytes of uniformly random data.
+func NewMasterKey(seed []byte) (*MasterKey, error) {
+	if len(seed) < MinSeedSize {
+		return nil, fmt.Errorf("seed should be at least %d bytes long", MinSeedSize)
+	}
+	mac := hmac.New(sha512.New, []byte(masterKeyDomain))
+	mac.Write(seed)
+	digest := mac.Sum(nil)
+	defer wipe(digest)
+	return newMasterKey(digest), nil
+}
+
+func newMasterKey(digest []byte) *MasterKey {
+	m := new(MasterKey)
+	copy(m.key[:], digest[:32])
+	copy(m.chainCode[:], digest[32:])
+	return m
+}
+
+func (m *MasterKey) child(label string) *MasterKey {
+	mac := hmac.New(sha512.New, m.chainCode[:])
+	mac.Write([]byte{0})
+	mac.Write(m.key[:])
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(label)))
+	mac.Write(length[:])
+	mac.Write([]byte(label))
+	digest := mac.Sum(nil)
+	defer wipe(digest)
+	return newMasterKey(digest)
+}
+
+// Destroy wipes m from memory. The master key should not be used
+// afterwards.
+func (m *MasterKey) Destroy() {
+	wipe(m.key[:])
+	wipe(m.chainCode[:])
+}
+
+// keyPair maps the key of a node to a key pair. The secret key is obtained
+// from 512 bits of HMAC output reduced modulo the group order, so that its
+// bias is negligible.
+func (m *MasterKey) keyPair() *KeyPair {
+	mac := hmac.New(sha512.New, m.key[:])
+	var digest [arith.NumBytesWide]byte
+	defer wipe(digest[:])
+	keyPair := new(KeyPair)
+	for counter := byte(0); ; counter++ {
+		mac.Reset()
+		mac.Write([]byte(keyPairDomain))
+		mac.Write([]byte{counter})
+		mac.Sum(digest[:0])
+		keyPair.Sk.SetWideBytes(&digest)
+		// a zero secret key happens with negligible probability, but would
+		// yield the identity as public key
+		if !keyPair.Sk.IsZero() {
+			keyPair.Pk.ScalarBaseMultSecret(&keyPair.Sk)
+			return keyPair
+		}
+	}
+}
+
+// Derive returns the key pair at path.
+func (m *MasterKey) Derive(path DerivationPath) (*KeyPair, error) {
+	if err := path.validate(); err != nil {
+		return nil, err
+	}
+	node := m
+	for _, label := range path {
+		child := node.child(label)
+		if node != m {
+			node.Destroy()
+		}
+		node = child
+	}
+	if node != m {
+		defer node.Destroy()
+	}
+	return node.keyPair(), nil
+}
+
+// DeriveWithProof returns the key pair at path, together with a proof of
+// knowledge of its secret key, ready to be published on-chain.
+func (m *MasterKey) DeriveWithProof(r io.Reader, path DerivationPath) (*KeyPair, *ProofSkKnowledge, error) {
+	keyPair, err := m.Derive(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := ProveSkKnowledge(r, keyPair)
+	if err != nil {
+		return nil, nil, err
+	}
+	return keyPair, proof, nil
+}
+
+// DerivationPath identifies a node of the tree of keys derived from a
+// MasterKey. Its string representation is "m/" followed by the labels of
+// the nodes separated by "/", e.g. "m/1/0x5fbdb2315678afecb367f032d93f642f64180aa3".
+type DerivationPath []string
+
+// ProposalDerivationPath returns the canonical path of the key of a
+// proposal of a GovernorEncrypted contract deployed on chain chainID:
+//
+//	m/<chainID>/<contract>/<proposalID>
+//
+// where chainID is in decimal, contract is the lowercase hex address and
+// proposalID is the 32 bytes hex representation of the uint256 id.
+func ProposalDerivationPath(chainID *big.Int, contract common.Address, proposalID *big.Int) (DerivationPath, error) {
+	if chainID.Sign() < 0 {
+		return nil, errors.New("chain id should be non-negative")
+	}
+	if proposalID.Sign() < 0 || proposalID.BitLen() > 256 {
+		return nil, errors.New("proposal id should be a uint256")
+	}
+	return DerivationPath{
+		chainID.String(),
+		strings.ToLower(contract.Hex()),
+		fmt.Sprintf("0x%064x", proposalID),
+	}, nil
+}
+
+// ParseDerivationPath parses the string representation of a path.
+func ParseDerivationPath(s string) (DerivationPath, error) {
+	labels := strings.Split(s, "/")
+	if labels[0] != "m" {
+		return nil, errors.New(`derivation path should start with "m"`)
+	}
+	path := DerivationPath(labels[1:])
+	if err := path.validate(); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+func (p DerivationPath) String() string {
+	return strings.Join(append([]string{"m"}, p...), "/")
+}
+
+// validate checks that p can be represented as a string, i.e. that labels
+// are non-empty and made of printable ASCII characters other than "/".
+func (p DerivationPath) validate() error {
+	for i, label := range p {
+		if label == "" {
+			return fmt.Errorf("empty label at depth %d", i+1)
+		}
+		for _, c := range []byte(label) {
+			if c <= ' ' || c > '~' || c == '/' {
+				return fmt.Errorf("invalid character %q in label at depth %d", c, i+1)
+			}
+		}
+	}
+	return nil
+}
+
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
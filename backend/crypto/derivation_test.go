@@ -0,0 +1,137 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDeriveKeyPair(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x42}, 32)
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	paths := make([]DerivationPath, 0)
+	for _, proposalID := range []int64{1, 2} {
+		for _, chainID := range []int64{1, 5} {
+			path, err := ProposalDerivationPath(big.NewInt(chainID), contract, big.NewInt(proposalID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			paths = append(paths, path)
+		}
+	}
+
+	seen := make(map[string]DerivationPath)
+	for _, path := range paths {
+		keyPair, proof, err := master.DeriveWithProof(rand.Reader, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifySkKnowledge(proof, &keyPair.Pk); err != nil {
+			t.Fatal(err)
+		}
+		if !keyPair.Pk.Equal(new(KeyPair).Pk.ScalarBaseMultSecret(&keyPair.Sk)) {
+			t.Fatalf("pk does not match sk at %s", path)
+		}
+
+		// derivation is deterministic, also across master keys from the same seed
+		other, _ := NewMasterKey(seed)
+		again, err := other.Derive(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !again.Sk.Equal(&keyPair.Sk) {
+			t.Fatalf("derivation at %s is not deterministic", path)
+		}
+
+		id := keyPair.Sk.Declassify().String()
+		if otherPath, ok := seen[id]; ok {
+			t.Fatalf("paths %s and %s yield the same key", path, otherPath)
+		}
+		seen[id] = path
+	}
+
+	// a different seed yields different keys
+	otherMaster, _ := NewMasterKey(bytes.Repeat([]byte{0x43}, 32))
+	otherKeyPair, _ := otherMaster.Derive(paths[0])
+	if _, ok := seen[otherKeyPair.Sk.Declassify().String()]; ok {
+		t.Fatal("different seeds yield the same key")
+	}
+}
+
+func TestDerivationPathEncoding(t *testing.T) {
+	proposalID, _ := new(big.Int).SetString("c0ffee", 16)
+	path, err := ProposalDerivationPath(
+		big.NewInt(31337),
+		common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3"),
+		proposalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "m/31337/0x5fbdb2315678afecb367f032d93f642f64180aa3/0x0000000000000000000000000000000000000000000000000000000000c0ffee"
+	if path.String() != expected {
+		t.Fatalf("expected: %s, got: %s", expected, path)
+	}
+	parsed, err := ParseDerivationPath(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.String() != expected {
+		t.Fatalf("expected: %s, got: %s", expected, parsed)
+	}
+	if root, err := ParseDerivationPath("m"); err != nil || len(root) != 0 {
+		t.Fatalf("expected the root path, got %v, %v", root, err)
+	}
+
+	invalid := map[string]string{
+		"missing root":   "1/2",
+		"wrong root":     "M/1",
+		"empty label":    "m/1//2",
+		"trailing slash": "m/1/",
+		"space":          "m/a b",
+		"non-ascii":      "m/é",
+	}
+	for name, s := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseDerivationPath(s); err == nil {
+				t.Fatalf("successfully parsed invalid path %q", s)
+			}
+		})
+	}
+	if _, err := ProposalDerivationPath(big.NewInt(1), common.Address{}, new(big.Int).Lsh(big.NewInt(1), 256)); err == nil {
+		t.Fatal("successfully derived the path of a proposal id over uint256")
+	}
+}
+
+func TestNewMasterKeyShortSeed(t *testing.T) {
+	if _, err := NewMasterKey(make([]byte, MinSeedSize-1)); err == nil {
+		t.Fatal("successfully created master key from a short seed")
+	}
+}
+
+func TestDeriveKnownAnswer(t *testing.T) {
+	// Regression vector, which pins down the derivation algorithm for
+	// implementations in other languages.
+	master, err := NewMasterKey(bytes.Repeat([]byte{0x42}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := ParseDerivationPath("m/1/0x5fbdb2315678afecb367f032d93f642f64180aa3/0x0000000000000000000000000000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPair, err := master.Derive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "646882476160199210459894359165900642952232101019904140226827682990708359183"
+	if keyPair.Sk.Declassify().String() != expected {
+		t.Fatalf("expected sk: %s, got: %s", expected, keyPair.Sk.Declassify().String())
+	}
+}
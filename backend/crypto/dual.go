@@ -0,0 +1,88 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// DualBallot is a yes-no vote encrypted under the public keys of both a
+// primary and a backup tallying authority, so that the backup authority can
+// tally the election if the primary one fails to.
+//
+// Proof proves that Primary encrypts 0 or 1, and Equality proves that
+// Backup encrypts the same value, so that Backup is well-formed as well
+// and both tallies count the same votes.
+type DualBallot struct {
+	Primary  EncryptedVote           `json:"primary"`
+	Backup   EncryptedVote           `json:"backup"`
+	Proof    ProofVoteWellFormedness `json:"proof"`
+	Equality ProofPlaintextEquality  `json:"equality"`
+}
+
+// DualBallotCost is the cost of the on-chain verification of a dual
+// ballot (see VerificationCost).
+var DualBallotCost = VoteWellFormednessCost.Add(PlaintextEqualityCost)
+
+// EncryptDualVoteWithProof encrypts vote, which should be 0 or 1, under
+// both primaryPk and backupPk, and proves the ballot well-formed. The
+// randomness of the encryptions is hedged with voterSecret (see
+// NewVoterSecret).
+func EncryptDualVoteWithProof(
+	reader io.Reader,
+	vote int64,
+	voterSecret *arith.SecretScalar,
+	primaryPk *arith.CurvePoint,
+	backupPk *arith.CurvePoint) (*DualBallot, error) {
+	if vote != int64(No) && vote != int64(Yes) {
+		return nil, fmt.Errorf("dual ballot can only encrypt yes/no votes, got %d", vote)
+	}
+	nonces, err := newVoterReader(reader, voterSecret, primaryPk, backupPk)
+	if err != nil {
+		return nil, err
+	}
+	defer nonces.destroy()
+	primary, r1, err := Vote(vote).encrypt(nonces, primaryPk)
+	if err != nil {
+		return nil, err
+	}
+	defer r1.Destroy()
+	proof, err := ProveVoteWellFormedness(nonces, primary, Vote(vote), r1, primaryPk)
+	if err != nil {
+		return nil, err
+	}
+	backup, r2, err := Vote(vote).encrypt(nonces, backupPk)
+	if err != nil {
+		return nil, err
+	}
+	defer r2.Destroy()
+	v := arith.NewSecretScalar(new(arith.Scalar).SetInt64(vote))
+	defer v.Destroy()
+	equality, err := ProvePlaintextEquality(nonces, primary, backup, v, r1, r2, primaryPk, backupPk)
+	if err != nil {
+		return nil, err
+	}
+
+	ballot := new(DualBallot)
+	ballot.Primary.Set(primary)
+	ballot.Backup.Set(backup)
+	ballot.Proof.Set(proof)
+	ballot.Equality.Set(equality)
+	return ballot, nil
+}
+
+// VerifyDualBallot verifies that a dual ballot encrypts the same 0 or 1
+// vote under primaryPk and backupPk.
+func VerifyDualBallot(ballot *DualBallot, primaryPk, backupPk *arith.CurvePoint) error {
+	if ballot == nil {
+		return errors.New("missing dual ballot")
+	}
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	if err := v.VerifyVoteWellFormedness(&ballot.Proof, &ballot.Primary, primaryPk); err != nil {
+		return err
+	}
+	return v.VerifyPlaintextEquality(&ballot.Equality, &ballot.Primary, &ballot.Backup, primaryPk, backupPk)
+}
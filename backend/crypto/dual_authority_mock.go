@@ -0,0 +1,247 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// Authority identifies one of the two tallying authorities of a
+// DualAuthorityMock.
+type Authority int
+
+const (
+	PrimaryAuthority Authority = iota
+	BackupAuthority
+)
+
+func (a Authority) String() string {
+	switch a {
+	case PrimaryAuthority:
+		return "primary"
+	case BackupAuthority:
+		return "backup"
+	default:
+		return fmt.Sprintf("Authority(%d)", int(a))
+	}
+}
+
+// DualAuthorityMock mimics a voting smart contract with a primary and a
+// backup tallying authority. Votes are cast as dual ballots, and both
+// encrypted tallies are accumulated. The primary authority tallies, unless
+// it misses its deadline, after which Failover hands tallying over to the
+// backup authority. Time is modeled by a logical clock, advanced by Mine,
+// and the primary authority has tallyingPeriod ticks after the end of the
+// voting phase to tally. It is safe for concurrent use.
+type DualAuthorityMock struct {
+	mu               sync.RWMutex
+	clock            uint64
+	tallyingPeriod   uint64
+	tallyingDeadline uint64
+	primaryPk        *arith.CurvePoint
+	backupPk         *arith.CurvePoint
+	primaryTally     *EncryptedVote
+	backupTally      *EncryptedVote
+	numBallots       int
+	failedOver       bool
+	result           uint64
+	talliedBy        Authority
+	status           Status
+}
+
+// NewDualAuthorityMock returns a DualAuthorityMock whose clock starts at 1,
+// and whose primary authority has tallyingPeriod ticks to tally.
+func NewDualAuthorityMock(tallyingPeriod uint64) *DualAuthorityMock {
+	return &DualAuthorityMock{
+		clock:          1,
+		tallyingPeriod: tallyingPeriod,
+		primaryTally:   NewEncryptedVote(),
+		backupTally:    NewEncryptedVote(),
+		status:         Init,
+	}
+}
+
+// Clock returns the current timepoint.
+func (sc *DualAuthorityMock) Clock() uint64 {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.clock
+}
+
+// Mine advances the clock by n ticks, and returns the new timepoint.
+func (sc *DualAuthorityMock) Mine(n uint64) uint64 {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.clock += n
+	return sc.clock
+}
+
+// DeclarePks declares the public keys of the primary and the backup
+// authorities, which should be distinct, after verifying the proofs of
+// knowledge of the secret keys.
+func (sc *DualAuthorityMock) DeclarePks(
+	primaryPk *arith.CurvePoint,
+	primaryProof *ProofSkKnowledge,
+	backupPk *arith.CurvePoint,
+	backupProof *ProofSkKnowledge) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Init {
+		return fmt.Errorf("wrong status")
+	}
+	if err := VerifySkKnowledge(primaryProof, primaryPk); err != nil {
+		return fmt.Errorf("invalid primary pk: %w", err)
+	}
+	if err := VerifySkKnowledge(backupProof, backupPk); err != nil {
+		return fmt.Errorf("invalid backup pk: %w", err)
+	}
+	if primaryPk.Equal(backupPk) {
+		return errors.New("primary and backup pks should be distinct")
+	}
+	sc.primaryPk = new(arith.CurvePoint).Set(primaryPk)
+	sc.backupPk = new(arith.CurvePoint).Set(backupPk)
+	sc.status = Declared
+	return nil
+}
+
+func (sc *DualAuthorityMock) StartVotingPhase() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Declared {
+		return fmt.Errorf("wrong status")
+	}
+	sc.status = Voting
+	return nil
+}
+
+func (sc *DualAuthorityMock) CastVote(ballot *DualBallot) error {
+	// the pks cannot change during voting, see SmartContractMock.CastVote
+	sc.mu.RLock()
+	if sc.status != Voting {
+		sc.mu.RUnlock()
+		return fmt.Errorf("wrong status")
+	}
+	primaryPk, backupPk := sc.primaryPk, sc.backupPk
+	sc.mu.RUnlock()
+	if err := VerifyDualBallot(ballot, primaryPk, backupPk); err != nil {
+		return err
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Voting {
+		return fmt.Errorf("wrong status")
+	}
+	sc.primaryTally.Add(sc.primaryTally, &ballot.Primary)
+	sc.backupTally.Add(sc.backupTally, &ballot.Backup)
+	sc.numBallots++
+	return nil
+}
+
+func (sc *DualAuthorityMock) StopVotingPhase() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Voting {
+		return fmt.Errorf("wrong status")
+	}
+	sc.status = Tallying
+	sc.tallyingDeadline = sc.clock + sc.tallyingPeriod
+	return nil
+}
+
+// GetTallyingDeadline returns the last timepoint at which the primary
+// authority is guaranteed to be able to tally.
+func (sc *DualAuthorityMock) GetTallyingDeadline() (uint64, error) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	if sc.status != Tallying && sc.status != Fini {
+		return 0, fmt.Errorf("wrong status")
+	}
+	return sc.tallyingDeadline, nil
+}
+
+// Failover hands tallying over to the backup authority, once the primary
+// authority has missed its tallying deadline: only the backup authority can
+// tally from now on.
+func (sc *DualAuthorityMock) Failover() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Tallying || sc.failedOver {
+		return fmt.Errorf("wrong status")
+	}
+	if sc.clock <= sc.tallyingDeadline {
+		return errors.New("tallying deadline not missed yet")
+	}
+	sc.failedOver = true
+	return nil
+}
+
+// Tally posts the decrypted tally of authority, after verifying its proof
+// of correct decryption. The primary authority can only tally before
+// Failover, and the backup authority only after.
+func (sc *DualAuthorityMock) Tally(authority Authority, proof *ProofCorrectDecryption, decryptedTally uint64) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Tallying {
+		return fmt.Errorf("wrong status")
+	}
+	var tally *EncryptedVote
+	var pk *arith.CurvePoint
+	switch {
+	case authority == PrimaryAuthority && !sc.failedOver:
+		tally, pk = sc.primaryTally, sc.primaryPk
+	case authority == BackupAuthority && sc.failedOver:
+		tally, pk = sc.backupTally, sc.backupPk
+	default:
+		return fmt.Errorf("%s authority cannot tally", authority)
+	}
+	if err := VerifyCorrectDecryption(proof, tally, Vote(decryptedTally), pk); err != nil {
+		return err
+	}
+	sc.result = decryptedTally
+	sc.talliedBy = authority
+	sc.status = Fini
+	return nil
+}
+
+// GetEncryptedTally returns the encrypted tally to be decrypted by
+// authority.
+func (sc *DualAuthorityMock) GetEncryptedTally(authority Authority) (*EncryptedVote, error) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	switch authority {
+	case PrimaryAuthority:
+		return new(EncryptedVote).Set(sc.primaryTally), nil
+	case BackupAuthority:
+		return new(EncryptedVote).Set(sc.backupTally), nil
+	default:
+		return nil, fmt.Errorf("unknown authority %s", authority)
+	}
+}
+
+// GetResult returns the decrypted tally, and the authority which posted
+// it.
+func (sc *DualAuthorityMock) GetResult() (uint64, Authority, error) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	if sc.status != Fini {
+		return 0, 0, fmt.Errorf("wrong status")
+	}
+	return sc.result, sc.talliedBy, nil
+}
+
+// GetNumBallots returns the number of votes cast so far.
+func (sc *DualAuthorityMock) GetNumBallots() int {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.numBallots
+}
+
+// GetStatus returns the current status of the contract.
+func (sc *DualAuthorityMock) GetStatus() Status {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.status
+}
@@ -0,0 +1,101 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+const testTallyingPeriod = 4
+
+func TestDualAuthorityMockFailover(t *testing.T) {
+	tests := map[string]struct {
+		failover bool
+		expected Authority
+	}{
+		"primary tallies":   {failover: false, expected: PrimaryAuthority},
+		"backup takes over": {failover: true, expected: BackupAuthority},
+	}
+	votes := []int64{1, 0, 1, 1, 0}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			primary, primaryProof, err := NewKeyPairWithProof(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			backup, backupProof, err := NewKeyPairWithProof(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sc := NewDualAuthorityMock(testTallyingPeriod)
+			if err := sc.DeclarePks(&primary.Pk, primaryProof, &primary.Pk, primaryProof); err == nil {
+				t.Fatal("successfully declared the same pk twice")
+			}
+			if err := sc.DeclarePks(&primary.Pk, primaryProof, &backup.Pk, backupProof); err != nil {
+				t.Fatal(err)
+			}
+			if err := sc.StartVotingPhase(); err != nil {
+				t.Fatal(err)
+			}
+			for _, vote := range votes {
+				ballot, err := EncryptDualVoteWithProof(rand.Reader, vote, testVoterSecret, &primary.Pk, &backup.Pk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := sc.CastVote(ballot); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := sc.Failover(); err == nil {
+				t.Fatal("successfully failed over during voting")
+			}
+			if err := sc.StopVotingPhase(); err != nil {
+				t.Fatal(err)
+			}
+
+			keyPairs := map[Authority]*KeyPair{PrimaryAuthority: primary, BackupAuthority: backup}
+			other := BackupAuthority
+			if tc.failover {
+				// the primary authority can still tally until the deadline
+				deadline, err := sc.GetTallyingDeadline()
+				if err != nil {
+					t.Fatal(err)
+				}
+				sc.Mine(deadline - sc.Clock())
+				if err := sc.Failover(); err == nil {
+					t.Fatal("successfully failed over before the tallying deadline")
+				}
+				sc.Mine(1)
+				if err := sc.Failover(); err != nil {
+					t.Fatal(err)
+				}
+				other = PrimaryAuthority
+			}
+			// the other authority cannot tally, even with a valid proof
+			otherTally, _ := sc.GetEncryptedTally(other)
+			result, proof, err := DecryptTallyWithProof(rand.Reader, otherTally, int64(len(votes)), keyPairs[other])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := sc.Tally(other, proof, uint64(result)); err == nil {
+				t.Fatalf("%s authority successfully tallied", other)
+			}
+
+			tally, _ := sc.GetEncryptedTally(tc.expected)
+			result, proof, err = DecryptTallyWithProof(rand.Reader, tally, int64(len(votes)), keyPairs[tc.expected])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := sc.Tally(tc.expected, proof, uint64(result)); err != nil {
+				t.Fatal(err)
+			}
+			got, authority, err := sc.GetResult()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != 3 || authority != tc.expected {
+				t.Fatalf("expected result 3 by %s, got %d by %s", tc.expected, got, authority)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestEncryptAndVerifyDualBallot(t *testing.T) {
+	tests := map[string]struct {
+		vote  int64
+		valid bool
+	}{
+		"no":       {0, true},
+		"yes":      {1, true},
+		"negative": {-1, false},
+		"two":      {2, false},
+	}
+	primary := generateKeyPair(t, rand.Reader)
+	backup := generateKeyPair(t, rand.Reader)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ballot, err := EncryptDualVoteWithProof(rand.Reader, tc.vote, testVoterSecret, &primary.Pk, &backup.Pk)
+			if !tc.valid {
+				if err == nil {
+					t.Fatalf("successfully encrypted vote %d", tc.vote)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := VerifyDualBallot(ballot, &primary.Pk, &backup.Pk); err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range []struct {
+				keyPair *KeyPair
+				e       *EncryptedVote
+			}{{primary, &ballot.Primary}, {backup, &ballot.Backup}} {
+				got, err := d.e.Decrypt(&d.keyPair.Sk, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if int64(got) != tc.vote {
+					t.Fatalf("expected: %d, got: %d", tc.vote, got)
+				}
+			}
+			if err := VerifyDualBallot(ballot, &backup.Pk, &primary.Pk); err == nil {
+				t.Fatal("successfully verified a dual ballot with swapped keys")
+			}
+		})
+	}
+}
+
+func TestVerifyDualBallotMismatch(t *testing.T) {
+	primary := generateKeyPair(t, rand.Reader)
+	backup := generateKeyPair(t, rand.Reader)
+	ballot, err := EncryptDualVoteWithProof(rand.Reader, 1, testVoterSecret, &primary.Pk, &backup.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the backup authority would count a vote against instead
+	no, _, err := No.Encrypt(rand.Reader, testVoterSecret, &backup.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ballot.Backup.Set(no)
+	if err := VerifyDualBallot(ballot, &primary.Pk, &backup.Pk); err == nil {
+		t.Fatal("successfully verified a dual ballot with different votes")
+	}
+	if err := VerifyDualBallot(nil, &primary.Pk, &backup.Pk); err == nil {
+		t.Fatal("successfully verified a missing dual ballot")
+	}
+}
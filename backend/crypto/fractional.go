@@ -0,0 +1,202 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// Support is an option of a fractional vote, numbered as in OpenZeppelin
+// GovernorCountingFractional.
+type Support int
+
+const (
+	Against Support = iota
+	For
+	Abstain
+)
+
+// NumSupports is the number of options of a fractional vote.
+const NumSupports = 3
+
+// FractionalBallot is a vote which splits the weight w of the voter
+// between the options Against, For and Abstain. Amounts[s] encrypts the
+// part of w assigned to option s.
+type FractionalBallot struct {
+	Amounts [NumSupports]EncryptedVote `json:"amounts"`
+	Proof   ProofFractionalBallot      `json:"proof"`
+}
+
+// ProofFractionalBallot is a cryptographic proof that the amounts of a
+// FractionalBallot are in [0, w] and sum to w.
+//
+// Each amount is the homomorphic recombination sum(2^i * Bits[s][i]) of
+// bits.Len64(w) encrypted bits, each one with a proof of well-formedness,
+// so it is in [0, 2^bits.Len64(w)). Sum proves that the sum of the amounts
+// encrypts w. Since the sum of the amounts cannot wrap around the order of
+// the group, the amounts sum to w as integers, and are thus at most w.
+type ProofFractionalBallot struct {
+	Bits      [NumSupports][]EncryptedVote           `json:"bits"`
+	BitProofs [NumSupports][]ProofVoteWellFormedness `json:"bitProofs"`
+	Sum       ProofCorrectDecryption                 `json:"sum"`
+}
+
+// EncryptFractionalVote encrypts a fractional vote which assigns
+// amounts[s] to option s, for a voter of weight w, and proves it
+// well-formed. The amounts should sum to w. The randomness of the
+// encryptions is hedged with voterSecret (see NewVoterSecret).
+func EncryptFractionalVote(
+	reader io.Reader,
+	amounts [NumSupports]uint64,
+	w uint64,
+	voterSecret *arith.SecretScalar,
+	pk *arith.CurvePoint) (*FractionalBallot, error) {
+	if err := checkFractionalWeight(w); err != nil {
+		return nil, err
+	}
+	var total, carry uint64
+	for _, amount := range amounts {
+		total, carry = bits.Add64(total, amount, carry)
+	}
+	if carry != 0 || total != w {
+		return nil, fmt.Errorf("amounts should sum to the weight %d", w)
+	}
+	nonces, err := newVoterReader(reader, voterSecret, pk)
+	if err != nil {
+		return nil, err
+	}
+	defer nonces.destroy()
+
+	weights := powersOfTwo(bits.Len64(w))
+	ballot := new(FractionalBallot)
+	r := new(arith.SecretScalar)
+	defer r.Destroy()
+	for s, amount := range amounts {
+		encryptedBits, bitProofs, rs, err := encryptBits(nonces, amount, weights, pk)
+		if err != nil {
+			return nil, err
+		}
+		r.Add(r, rs)
+		rs.Destroy()
+		ballot.Amounts[s].Set(recombineBits(encryptedBits, weights))
+		ballot.Proof.Bits[s] = encryptedBits
+		ballot.Proof.BitProofs[s] = bitProofs
+	}
+
+	proof, err := proveEncryptsZero(nonces, fractionalDifference(ballot, w), r, pk)
+	if err != nil {
+		return nil, err
+	}
+	ballot.Proof.Sum.Set(proof)
+	return ballot, nil
+}
+
+// VerifyFractionalBallot verifies a fractional ballot of a voter of weight
+// w.
+func VerifyFractionalBallot(ballot *FractionalBallot, w uint64, pk *arith.CurvePoint) error {
+	if ballot == nil {
+		return errors.New("missing fractional ballot")
+	}
+	if pk == nil || !pk.IsValid() {
+		return errors.New("invalid public key")
+	}
+	if err := checkFractionalWeight(w); err != nil {
+		return err
+	}
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	weights := powersOfTwo(bits.Len64(w))
+	for s := range ballot.Amounts {
+		amount := &ballot.Amounts[s]
+		if !amount.A.IsValid() || !amount.B.IsValid() {
+			return fmt.Errorf("support %d: invalid encrypted amount", s)
+		}
+		if len(ballot.Proof.Bits[s]) != len(weights) {
+			return fmt.Errorf("support %d: amount should have %d bits", s, len(weights))
+		}
+		if err := verifyBits(v, ballot.Proof.Bits[s], ballot.Proof.BitProofs[s], pk); err != nil {
+			return fmt.Errorf("support %d: %w", s, err)
+		}
+		recombined := recombineBits(ballot.Proof.Bits[s], weights)
+		if !recombined.A.Equal(&amount.A) || !recombined.B.Equal(&amount.B) {
+			return fmt.Errorf("support %d: amount does not match its bits", s)
+		}
+	}
+	if err := verifyEncryptsZero(v, &ballot.Proof.Sum, fractionalDifference(ballot, w), pk); err != nil {
+		return errors.New("amounts do not sum to the weight")
+	}
+	return nil
+}
+
+// FractionalTally is the homomorphic tally of fractional ballots, with one
+// encrypted total for each option.
+type FractionalTally struct {
+	Totals [NumSupports]EncryptedVote `json:"totals"`
+}
+
+// NewFractionalTally returns a tally of no ballots.
+func NewFractionalTally() *FractionalTally {
+	tally := new(FractionalTally)
+	for s := range tally.Totals {
+		tally.Totals[s].Set(NewEncryptedVote())
+	}
+	return tally
+}
+
+// Add adds the amounts of ballot, which should have been verified, to the
+// tally and returns the tally.
+func (t *FractionalTally) Add(ballot *FractionalBallot) *FractionalTally {
+	for s := range t.Totals {
+		t.Totals[s].Add(&t.Totals[s], &ballot.Amounts[s])
+	}
+	return t
+}
+
+// DecryptFractionalTallyWithProof decrypts each total of a fractional
+// tally, proving each decryption correct. Parameter n should be an upper
+// bound on the totals, such as the sum of the weights of the voters.
+func DecryptFractionalTallyWithProof(
+	r io.Reader,
+	tally *FractionalTally,
+	n int64,
+	keyPair *KeyPair) ([NumSupports]int64, [NumSupports]*ProofCorrectDecryption, error) {
+	var results [NumSupports]int64
+	var proofs [NumSupports]*ProofCorrectDecryption
+	for s := range tally.Totals {
+		result, proof, err := DecryptTallyWithProof(r, &tally.Totals[s], n, keyPair)
+		if err != nil {
+			return results, proofs, fmt.Errorf("support %d: %w", s, err)
+		}
+		results[s], proofs[s] = result, proof
+	}
+	return results, proofs, nil
+}
+
+// checkFractionalWeight rejects weights for which the sum of the amounts
+// could wrap around the order of the group, as well as the zero weight,
+// which leaves nothing to split.
+func checkFractionalWeight(w uint64) error {
+	if w == 0 {
+		return errors.New("weight should be positive")
+	}
+	if w > math.MaxInt64 {
+		return fmt.Errorf("weight %d overflows int64", w)
+	}
+	return nil
+}
+
+// fractionalDifference returns the encryption of the sum of the amounts of
+// ballot minus w, which is 0 if and only if the amounts sum to w.
+func fractionalDifference(ballot *FractionalBallot, w uint64) *EncryptedVote {
+	res := NewEncryptedVote()
+	for s := range ballot.Amounts {
+		res.Add(res, &ballot.Amounts[s])
+	}
+	wG := new(arith.CurvePoint).ScalarBaseMult(new(arith.Scalar).SetUint64(w))
+	res.B.Add(&res.B, wG.Neg(wG))
+	return res
+}
@@ -0,0 +1,131 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+func TestFractionalVoting(t *testing.T) {
+	tests := map[string]struct {
+		weights []uint64
+		amounts [][NumSupports]uint64
+	}{
+		"single voter": {
+			weights: []uint64{10},
+			amounts: [][NumSupports]uint64{{3, 5, 2}},
+		},
+		"whole weight to one option": {
+			weights: []uint64{7, 8, 1},
+			amounts: [][NumSupports]uint64{{7, 0, 0}, {0, 8, 0}, {0, 0, 1}},
+		},
+		"mixed delegates": {
+			weights: []uint64{100, 64, 33},
+			amounts: [][NumSupports]uint64{{40, 35, 25}, {0, 63, 1}, {11, 11, 11}},
+		},
+	}
+	keyPair := generateKeyPair(t, rand.Reader)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tally := NewFractionalTally()
+			var expected [NumSupports]int64
+			var totalWeight int64
+			for i, w := range tc.weights {
+				ballot, err := EncryptFractionalVote(rand.Reader, tc.amounts[i], w, testVoterSecret, &keyPair.Pk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := VerifyFractionalBallot(ballot, w, &keyPair.Pk); err != nil {
+					t.Fatal(err)
+				}
+				if err := VerifyFractionalBallot(ballot, w+1, &keyPair.Pk); err == nil {
+					t.Fatal("successfully verified a fractional ballot for another weight")
+				}
+				tally.Add(ballot)
+				for s, amount := range tc.amounts[i] {
+					expected[s] += int64(amount)
+				}
+				totalWeight += int64(w)
+			}
+
+			results, proofs, err := DecryptFractionalTallyWithProof(rand.Reader, tally, totalWeight, keyPair)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if results != expected {
+				t.Fatalf("expected: %v, got: %v", expected, results)
+			}
+			for s := range results {
+				if err := VerifyCorrectDecryption(proofs[s], &tally.Totals[s], Vote(results[s]), &keyPair.Pk); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestEncryptFractionalVoteInvalidAmounts(t *testing.T) {
+	tests := map[string]struct {
+		amounts [NumSupports]uint64
+		w       uint64
+	}{
+		"sum below weight": {amounts: [NumSupports]uint64{1, 2, 3}, w: 7},
+		"sum above weight": {amounts: [NumSupports]uint64{1, 2, 3}, w: 5},
+		"zero weight":      {amounts: [NumSupports]uint64{0, 0, 0}, w: 0},
+		"sum overflows":    {amounts: [NumSupports]uint64{1 << 63, 1 << 63, 1}, w: 1},
+	}
+	keyPair := generateKeyPair(t, rand.Reader)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := EncryptFractionalVote(rand.Reader, tc.amounts, tc.w, testVoterSecret, &keyPair.Pk); err == nil {
+				t.Fatal("encrypted an invalid fractional vote")
+			}
+		})
+	}
+}
+
+func TestVerifyFractionalBallotTampered(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	const w = 10
+	one := new(arith.CurvePoint).ScalarBaseMult(new(arith.Scalar).SetInt64(1))
+	minusOne := new(arith.CurvePoint).Neg(one)
+
+	tests := map[string]func(ballot *FractionalBallot){
+		"amount moved between options": func(ballot *FractionalBallot) {
+			ballot.Amounts[For].B.Add(&ballot.Amounts[For].B, one)
+			ballot.Amounts[Against].B.Add(&ballot.Amounts[Against].B, minusOne)
+		},
+		"amount replaced": func(ballot *FractionalBallot) {
+			other, err := EncryptFractionalVote(rand.Reader, [NumSupports]uint64{0, 10, 0}, w, testVoterSecret, &keyPair.Pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ballot.Amounts[For].Set(&other.Amounts[For])
+			ballot.Proof.Bits[For] = other.Proof.Bits[For]
+			ballot.Proof.BitProofs[For] = other.Proof.BitProofs[For]
+		},
+		"missing bit": func(ballot *FractionalBallot) {
+			ballot.Proof.Bits[Abstain] = ballot.Proof.Bits[Abstain][1:]
+			ballot.Proof.BitProofs[Abstain] = ballot.Proof.BitProofs[Abstain][1:]
+		},
+		"invalid amount": func(ballot *FractionalBallot) {
+			ballot.Amounts[Against] = EncryptedVote{}
+		},
+	}
+
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			ballot, err := EncryptFractionalVote(rand.Reader, [NumSupports]uint64{4, 5, 1}, w, testVoterSecret, &keyPair.Pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tamper(ballot)
+			if err := VerifyFractionalBallot(ballot, w, &keyPair.Pk); err == nil {
+				t.Fatal("successfully verified a tampered fractional ballot")
+			}
+		})
+	}
+}
@@ -8,18 +8,25 @@ import (
 
 // KeyPair is an election authority key-pair for ElGamal encryption of votes.
 type KeyPair struct {
-	Pk arith.CurvePoint `json:"pk"` // public key
-	Sk arith.Scalar     `json:"sk"` // secret key
+	Pk arith.CurvePoint   `json:"pk"` // public key
+	Sk arith.SecretScalar `json:"sk"` // secret key
 }
 
 // NewKeyPair allocates and initializes a new valid ElGamal KeyPair.
 func NewKeyPair(r io.Reader) (*KeyPair, error) {
-	sk, pk, err := arith.RandomCurvePoint(r)
+	sk, err := arith.RandomSecretScalar(r)
 	if err != nil {
 		return nil, err
 	}
+	defer sk.Destroy()
 	keyPair := new(KeyPair)
-	keyPair.Pk.Set(pk)
+	keyPair.Pk.ScalarBaseMultSecret(sk)
 	keyPair.Sk.Set(sk)
 	return keyPair, nil
 }
+
+// Destroy wipes the secret key of keyPair from memory. The key pair should
+// not be used afterwards.
+func (keyPair *KeyPair) Destroy() {
+	keyPair.Sk.Destroy()
+}
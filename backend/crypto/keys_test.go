@@ -10,13 +10,21 @@ import (
 
 func TestNewKeyPair(t *testing.T) {
 	keyPair := generateKeyPair(t, rand.Reader)
-	pk := new(arith.CurvePoint).ScalarBaseMult(&keyPair.Sk)
+	pk := new(arith.CurvePoint).ScalarBaseMultSecret(&keyPair.Sk)
 	if !pk.Equal(&keyPair.Pk) {
 		t.Fatal("keyPair.Pk() != G1 * keyPair.Sk()")
 	}
 }
 
-func generateKeyPair(t *testing.T, r io.Reader) *KeyPair {
+func TestKeyPairDestroy(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	keyPair.Destroy()
+	if !keyPair.Sk.IsZero() {
+		t.Fatal("secret key has not been wiped")
+	}
+}
+
+func generateKeyPair(t testing.TB, r io.Reader) *KeyPair {
 	keyPair, err := NewKeyPair(r)
 	if err != nil {
 		t.Fatal(err)
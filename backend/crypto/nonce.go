@@ -0,0 +1,208 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// freshRandomnessSize is the number of bytes read from the io.Reader passed
+// to a prover, which are mixed with the secrets and the statement.
+const freshRandomnessSize = 32
+
+const seededReaderDomain = "e-voting seeded reader"
+
+// Domains of the hedged nonces of each prover, so that two provers never
+// derive the same nonces from the same secret.
+const (
+	skKnowledgeNonceDomain        = "e-voting sk knowledge nonce"
+	correctDecryptionNonceDomain  = "e-voting correct decryption nonce"
+	voteWellFormednessNonceDomain = "e-voting vote well-formedness nonce"
+	squareEncryptionNonceDomain   = "e-voting square encryption nonce"
+	squareNonceDomain             = "e-voting square nonce"
+	plaintextEqualityNonceDomain  = "e-voting plaintext equality nonce"
+	encryptionNonceDomain         = "e-voting encryption nonce"
+	voterNonceDomain              = "e-voting voter nonce"
+	rangeNonceDomain              = "e-voting range nonce"
+	thresholdNonceDomain          = "e-voting threshold nonce"
+)
+
+const voterSecretDomain = "e-voting voter secret"
+
+// hedgedReader returns the source of the nonces of a prover: an HMAC-DRBG
+// seeded, like the deterministic nonces of RFC 6979, with the secrets of
+// the prover and its statement, but also with fresh randomness read from
+// reader. The nonces are unpredictable as long as either the secrets or
+// reader are, so that a weak or repeated random number generator does not
+// leak the secrets: a repeated reader only makes the nonces repeat for the
+// same secrets and statement, i.e. it makes the proof deterministic.
+//
+// The caller should destroy the returned reader once the nonces are drawn.
+func hedgedReader(
+	reader io.Reader,
+	domain string,
+	secrets []*arith.SecretScalar,
+	statement ...*arith.CurvePoint) (*hmacDRBG, error) {
+	data := make([][]byte, len(statement))
+	for i, p := range statement {
+		var err error
+		if data[i], err = p.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	return newHedgedDRBG(reader, domain, secrets, data)
+}
+
+// NewHedgedReader returns the source of nonces used by the provers of this
+// package, for provers built on top of it: the nonces are drawn from an
+// HMAC-DRBG seeded with domain, the secrets, the serialized statement and
+// fresh randomness read from reader. domain should be unique to the
+// prover, and the statement should include everything the secrets are
+// used for, since the same secrets and statement give the same nonces if
+// reader repeats.
+func NewHedgedReader(
+	reader io.Reader,
+	domain string,
+	secrets []*arith.SecretScalar,
+	statement ...[]byte) (io.Reader, error) {
+	d, err := newHedgedDRBG(reader, domain, secrets, statement)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func newHedgedDRBG(
+	reader io.Reader,
+	domain string,
+	secrets []*arith.SecretScalar,
+	statement [][]byte) (*hmacDRBG, error) {
+	var fresh [freshRandomnessSize]byte
+	defer wipe(fresh[:])
+	if _, err := io.ReadFull(reader, fresh[:]); err != nil {
+		return nil, err
+	}
+	seed := make([][]byte, 0, 2+len(secrets)+len(statement))
+	seed = append(seed, lengthPrefixed([]byte(domain)), fresh[:])
+	for _, secret := range secrets {
+		buf := new([arith.NumBytesScalar]byte)
+		secret.FillBytes(buf)
+		defer wipe(buf[:])
+		seed = append(seed, buf[:])
+	}
+	for _, data := range statement {
+		seed = append(seed, lengthPrefixed(data))
+	}
+	return newHMACDRBG(seed...), nil
+}
+
+// NewVoterSecret derives the secret of a voter from keyMaterial, which
+// should be held by the voter only and have at least 32 bytes of entropy,
+// e.g. their private key, or the signature of a fixed message by their
+// wallet. The voter secret hedges the randomness of the encryptions of
+// their votes, which would otherwise only depend on the random number
+// generator, the public key and the vote: were the generator weak, anyone
+// could recompute the encryption of each possible vote.
+//
+// The caller should Destroy the voter secret once it is no longer needed.
+func NewVoterSecret(keyMaterial []byte) (*arith.SecretScalar, error) {
+	if len(keyMaterial) == 0 {
+		return nil, errors.New("voter key material should not be empty")
+	}
+	d := newHMACDRBG(lengthPrefixed([]byte(voterSecretDomain)), keyMaterial)
+	defer d.destroy()
+	return arith.RandomSecretScalar(d)
+}
+
+// newVoterReader returns the source of the randomness of the encryptions
+// of a voter, and of their proofs: an HMAC-DRBG hedged with voterSecret
+// and pks. All the encryptions of a ballot should draw from the same
+// reader, so that they get distinct randomness even if reader repeats.
+//
+// The caller should destroy the returned reader once it is no longer needed.
+func newVoterReader(
+	reader io.Reader,
+	voterSecret *arith.SecretScalar,
+	pks ...*arith.CurvePoint) (*hmacDRBG, error) {
+	if voterSecret == nil {
+		return nil, errors.New("missing voter secret")
+	}
+	return hedgedReader(reader, voterNonceDomain, []*arith.SecretScalar{voterSecret}, pks...)
+}
+
+// NewSeededReader returns a deterministic stream of pseudorandom bytes
+// derived from seed, which can be passed to the provers in place of
+// crypto/rand.Reader to obtain reproducible proofs and encryptions, e.g. in
+// tests and test vectors. The nonces of the provers stay hedged with their
+// secrets, but anything else drawn from the reader, such as a key pair, is
+// predictable from seed, so seed should be secret and random outside tests.
+func NewSeededReader(seed []byte) io.Reader {
+	return newHMACDRBG(lengthPrefixed([]byte(seededReaderDomain)), seed)
+}
+
+// hmacDRBG is the HMAC_DRBG of NIST SP 800-90A with SHA-256, without
+// reseeding, which RFC 6979 uses to derive nonces.
+type hmacDRBG struct {
+	k [sha256.Size]byte
+	v [sha256.Size]byte
+	h hash.Hash
+}
+
+func newHMACDRBG(seed ...[]byte) *hmacDRBG {
+	d := new(hmacDRBG)
+	for i := range d.v {
+		d.v[i] = 0x01
+	}
+	d.h = hmac.New(sha256.New, d.k[:])
+	d.update(seed)
+	return d
+}
+
+// update is the HMAC_DRBG_Update function of SP 800-90A.
+func (d *hmacDRBG) update(data [][]byte) {
+	for _, separator := range []byte{0x00, 0x01} {
+		d.h = hmac.New(sha256.New, d.k[:])
+		d.h.Write(d.v[:])
+		d.h.Write([]byte{separator})
+		for _, b := range data {
+			d.h.Write(b)
+		}
+		d.h.Sum(d.k[:0])
+		d.h = hmac.New(sha256.New, d.k[:])
+		d.h.Write(d.v[:])
+		d.h.Sum(d.v[:0])
+		if len(data) == 0 {
+			return
+		}
+	}
+}
+
+// Read fills p with the output of HMAC_DRBG_Generate. It never fails.
+func (d *hmacDRBG) Read(p []byte) (int, error) {
+	for n := 0; n < len(p); n += len(d.v) {
+		d.h.Reset()
+		d.h.Write(d.v[:])
+		d.h.Sum(d.v[:0])
+		copy(p[n:], d.v[:])
+	}
+	d.update(nil)
+	return len(p), nil
+}
+
+// destroy wipes the state of d, which should not be used afterwards.
+func (d *hmacDRBG) destroy() {
+	wipe(d.k[:])
+	wipe(d.v[:])
+	d.h = nil
+}
+
+// lengthPrefixed returns b prefixed with its length, so that the seeds of
+// the HMAC_DRBG are unambiguous.
+func lengthPrefixed(b []byte) []byte {
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(b))), b...)
+}
@@ -0,0 +1,266 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+func TestHMACDRBG(t *testing.T) {
+	// RFC 6979, appendix A.2.5: P-256 with SHA-256, message "sample"
+	x, _ := hex.DecodeString("c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721")
+	h1 := sha256.Sum256([]byte("sample"))
+	want, _ := hex.DecodeString("a6e3c57dd01abe90086538398355dd4c3b17aa873382b0f24d6129493d8aad60")
+
+	got := make([]byte, len(want))
+	if _, err := newHMACDRBG(x, h1[:]).Read(got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("expected: %x, got: %x", want, got)
+	}
+}
+
+func TestSeededReader(t *testing.T) {
+	seed := []byte("seed")
+	want := generateSeededProofs(t, NewSeededReader(seed))
+	if got := generateSeededProofs(t, NewSeededReader(seed)); !bytes.Equal(got, want) {
+		t.Fatal("seeded proofs are not reproducible")
+	}
+	if got := generateSeededProofs(t, NewSeededReader([]byte("other seed"))); bytes.Equal(got, want) {
+		t.Fatal("different seeds gave the same proofs")
+	}
+}
+
+func TestHedgedNoncesWithRepeatedReader(t *testing.T) {
+	// a broken random number generator, which always returns zeros
+	keyPair1 := generateKeyPair(t, rand.Reader)
+	keyPair2 := generateKeyPair(t, rand.Reader)
+
+	no, r0, err := No.Encrypt(zeroReader{}, testVoterSecret, &keyPair1.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r0.Destroy()
+	yes, r1, err := Yes.Encrypt(zeroReader{}, testVoterSecret, &keyPair1.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r1.Destroy()
+	if r0.Equal(r1) {
+		t.Fatal("the same randomness encrypted different votes")
+	}
+	_, r2, err := No.Encrypt(zeroReader{}, testVoterSecret, &keyPair2.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r2.Destroy()
+	if r0.Equal(r2) {
+		t.Fatal("the same randomness encrypted for different pks")
+	}
+
+	proof0, err := ProveCorrectDecryption(zeroReader{}, no, keyPair1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof1, err := ProveCorrectDecryption(zeroReader{}, yes, keyPair1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyCorrectDecryption(proof0, no, No, &keyPair1.Pk); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyCorrectDecryption(proof1, yes, Yes, &keyPair1.Pk); err != nil {
+		t.Fatal(err)
+	}
+	// with the same nonce, sk = (S0 - S1) / (C0 - C1), so check that
+	// S0 - C0*sk and S1 - C1*sk, the two nonces, differ
+	sk := keyPair1.Sk.Declassify()
+	nonce0 := new(arith.Scalar).Sub(&proof0.S, new(arith.Scalar).Mul(proof0.C.Scalar(), sk))
+	nonce1 := new(arith.Scalar).Sub(&proof1.S, new(arith.Scalar).Mul(proof1.C.Scalar(), sk))
+	if nonce0.Equal(nonce1) {
+		t.Fatal("the same nonce proved two decryptions")
+	}
+
+	again, err := ProveCorrectDecryption(zeroReader{}, no, keyPair1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *again != *proof0 {
+		t.Fatal("the same reader, secrets and statement gave different proofs")
+	}
+}
+
+// testVoterSecret is the voter secret of the encryptions of the tests.
+var testVoterSecret, _ = NewVoterSecret([]byte("e-voting test voter"))
+
+func TestNewVoterSecret(t *testing.T) {
+	secret1, err := NewVoterSecret([]byte("voter 1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secret1.Destroy()
+	again, err := NewVoterSecret([]byte("voter 1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer again.Destroy()
+	secret2, err := NewVoterSecret([]byte("voter 2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secret2.Destroy()
+	if !secret1.Equal(again) {
+		t.Fatal("the same key material gave different voter secrets")
+	}
+	if secret1.Equal(secret2) {
+		t.Fatal("different key materials gave the same voter secret")
+	}
+	if _, err := NewVoterSecret(nil); err == nil {
+		t.Fatal("derived a voter secret from empty key material")
+	}
+}
+
+func TestEncryptWithRepeatedReader(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	seed := []byte("repeated seed")
+	encrypt := func(voterSecret *arith.SecretScalar) *EncryptedVote {
+		t.Helper()
+		encryptedVote, _, err := EncryptVoteWithProof(NewSeededReader(seed), int64(Yes), voterSecret, &keyPair.Pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return encryptedVote
+	}
+	equal := func(a, b *EncryptedVote) bool {
+		return a.A.Equal(&b.A) && a.B.Equal(&b.B)
+	}
+
+	secret1, err := NewVoterSecret([]byte("voter 1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secret1.Destroy()
+	secret2, err := NewVoterSecret([]byte("voter 2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secret2.Destroy()
+	encryptedVote1 := encrypt(secret1)
+	if equal(encryptedVote1, encrypt(secret2)) {
+		t.Fatal("voters with different secrets gave the same encryption")
+	}
+	if !equal(encryptedVote1, encrypt(secret1)) {
+		t.Fatal("the same reader, voter secret, vote and pk gave different encryptions")
+	}
+
+	if _, _, err := Yes.Encrypt(rand.Reader, nil, &keyPair.Pk); err == nil {
+		t.Fatal("encrypted a vote without a voter secret")
+	}
+
+	// the entries of a ballot get distinct randomness, even if the reader
+	// always returns zeros
+	ballot, err := EncryptApprovalBallot(zeroReader{}, []bool{false, false}, NoApprovalLimit, secret1, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if equal(&ballot.Entries[0], &ballot.Entries[1]) {
+		t.Fatal("the same randomness encrypted two entries of a ballot")
+	}
+}
+
+func TestEncryptSquareWithRepeatedReader(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	encryptedVote, r, err := Vote(2).Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Destroy()
+	v := arith.NewSecretScalar(new(arith.Scalar).SetInt64(2))
+	defer v.Destroy()
+	_, s, err := EncryptSquare(zeroReader{}, encryptedVote, v, r, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Destroy()
+
+	// an attacker knowing the reader guesses the small vote, but not r
+	guess, err := hedgedReader(
+		zeroReader{},
+		squareEncryptionNonceDomain,
+		[]*arith.SecretScalar{v},
+		&keyPair.Pk,
+		&encryptedVote.A,
+		&encryptedVote.B)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer guess.destroy()
+	guessed, err := arith.RandomSecretScalar(guess)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer guessed.Destroy()
+	if s.Equal(guessed) {
+		t.Fatal("the randomness of the square was recomputed from the vote only")
+	}
+}
+
+func TestHedgedNoncesReaderError(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	if _, err := ProveSkKnowledge(errorReader{}, keyPair); err == nil {
+		t.Fatal("proved with a failing reader")
+	}
+	if _, _, err := Yes.Encrypt(errorReader{}, testVoterSecret, &keyPair.Pk); err == nil {
+		t.Fatal("encrypted with a failing reader")
+	}
+	if _, err := NewHedgedReader(io.LimitReader(rand.Reader, freshRandomnessSize-1), "domain", nil); err == nil {
+		t.Fatal("hedged a short reader")
+	}
+}
+
+// generateSeededProofs generates a key pair, an encrypted vote and all
+// their proofs from reader, and returns them serialized.
+func generateSeededProofs(t *testing.T, reader io.Reader) []byte {
+	t.Helper()
+	keyPair := generateKeyPair(t, reader)
+	skProof, err := ProveSkKnowledge(reader, keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedVote, proof, err := EncryptVoteWithProof(reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decryptionProof, err := ProveCorrectDecryption(reader, encryptedVote, keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal([]interface{}{keyPair.Pk, skProof, encryptedVote, proof, decryptionProof})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) {
+	return 0, errors.New("broken reader")
+}
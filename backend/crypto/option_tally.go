@@ -0,0 +1,82 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// OptionTally is the homomorphic tally of ballots with one encrypted entry
+// per option, such as approval and score ballots. Totals[i] is the sum of
+// the entries for option i, each one scaled by the weight of its voter.
+type OptionTally struct {
+	Totals []EncryptedVote `json:"totals"`
+}
+
+// NewOptionTally returns a tally of no ballots with numOptions options.
+func NewOptionTally(numOptions int) *OptionTally {
+	tally := &OptionTally{Totals: make([]EncryptedVote, numOptions)}
+	for i := range tally.Totals {
+		tally.Totals[i].Set(NewEncryptedVote())
+	}
+	return tally
+}
+
+// Add adds the entries of a verified ballot, scaled by the weight of the
+// voter, to the tally.
+func (t *OptionTally) Add(entries []EncryptedVote, weight uint64) error {
+	if len(entries) != len(t.Totals) {
+		return fmt.Errorf("ballot has %d entries, tally has %d options", len(entries), len(t.Totals))
+	}
+	scaled := new(EncryptedVote)
+	k := new(arith.Scalar).SetUint64(weight)
+	for i := range entries {
+		scaled.Scale(&entries[i], k)
+		t.Totals[i].Add(&t.Totals[i], scaled)
+	}
+	return nil
+}
+
+// DecryptOptionTallyWithProof decrypts each total of a tally, proving each
+// decryption correct. Parameter n should be an upper bound on the totals.
+func DecryptOptionTallyWithProof(
+	r io.Reader,
+	tally *OptionTally,
+	n int64,
+	keyPair *KeyPair) ([]int64, []*ProofCorrectDecryption, error) {
+	results := make([]int64, len(tally.Totals))
+	proofs := make([]*ProofCorrectDecryption, len(tally.Totals))
+	for i := range tally.Totals {
+		result, proof, err := DecryptTallyWithProof(r, &tally.Totals[i], n, keyPair)
+		if err != nil {
+			return nil, nil, fmt.Errorf("option %d: %w", i, err)
+		}
+		results[i], proofs[i] = result, proof
+	}
+	return results, proofs, nil
+}
+
+// VerifyOptionTally verifies the proofs of correct decryption of each
+// total of a tally to the corresponding result.
+func VerifyOptionTally(
+	tally *OptionTally,
+	results []int64,
+	proofs []*ProofCorrectDecryption,
+	pk *arith.CurvePoint) error {
+	if tally == nil {
+		return errors.New("missing tally")
+	}
+	if len(results) != len(tally.Totals) || len(proofs) != len(tally.Totals) {
+		return fmt.Errorf("tally should have %d results and proofs", len(tally.Totals))
+	}
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	for i := range tally.Totals {
+		if err := v.VerifyCorrectDecryption(proofs[i], &tally.Totals[i], Vote(results[i]), pk); err != nil {
+			return fmt.Errorf("option %d: %w", i, err)
+		}
+	}
+	return nil
+}
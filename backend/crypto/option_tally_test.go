@@ -0,0 +1,40 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestOptionTallyWrongNumberOfEntries(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	ballot, err := EncryptApprovalBallot(rand.Reader, []bool{true, false}, NoApprovalLimit, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewOptionTally(3).Add(ballot.Entries, 1); err == nil {
+		t.Fatal("added a ballot with the wrong number of entries")
+	}
+}
+
+func TestVerifyOptionTallyWrongResult(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	tally := NewOptionTally(2)
+	ballot, err := EncryptApprovalBallot(rand.Reader, []bool{true, false}, NoApprovalLimit, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tally.Add(ballot.Entries, 3); err != nil {
+		t.Fatal(err)
+	}
+	results, proofs, err := DecryptOptionTallyWithProof(rand.Reader, tally, 3, keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results[0], results[1] = results[1], results[0]
+	if err := VerifyOptionTally(tally, results, proofs, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified the wrong results")
+	}
+	if err := VerifyOptionTally(tally, results[:1], proofs[:1], &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified missing results")
+	}
+}
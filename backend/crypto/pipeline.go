@@ -0,0 +1,162 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+var errWeightOverflow = errors.New("total weight overflows uint64")
+
+// WeightedBallot is an encrypted vote, together with its proof of
+// well-formedness and the voting power of the voter.
+type WeightedBallot struct {
+	EncryptedVote EncryptedVote           `json:"encryptedVote"`
+	Proof         ProofVoteWellFormedness `json:"proof"`
+	Weight        uint64                  `json:"weight"`
+}
+
+// BallotError reports that the ballot at position Index of a stream is
+// invalid.
+type BallotError struct {
+	Index int
+	Err   error
+}
+
+func (e *BallotError) Error() string {
+	return fmt.Sprintf("ballot %d: %v", e.Index, e.Err)
+}
+
+func (e *BallotError) Unwrap() error {
+	return e.Err
+}
+
+// BallotTally is the outcome of VerifyBallots.
+type BallotTally struct {
+	// Tally is the sum of the valid encrypted votes, each one scaled by its
+	// weight.
+	Tally EncryptedVote
+	// Weight is the total weight of the valid ballots, which is an upper
+	// bound on the decrypted tally.
+	Weight uint64
+	// NumValid is the number of valid ballots.
+	NumValid int
+	// Errors lists the invalid ballots, sorted by index. They do not
+	// contribute to the tally.
+	Errors []*BallotError
+}
+
+// VerifyBallots verifies the ballots received from ballots, encrypted with
+// public key pk, until the channel is closed. The ballots are verified on
+// GOMAXPROCS goroutines, and the valid ones are accumulated into a weighted
+// tally. Ballots are indexed from 0 in the order in which they are received.
+//
+// VerifyBallots returns ctx.Err() if ctx is done before all the ballots
+// have been verified, in which case it stops receiving from ballots. It
+// also fails if the total weight of the valid ballots overflows uint64.
+func VerifyBallots(ctx context.Context, pk *arith.CurvePoint, ballots <-chan *WeightedBallot) (*BallotTally, error) {
+	if pk == nil || !pk.IsValid() {
+		return nil, errors.New("invalid public key")
+	}
+
+	type job struct {
+		index  int
+		ballot *WeightedBallot
+	}
+	jobs := make(chan job)
+	go func() {
+		defer close(jobs)
+		for index := 0; ; index++ {
+			select {
+			case ballot, ok := <-ballots:
+				if !ok {
+					return
+				}
+				select {
+				case jobs <- job{index, ballot}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	numWorkers := runtime.GOMAXPROCS(0)
+	partials := make([]*BallotTally, numWorkers)
+	var wg sync.WaitGroup
+	var overflow int32
+	for i := range partials {
+		partial := new(BallotTally)
+		partial.Tally.Set(NewEncryptedVote())
+		partials[i] = partial
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v := verifierPool.Get().(*Verifier)
+			defer verifierPool.Put(v)
+			scaled := new(EncryptedVote)
+			k := new(arith.Scalar)
+			for j := range jobs {
+				if ctx.Err() != nil || atomic.LoadInt32(&overflow) != 0 {
+					continue
+				}
+				err := partial.add(v, scaled, k, j.ballot, pk)
+				if errors.Is(err, errWeightOverflow) {
+					atomic.StoreInt32(&overflow, 1)
+				} else if err != nil {
+					partial.Errors = append(partial.Errors, &BallotError{Index: j.index, Err: err})
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if overflow != 0 {
+		return nil, errWeightOverflow
+	}
+
+	res := new(BallotTally)
+	res.Tally.Set(NewEncryptedVote())
+	for _, partial := range partials {
+		var carry uint64
+		res.Weight, carry = bits.Add64(res.Weight, partial.Weight, 0)
+		if carry != 0 {
+			return nil, errWeightOverflow
+		}
+		res.Tally.Add(&res.Tally, &partial.Tally)
+		res.NumValid += partial.NumValid
+		res.Errors = append(res.Errors, partial.Errors...)
+	}
+	sort.Slice(res.Errors, func(i, j int) bool { return res.Errors[i].Index < res.Errors[j].Index })
+	return res, nil
+}
+
+// add verifies ballot and adds it to t, using scaled and k as scratch space.
+func (t *BallotTally) add(v *Verifier, scaled *EncryptedVote, k *arith.Scalar, ballot *WeightedBallot, pk *arith.CurvePoint) error {
+	if ballot == nil {
+		return errors.New("missing ballot")
+	}
+	if err := v.VerifyVoteWellFormedness(&ballot.Proof, &ballot.EncryptedVote, pk); err != nil {
+		return err
+	}
+	weight, carry := bits.Add64(t.Weight, ballot.Weight, 0)
+	if carry != 0 {
+		return errWeightOverflow
+	}
+	scaled.Scale(&ballot.EncryptedVote, k.SetUint64(ballot.Weight))
+	t.Tally.Add(&t.Tally, scaled)
+	t.Weight = weight
+	t.NumValid++
+	return nil
+}
@@ -0,0 +1,127 @@
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestVerifyBallots(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	otherKeyPair := generateKeyPair(t, rand.Reader)
+
+	var ballots []*WeightedBallot
+	var expectedResult, expectedWeight uint64
+	invalid := map[int]bool{3: true, 7: true, 12: true}
+	for i := 0; i < 20; i++ {
+		vote := Vote(i % 2)
+		weight := uint64(i + 1)
+		pk := &keyPair.Pk
+		if invalid[i] {
+			pk = &otherKeyPair.Pk
+		}
+		encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(vote), testVoterSecret, pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ballot := &WeightedBallot{Weight: weight}
+		ballot.EncryptedVote.Set(encryptedVote)
+		ballot.Proof.Set(proof)
+		ballots = append(ballots, ballot)
+		if !invalid[i] {
+			expectedResult += weight * uint64(vote)
+			expectedWeight += weight
+		}
+	}
+	ballots = append(ballots, nil)
+
+	res, err := VerifyBallots(context.Background(), &keyPair.Pk, stream(ballots))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NumValid != len(ballots)-len(invalid)-1 {
+		t.Fatalf("expected %d valid ballots, got %d", len(ballots)-len(invalid)-1, res.NumValid)
+	}
+	if res.Weight != expectedWeight {
+		t.Fatalf("expected weight: %d, got: %d", expectedWeight, res.Weight)
+	}
+	expectedErrors := []int{3, 7, 12, len(ballots) - 1}
+	if len(res.Errors) != len(expectedErrors) {
+		t.Fatalf("expected %d errors, got %v", len(expectedErrors), res.Errors)
+	}
+	for i, index := range expectedErrors {
+		if res.Errors[i].Index != index {
+			t.Fatalf("expected error for ballot %d, got %v", index, res.Errors[i])
+		}
+	}
+
+	result, err := res.Tally.Decrypt(&keyPair.Sk, int64(res.Weight))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(result) != expectedResult {
+		t.Fatalf("expected result: %d, got: %d", expectedResult, result)
+	}
+}
+
+func TestVerifyBallotsCanceled(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// the channel is never closed, so only cancellation can stop VerifyBallots
+	ballots := make(chan *WeightedBallot)
+	if _, err := VerifyBallots(ctx, &keyPair.Pk, ballots); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestVerifyBallotsWeightOverflow(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	var ballots []*WeightedBallot
+	for i := 0; i < 2; i++ {
+		encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ballot := &WeightedBallot{Weight: math.MaxUint64}
+		ballot.EncryptedVote.Set(encryptedVote)
+		ballot.Proof.Set(proof)
+		ballots = append(ballots, ballot)
+	}
+	if _, err := VerifyBallots(context.Background(), &keyPair.Pk, stream(ballots)); err == nil {
+		t.Fatal("successfully tallied ballots whose total weight overflows")
+	}
+}
+
+func BenchmarkVerifyBallots(b *testing.B) {
+	keyPair := generateKeyPair(b, rand.Reader)
+	encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		b.Fatal(err)
+	}
+	ballot := &WeightedBallot{Weight: 1}
+	ballot.EncryptedVote.Set(encryptedVote)
+	ballot.Proof.Set(proof)
+	b.ResetTimer()
+	ballots := make(chan *WeightedBallot)
+	go func() {
+		defer close(ballots)
+		for i := 0; i < b.N; i++ {
+			ballots <- ballot
+		}
+	}()
+	if _, err := VerifyBallots(context.Background(), &keyPair.Pk, ballots); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func stream(ballots []*WeightedBallot) <-chan *WeightedBallot {
+	ch := make(chan *WeightedBallot, len(ballots))
+	for _, ballot := range ballots {
+		ch <- ballot
+	}
+	close(ch)
+	return ch
+}
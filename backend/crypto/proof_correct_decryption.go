@@ -1,8 +1,6 @@
 package crypto
 
 import (
-	"encoding/json"
-	"errors"
 	"io"
 
 	"github.com/HorizenLabs/e-voting-poc/backend/arith"
@@ -27,12 +25,25 @@ func ProveCorrectDecryption(
 	encryptedVote *EncryptedVote,
 	keyPair *KeyPair) (*ProofCorrectDecryption, error) {
 	// Implementation based on https://eprint.iacr.org/2016/765.pdf, section 4.4
-	r, v, err := arith.RandomCurvePoint(reader)
+	nonces, err := hedgedReader(
+		reader,
+		correctDecryptionNonceDomain,
+		[]*arith.SecretScalar{&keyPair.Sk},
+		&keyPair.Pk,
+		&encryptedVote.A,
+		&encryptedVote.B)
 	if err != nil {
 		return nil, err
 	}
+	defer nonces.destroy()
+	r, err := arith.RandomSecretScalar(nonces)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Destroy()
+	v := new(arith.CurvePoint).ScalarBaseMultSecret(r)
 
-	u := new(arith.CurvePoint).ScalarMult(&encryptedVote.A, r)
+	u := new(arith.CurvePoint).ScalarMultSecret(&encryptedVote.A, r)
 
 	bytesPk, err := keyPair.Pk.MarshalBinary()
 	if err != nil {
@@ -61,10 +72,12 @@ func ProveCorrectDecryption(
 		bytesU,
 		bytesV)
 
-	s := new(arith.Scalar).Mul(c.Scalar(), &keyPair.Sk)
-	s = new(arith.Scalar).Add(r, s)
+	s := arith.NewSecretScalar(c.Scalar())
+	defer s.Destroy()
+	s.Mul(s, &keyPair.Sk)
+	s.Add(r, s)
 	proof := new(ProofCorrectDecryption)
-	proof.S.Set(s)
+	proof.S.Set(s.Declassify())
 	proof.C.Set(c)
 	return proof, nil
 }
@@ -75,63 +88,7 @@ func VerifyCorrectDecryption(
 	encryptedVote *EncryptedVote,
 	decryptedVote Vote,
 	pk *arith.CurvePoint) error {
-	minusEncodedVote := new(arith.CurvePoint).Neg(encode(decryptedVote))
-	d := new(arith.CurvePoint).Add(&encryptedVote.B, minusEncodedVote)
-	return verifyCorrectDecryptionInternal(proof, encryptedVote, d, pk)
-}
-
-func verifyCorrectDecryptionInternal(proof *ProofCorrectDecryption,
-	encryptedVote *EncryptedVote,
-	d *arith.CurvePoint,
-	pk *arith.CurvePoint) error {
-	// Implementation based on https://eprint.iacr.org/2016/765.pdf, section 4.4
-	m, err := json.Marshal(proof)
-	if err != nil {
-		return err
-	}
-	proof = new(ProofCorrectDecryption)
-	err = json.Unmarshal(m, proof)
-	if err != nil {
-		return err
-	}
-
-	sA := new(arith.CurvePoint).ScalarMult(&encryptedVote.A, &proof.S)
-	cD := new(arith.CurvePoint).ScalarMult(d, proof.C.Scalar())
-	u := new(arith.CurvePoint).Add(sA, new(arith.CurvePoint).Neg(cD))
-
-	sG := new(arith.CurvePoint).ScalarBaseMult(&proof.S)
-	cPk := new(arith.CurvePoint).ScalarMult(pk, proof.C.Scalar())
-	v := new(arith.CurvePoint).Add(sG, new(arith.CurvePoint).Neg(cPk))
-
-	bytesPk, err := pk.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	bytesA, err := encryptedVote.A.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	bytesB, err := encryptedVote.B.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	bytesU, err := u.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	bytesV, err := v.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	c := arith.FiatShamirChallenge(
-		bytesPk,
-		bytesA,
-		bytesB,
-		bytesU,
-		bytesV)
-
-	if !c.Equal(&proof.C) {
-		return errors.New("decryption proof verification failed")
-	}
-	return nil
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	return v.VerifyCorrectDecryption(proof, encryptedVote, decryptedVote, pk)
 }
@@ -33,3 +33,37 @@ func TestProveAndVerifyCorrectDecryption(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkProveCorrectDecryption(b *testing.B) {
+	keyPair := generateKeyPair(b, rand.Reader)
+	encryptedVote, _, err := Yes.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ProveCorrectDecryption(rand.Reader, encryptedVote, keyPair); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerifyCorrectDecryption(b *testing.B) {
+	keyPair := generateKeyPair(b, rand.Reader)
+	encryptedVote, _, err := Yes.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		b.Fatal(err)
+	}
+	proof, err := ProveCorrectDecryption(rand.Reader, encryptedVote, keyPair)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := VerifyCorrectDecryption(proof, encryptedVote, Yes, &keyPair.Pk); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package crypto
+
+import (
+	"errors"
+	"io"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// ProofPlaintextEquality is a cryptographic proof that two encrypted votes
+// (A1, B1) and (A2, B2), encrypted with two different public keys pk1 and
+// pk2, encrypt the same value v.
+//
+// If (A1, B1) = (r1*g, r1*pk1 + v*g) and (A2, B2) = (r2*g, r2*pk2 + v*g),
+// the proof is a proof of knowledge of v, r1 and r2 satisfying these four
+// linear relations, the two encodings of v sharing the same witness.
+type ProofPlaintextEquality struct {
+	C   arith.Challenge `json:"c"`
+	Zv  arith.Scalar    `json:"zv"`
+	Zr1 arith.Scalar    `json:"zr1"`
+	Zr2 arith.Scalar    `json:"zr2"`
+}
+
+// Set sets p to q and returns q.
+func (q *ProofPlaintextEquality) Set(p *ProofPlaintextEquality) *ProofPlaintextEquality {
+	q.C.Set(&p.C)
+	q.Zv.Set(&p.Zv)
+	q.Zr1.Set(&p.Zr1)
+	q.Zr2.Set(&p.Zr2)
+	return q
+}
+
+// ProvePlaintextEquality generates a proof that encryptedVote1, encrypted
+// with pk1, and encryptedVote2, encrypted with pk2, both encrypt v. The
+// parameters should be obtained in the following way:
+//
+//	encryptedVote1, r1, err := vote.Encrypt(rand.Reader, voterSecret, pk1)
+//	encryptedVote2, r2, err := vote.Encrypt(rand.Reader, voterSecret, pk2)
+func ProvePlaintextEquality(
+	reader io.Reader,
+	encryptedVote1 *EncryptedVote,
+	encryptedVote2 *EncryptedVote,
+	v *arith.SecretScalar,
+	r1 *arith.SecretScalar,
+	r2 *arith.SecretScalar,
+	pk1 *arith.CurvePoint,
+	pk2 *arith.CurvePoint) (*ProofPlaintextEquality, error) {
+	nonces, err := hedgedReader(
+		reader,
+		plaintextEqualityNonceDomain,
+		[]*arith.SecretScalar{v, r1, r2},
+		pk1,
+		pk2,
+		&encryptedVote1.A,
+		&encryptedVote1.B,
+		&encryptedVote2.A,
+		&encryptedVote2.B)
+	if err != nil {
+		return nil, err
+	}
+	defer nonces.destroy()
+	kv, err := arith.RandomSecretScalar(nonces)
+	if err != nil {
+		return nil, err
+	}
+	defer kv.Destroy()
+	k1, err := arith.RandomSecretScalar(nonces)
+	if err != nil {
+		return nil, err
+	}
+	defer k1.Destroy()
+	k2, err := arith.RandomSecretScalar(nonces)
+	if err != nil {
+		return nil, err
+	}
+	defer k2.Destroy()
+
+	kvG := new(arith.CurvePoint).ScalarBaseMultSecret(kv)
+	// t1 = k1*g
+	t1 := new(arith.CurvePoint).ScalarBaseMultSecret(k1)
+	// t2 = k1*pk1 + kv*g
+	t2 := new(arith.CurvePoint).ScalarMultSecret(pk1, k1)
+	t2.Add(t2, kvG)
+	// t3 = k2*g
+	t3 := new(arith.CurvePoint).ScalarBaseMultSecret(k2)
+	// t4 = k2*pk2 + kv*g
+	t4 := new(arith.CurvePoint).ScalarMultSecret(pk2, k2)
+	t4.Add(t4, kvG)
+
+	points := []*arith.CurvePoint{
+		pk1, pk2,
+		&encryptedVote1.A, &encryptedVote1.B, &encryptedVote2.A, &encryptedVote2.B,
+		t1, t2, t3, t4,
+	}
+	data := make([][]byte, len(points))
+	for i, p := range points {
+		if data[i], err = p.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	c := arith.FiatShamirChallenge(data...)
+
+	proof := new(ProofPlaintextEquality)
+	proof.C.Set(c)
+	z := arith.NewSecretScalar(c.Scalar())
+	defer z.Destroy()
+	cs := arith.NewSecretScalar(c.Scalar())
+	defer cs.Destroy()
+	for _, w := range []struct {
+		k, x *arith.SecretScalar
+		z    *arith.Scalar
+	}{{kv, v, &proof.Zv}, {k1, r1, &proof.Zr1}, {k2, r2, &proof.Zr2}} {
+		// z = k + c*x
+		z.Mul(cs, w.x)
+		z.Add(w.k, z)
+		w.z.Set(z.Declassify())
+	}
+	return proof, nil
+}
+
+// VerifyPlaintextEquality verifies a proof that encryptedVote1, encrypted
+// with pk1, and encryptedVote2, encrypted with pk2, encrypt the same value.
+func VerifyPlaintextEquality(
+	proof *ProofPlaintextEquality,
+	encryptedVote1 *EncryptedVote,
+	encryptedVote2 *EncryptedVote,
+	pk1 *arith.CurvePoint,
+	pk2 *arith.CurvePoint) error {
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	return v.VerifyPlaintextEquality(proof, encryptedVote1, encryptedVote2, pk1, pk2)
+}
+
+// VerifyPlaintextEquality is like the function VerifyPlaintextEquality.
+func (v *Verifier) VerifyPlaintextEquality(
+	proof *ProofPlaintextEquality,
+	encryptedVote1 *EncryptedVote,
+	encryptedVote2 *EncryptedVote,
+	pk1 *arith.CurvePoint,
+	pk2 *arith.CurvePoint) error {
+	if proof == nil {
+		return errors.New("missing plaintext equality proof")
+	}
+	if err := v.checkEncryptedVote(encryptedVote1, pk1); err != nil {
+		return err
+	}
+	if err := v.checkEncryptedVote(encryptedVote2, pk2); err != nil {
+		return err
+	}
+	pkCopy1, pkCopy2 := &v.p[0], &v.p[1]
+	a1, b1, a2, b2 := &v.p[2], &v.p[3], &v.p[4], &v.p[5]
+	pkCopy1.Set(pk1)
+	pkCopy2.Set(pk2)
+	a1.Set(&encryptedVote1.A)
+	b1.Set(&encryptedVote1.B)
+	a2.Set(&encryptedVote2.A)
+	b2.Set(&encryptedVote2.B)
+	negC := v.s[0].SetChallenge(&proof.C)
+	negC.Neg(negC)
+	zvG, tmp := &v.p[6], &v.p[7]
+	zvG.ScalarBaseMult(&proof.Zv)
+
+	v.hasher.Reset()
+	if err := v.write(pkCopy1, pkCopy2, a1, b1, a2, b2); err != nil {
+		return err
+	}
+	// t1 = zr1*g - c*A1, t2 = zr1*pk1 + zv*g - c*B1, and the same for the
+	// second encrypted vote; the results overwrite the inputs, which are
+	// no longer needed
+	for _, e := range []struct {
+		a, b, pk *arith.CurvePoint
+		z        *arith.Scalar
+	}{{a1, b1, pkCopy1, &proof.Zr1}, {a2, b2, pkCopy2, &proof.Zr2}} {
+		e.a.ScalarMult(e.a, negC)
+		e.a.Add(e.a, tmp.ScalarBaseMult(e.z))
+		e.b.ScalarMult(e.b, negC)
+		e.b.Add(e.b, tmp.ScalarMult(e.pk, e.z))
+		e.b.Add(e.b, zvG)
+	}
+	if err := v.write(a1, b1, a2, b2); err != nil {
+		return err
+	}
+	if !v.hasher.Challenge(&v.c).Equal(&proof.C) {
+		return errors.New("plaintext equality proof verification failed")
+	}
+	return nil
+}
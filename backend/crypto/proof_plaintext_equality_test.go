@@ -0,0 +1,80 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+func TestProveAndVerifyPlaintextEquality(t *testing.T) {
+	tests := map[string]struct {
+		value1, value2 int64
+		valid          bool
+	}{
+		"equal zeros":  {0, 0, true},
+		"equal ones":   {1, 1, true},
+		"equal large":  {1000, 1000, true},
+		"different":    {0, 1, false},
+		"off by large": {7, 1007, false},
+	}
+	keyPair1 := generateKeyPair(t, rand.Reader)
+	keyPair2 := generateKeyPair(t, rand.Reader)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e1, e2, proof := generatePlaintextEquality(t, tc.value1, tc.value2, &keyPair1.Pk, &keyPair2.Pk)
+			err := VerifyPlaintextEquality(proof, e1, e2, &keyPair1.Pk, &keyPair2.Pk)
+			if tc.valid && err != nil {
+				t.Fatal(err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatal("successfully verified a plaintext equality proof for different values")
+			}
+			if err := VerifyPlaintextEquality(proof, e2, e1, &keyPair2.Pk, &keyPair1.Pk); err == nil {
+				t.Fatal("successfully verified a plaintext equality proof with swapped ciphertexts")
+			}
+		})
+	}
+}
+
+func TestVerifyPlaintextEqualityInvalidInputs(t *testing.T) {
+	keyPair1 := generateKeyPair(t, rand.Reader)
+	keyPair2 := generateKeyPair(t, rand.Reader)
+	e1, e2, proof := generatePlaintextEquality(t, 1, 1, &keyPair1.Pk, &keyPair2.Pk)
+	if err := VerifyPlaintextEquality(nil, e1, e2, &keyPair1.Pk, &keyPair2.Pk); err == nil {
+		t.Fatal("successfully verified a missing plaintext equality proof")
+	}
+	if err := VerifyPlaintextEquality(proof, e1, &EncryptedVote{}, &keyPair1.Pk, &keyPair2.Pk); err == nil {
+		t.Fatal("successfully verified a plaintext equality proof for an invalid ciphertext")
+	}
+	if err := VerifyPlaintextEquality(proof, e1, e2, &keyPair1.Pk, &keyPair1.Pk); err == nil {
+		t.Fatal("successfully verified a plaintext equality proof for the wrong key")
+	}
+}
+
+// generatePlaintextEquality encrypts value1 with pk1 and value2 with pk2,
+// and proves that both encrypt value1.
+func generatePlaintextEquality(
+	t *testing.T,
+	value1, value2 int64,
+	pk1, pk2 *arith.CurvePoint) (*EncryptedVote, *EncryptedVote, *ProofPlaintextEquality) {
+	t.Helper()
+	e1, r1, err := Vote(value1).Encrypt(rand.Reader, testVoterSecret, pk1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r1.Destroy()
+	e2, r2, err := Vote(value2).Encrypt(rand.Reader, testVoterSecret, pk2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r2.Destroy()
+	v := arith.NewSecretScalar(new(arith.Scalar).SetInt64(value1))
+	defer v.Destroy()
+	proof, err := ProvePlaintextEquality(rand.Reader, e1, e2, v, r1, r2, pk1, pk2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e1, e2, proof
+}
@@ -0,0 +1,187 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// ProofRange is a cryptographic proof that an encrypted vote encrypts a
+// value in [0, n], which generalizes ProofVoteWellFormedness to n > 1.
+//
+// The value x is decomposed as sum(w[i] * b[i]) with bits b[i] and the
+// weights w = RangeWeights(n), whose subset sums are exactly the integers
+// in [0, n]. The proof carries fresh encryptions of the bits, each one
+// with a proof of well-formedness, and a proof that the encrypted vote
+// minus the homomorphic recombination of the bits encrypts 0.
+//
+// The size of the proof and the cost of its verification grow with the
+// number of bits of n (see RangeProofCost).
+type ProofRange struct {
+	Bits      []EncryptedVote           `json:"bits"`
+	BitProofs []ProofVoteWellFormedness `json:"bitProofs"`
+	Equality  ProofCorrectDecryption    `json:"equality"`
+}
+
+// RangeWeights returns the weights of the bits of a range proof for
+// [0, n]: 1, 2, ..., 2^(k-2), and n - 2^(k-1) + 1, where k is the number of
+// bits of n. The last weight is at most 2^(k-1), so every integer in
+// [0, n] is the sum of a subset of the weights, and no subset sums to more
+// than n.
+func RangeWeights(n uint64) []uint64 {
+	k := bits.Len64(n)
+	if k == 0 {
+		return nil
+	}
+	weights := powersOfTwo(k)
+	weights[k-1] = n - (1 << (k - 1)) + 1
+	return weights
+}
+
+// ProveRange generates a proof that encryptedVote encrypts value, which
+// should be in [0, n]. The parameters should be obtained in the following
+// way:
+//
+//	encryptedVote, r, err := Vote(value).Encrypt(rand.Reader, voterSecret, pk)
+func ProveRange(
+	reader io.Reader,
+	encryptedVote *EncryptedVote,
+	value uint64,
+	r *arith.SecretScalar,
+	n uint64,
+	pk *arith.CurvePoint) (*ProofRange, error) {
+	if value > n {
+		return nil, fmt.Errorf("value %d is out of range [0, %d]", value, n)
+	}
+	// the bits are encrypted with the nonces, which are hedged with r since
+	// the value alone may be guessed
+	nonces, err := hedgedReader(
+		reader,
+		rangeNonceDomain,
+		[]*arith.SecretScalar{r},
+		pk,
+		&encryptedVote.A,
+		&encryptedVote.B)
+	if err != nil {
+		return nil, err
+	}
+	defer nonces.destroy()
+	weights := RangeWeights(n)
+	encryptedBits, bitProofs, rBits, err := encryptBits(nonces, value, weights, pk)
+	if err != nil {
+		return nil, err
+	}
+	defer rBits.Destroy()
+	rBits.Sub(r, rBits)
+
+	difference := rangeDifference(encryptedVote, encryptedBits, weights)
+	equality, err := proveEncryptsZero(nonces, difference, rBits, pk)
+	if err != nil {
+		return nil, err
+	}
+	proof := &ProofRange{Bits: encryptedBits, BitProofs: bitProofs}
+	proof.Equality.Set(equality)
+	return proof, nil
+}
+
+// VerifyRange verifies a proof that encryptedVote encrypts a value in
+// [0, n].
+func VerifyRange(
+	proof *ProofRange,
+	encryptedVote *EncryptedVote,
+	n uint64,
+	pk *arith.CurvePoint) error {
+	if proof == nil {
+		return errors.New("missing range proof")
+	}
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	if err := v.checkEncryptedVote(encryptedVote, pk); err != nil {
+		return err
+	}
+	weights := RangeWeights(n)
+	if len(proof.Bits) != len(weights) {
+		return fmt.Errorf("range proof should have %d bits", len(weights))
+	}
+	if err := verifyBits(v, proof.Bits, proof.BitProofs, pk); err != nil {
+		return err
+	}
+	difference := rangeDifference(encryptedVote, proof.Bits, weights)
+	if err := verifyEncryptsZero(v, &proof.Equality, difference, pk); err != nil {
+		return errors.New("range proof verification failed")
+	}
+	return nil
+}
+
+// EncryptValueWithRangeProof encrypts value, which should be in [0, n],
+// and proves it in range. The randomness of the encryption is hedged with
+// voterSecret (see NewVoterSecret).
+func EncryptValueWithRangeProof(
+	r io.Reader,
+	value uint64,
+	n uint64,
+	voterSecret *arith.SecretScalar,
+	pk *arith.CurvePoint) (*EncryptedVote, *ProofRange, error) {
+	nonces, err := newVoterReader(r, voterSecret, pk)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer nonces.destroy()
+	return encryptValueWithRangeProof(nonces, value, n, pk)
+}
+
+// encryptValueWithRangeProof is EncryptValueWithRangeProof without a voter
+// secret: r should be hedged with it already (see newVoterReader).
+func encryptValueWithRangeProof(
+	r io.Reader,
+	value uint64,
+	n uint64,
+	pk *arith.CurvePoint) (*EncryptedVote, *ProofRange, error) {
+	if n > math.MaxInt64 {
+		return nil, nil, fmt.Errorf("upper bound %d overflows int64", n)
+	}
+	if value > n {
+		return nil, nil, fmt.Errorf("value %d is out of range [0, %d]", value, n)
+	}
+	encryptedVote, secret, err := Vote(value).encrypt(r, pk)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer secret.Destroy()
+	proof, err := ProveRange(r, encryptedVote, value, secret, n, pk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encryptedVote, proof, nil
+}
+
+// RangeProofCost returns the cost of the on-chain verification of a range
+// proof for [0, n]: the proofs of the bits, the recombination, in which the
+// bit of weight 1 needs no multiplication, the subtraction from the
+// encrypted vote, and the proof of encryption of 0, in which the term m*g
+// of a proof of correct decryption vanishes.
+func RangeProofCost(n uint64) VerificationCost {
+	k := bits.Len64(n)
+	cost := VoteWellFormednessCost.Times(k)
+	if k > 0 {
+		// sum(w[i] * b[i]), then the encrypted vote minus the sum
+		cost = cost.Add(VerificationCost{ECMul: 2 * (k - 1), ECAdd: 2*(k-1) + 2})
+	}
+	return cost.Add(VerificationCost{
+		ECAdd:       CorrectDecryptionCost.ECAdd - 1,
+		ECMul:       CorrectDecryptionCost.ECMul - 1,
+		Keccak:      CorrectDecryptionCost.Keccak,
+		KeccakWords: CorrectDecryptionCost.KeccakWords,
+	})
+}
+
+// rangeDifference returns the encryption of the value of encryptedVote
+// minus sum(weights[i] * bits[i]).
+func rangeDifference(encryptedVote *EncryptedVote, bits []EncryptedVote, weights []uint64) *EncryptedVote {
+	recombined := recombineBits(bits, weights)
+	return recombined.Sub(encryptedVote, recombined)
+}
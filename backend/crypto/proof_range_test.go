@@ -0,0 +1,160 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+)
+
+func TestRangeWeights(t *testing.T) {
+	for _, n := range []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 15, 16, 17, 31, 100} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			weights := RangeWeights(n)
+			sums := make(map[uint64]bool)
+			for subset := 0; subset < 1<<len(weights); subset++ {
+				var sum uint64
+				for i, w := range weights {
+					if subset&(1<<i) != 0 {
+						sum += w
+					}
+				}
+				if sum > n {
+					t.Fatalf("weights %v sum to %d > %d", weights, sum, n)
+				}
+				sums[sum] = true
+			}
+			for x := uint64(0); x <= n; x++ {
+				if !sums[x] {
+					t.Fatalf("weights %v do not sum to %d", weights, x)
+				}
+				if _, err := decompose(x, weights); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestProveAndVerifyRange(t *testing.T) {
+	tests := map[string]struct {
+		value, n uint64
+	}{
+		"empty range":        {value: 0, n: 0},
+		"zero of one":        {value: 0, n: 1},
+		"one of one":         {value: 1, n: 1},
+		"lower bound":        {value: 0, n: 10},
+		"upper bound":        {value: 10, n: 10},
+		"middle":             {value: 6, n: 10},
+		"power of two":       {value: 16, n: 16},
+		"below power of two": {value: 15, n: 15},
+		"large range":        {value: 123456, n: 1000000},
+	}
+	keyPair := generateKeyPair(t, rand.Reader)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			encryptedVote, proof, err := EncryptValueWithRangeProof(rand.Reader, tc.value, tc.n, testVoterSecret, &keyPair.Pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := VerifyRange(proof, encryptedVote, tc.n, &keyPair.Pk); err != nil {
+				t.Fatal(err)
+			}
+			got, err := encryptedVote.Decrypt(&keyPair.Sk, int64(tc.n))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if uint64(got) != tc.value {
+				t.Fatalf("expected: %d, got: %d", tc.value, got)
+			}
+			other, _, err := EncryptValueWithRangeProof(rand.Reader, tc.value, tc.n, testVoterSecret, &keyPair.Pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := VerifyRange(proof, other, tc.n, &keyPair.Pk); err == nil {
+				t.Fatal("successfully verified a range proof for another encrypted vote")
+			}
+		})
+	}
+}
+
+func TestProveRangeOutOfRange(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	if _, _, err := EncryptValueWithRangeProof(rand.Reader, 11, 10, testVoterSecret, &keyPair.Pk); err == nil {
+		t.Fatal("proved an out of range value")
+	}
+
+	// a proof for the bits of 10, attached to an encryption of 11
+	encryptedVote, r, err := Vote(11).Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Destroy()
+	weights := RangeWeights(10)
+	bits, bitProofs, rBits, err := encryptBits(rand.Reader, 10, weights, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rBits.Destroy()
+	rBits.Sub(r, rBits)
+	equality, err := proveEncryptsZero(rand.Reader, rangeDifference(encryptedVote, bits, weights), rBits, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof := &ProofRange{Bits: bits, BitProofs: bitProofs}
+	proof.Equality.Set(equality)
+	if err := VerifyRange(proof, encryptedVote, 10, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a range proof for an out of range value")
+	}
+}
+
+func TestVerifyRangeWrongBound(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	encryptedVote, proof, err := EncryptValueWithRangeProof(rand.Reader, 5, 10, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// same number of bits, different last weight
+	if err := VerifyRange(proof, encryptedVote, 12, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a range proof for another bound")
+	}
+	if err := VerifyRange(proof, encryptedVote, 20, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a range proof with a missing bit")
+	}
+	if err := VerifyRange(nil, encryptedVote, 10, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a missing range proof")
+	}
+}
+
+func TestRangeProofCost(t *testing.T) {
+	tests := map[string]struct {
+		n        uint64
+		expected VerificationCost
+	}{
+		"empty range": {n: 0, expected: VerificationCost{ECAdd: 2, ECMul: 4, Keccak: 1, KeccakWords: 10}},
+		"one bit":     {n: 1, expected: VerificationCost{ECAdd: 9, ECMul: 12, Keccak: 2, KeccakWords: 24}},
+		"ten":         {n: 10, expected: VerificationCost{ECAdd: 30, ECMul: 42, Keccak: 5, KeccakWords: 66}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := RangeProofCost(tc.n); got != tc.expected {
+				t.Fatalf("expected: %+v, got: %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyRange(b *testing.B) {
+	keyPair := generateKeyPair(b, rand.Reader)
+	const n = 1000
+	encryptedVote, proof, err := EncryptValueWithRangeProof(rand.Reader, 700, n, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := VerifyRange(proof, encryptedVote, n, &keyPair.Pk); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package crypto
+
+import (
+	"errors"
+	"io"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// ProofReEncryption is a cryptographic proof that an encrypted vote is a
+// re-encryption of another one, i.e. that both encrypt the same value.
+//
+// The difference of the two encrypted votes is an encryption of 0 with
+// the re-encryption randomness s, so the proof is a proof of knowledge of
+// s such that the difference is (s*g, s*pk), with the same structure as a
+// proof of correct decryption.
+type ProofReEncryption struct {
+	S arith.Scalar    `json:"s"`
+	C arith.Challenge `json:"c"`
+}
+
+// Set sets p to q and returns q.
+func (q *ProofReEncryption) Set(p *ProofReEncryption) *ProofReEncryption {
+	q.S.Set(&p.S)
+	q.C.Set(&p.C)
+	return q
+}
+
+// ReEncrypt returns a fresh encryption of the same value as e, together
+// with the secret randomness of the re-encryption, which is needed to
+// prove it with ProveReEncryption. The randomness is hedged with
+// voterSecret, as in Vote.Encrypt. The caller should Destroy the secret
+// scalar once it is no longer needed.
+func (e *EncryptedVote) ReEncrypt(
+	reader io.Reader,
+	voterSecret *arith.SecretScalar,
+	pk *arith.CurvePoint) (*EncryptedVote, *arith.SecretScalar, error) {
+	zero, s, err := No.Encrypt(reader, voterSecret, pk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return zero.Add(zero, e), s, nil
+}
+
+// ProveReEncryption generates a proof that reEncrypted is a re-encryption
+// of original. In order to obtain a valid proof, the parameters should be
+// obtained in the following way:
+//
+//	reEncrypted, s, err := original.ReEncrypt(rand.Reader, voterSecret, pk)
+func ProveReEncryption(
+	reader io.Reader,
+	original *EncryptedVote,
+	reEncrypted *EncryptedVote,
+	s *arith.SecretScalar,
+	pk *arith.CurvePoint) (*ProofReEncryption, error) {
+	difference := new(EncryptedVote).Sub(reEncrypted, original)
+	proof, err := proveEncryptsZero(reader, difference, s, pk)
+	if err != nil {
+		return nil, err
+	}
+	return &ProofReEncryption{S: proof.S, C: proof.C}, nil
+}
+
+// VerifyReEncryption verifies a proof that reEncrypted is a re-encryption
+// of original.
+func VerifyReEncryption(
+	proof *ProofReEncryption,
+	original *EncryptedVote,
+	reEncrypted *EncryptedVote,
+	pk *arith.CurvePoint) error {
+	if proof == nil {
+		return errors.New("missing re-encryption proof")
+	}
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	if err := v.checkEncryptedVote(original, pk); err != nil {
+		return err
+	}
+	if err := v.checkEncryptedVote(reEncrypted, pk); err != nil {
+		return err
+	}
+	difference := new(EncryptedVote).Sub(reEncrypted, original)
+	zeroProof := &ProofCorrectDecryption{S: proof.S, C: proof.C}
+	if err := verifyEncryptsZero(v, zeroProof, difference, pk); err != nil {
+		return errors.New("re-encryption proof verification failed")
+	}
+	return nil
+}
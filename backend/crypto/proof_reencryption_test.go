@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestProveAndVerifyReEncryption(t *testing.T) {
+	tests := map[string]Vote{
+		"no":    No,
+		"yes":   Yes,
+		"large": 1000,
+	}
+	keyPair := generateKeyPair(t, rand.Reader)
+
+	for name, vote := range tests {
+		t.Run(name, func(t *testing.T) {
+			original, _, err := vote.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reEncrypted, s, err := original.ReEncrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Destroy()
+			if original.A.Equal(&reEncrypted.A) {
+				t.Fatal("re-encryption did not change the randomness")
+			}
+			got, err := reEncrypted.Decrypt(&keyPair.Sk, int64(vote))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != vote {
+				t.Fatalf("expected: %d, got: %d", vote, got)
+			}
+			proof, err := ProveReEncryption(rand.Reader, original, reEncrypted, s, &keyPair.Pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := VerifyReEncryption(proof, original, reEncrypted, &keyPair.Pk); err != nil {
+				t.Fatal(err)
+			}
+			if err := VerifyReEncryption(proof, reEncrypted, original, &keyPair.Pk); err == nil {
+				t.Fatal("successfully verified a re-encryption proof with swapped ciphertexts")
+			}
+		})
+	}
+}
+
+func TestVerifyReEncryptionOfOtherVote(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	original, _, err := Yes.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a fresh encryption of a different value, proved with its randomness
+	other, s, err := No.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Destroy()
+	proof, err := ProveReEncryption(rand.Reader, original, other, s, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyReEncryption(proof, original, other, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a re-encryption of another vote")
+	}
+	if err := VerifyReEncryption(nil, original, other, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a missing re-encryption proof")
+	}
+	if err := VerifyReEncryption(proof, original, &EncryptedVote{}, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a re-encryption proof for an invalid ciphertext")
+	}
+}
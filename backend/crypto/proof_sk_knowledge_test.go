@@ -16,3 +16,29 @@ func TestProveAndVerifySkKnowledge(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func BenchmarkProveSkKnowledge(b *testing.B) {
+	keyPair := generateKeyPair(b, rand.Reader)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ProveSkKnowledge(rand.Reader, keyPair); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerifySkKnowledge(b *testing.B) {
+	keyPair := generateKeyPair(b, rand.Reader)
+	proof, err := ProveSkKnowledge(rand.Reader, keyPair)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := VerifySkKnowledge(proof, &keyPair.Pk); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
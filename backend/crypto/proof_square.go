@@ -0,0 +1,213 @@
+package crypto
+
+import (
+	"errors"
+	"io"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// ProofSquare is a cryptographic proof that an encrypted vote (A', B')
+// encrypts the square of the value of another encrypted vote (A, B).
+//
+// If (A, B) = (r*g, r*pk + v*g), then (A', B') = (v*A + t*g, v*B + t*pk)
+// encrypts v^2 with randomness v*r + t. The proof is a proof of knowledge
+// of v, r and t satisfying these four linear relations.
+type ProofSquare struct {
+	C  arith.Challenge `json:"c"`
+	Zv arith.Scalar    `json:"zv"`
+	Zr arith.Scalar    `json:"zr"`
+	Zt arith.Scalar    `json:"zt"`
+}
+
+// Set sets p to q and returns q.
+func (q *ProofSquare) Set(p *ProofSquare) *ProofSquare {
+	q.C.Set(&p.C)
+	q.Zv.Set(&p.Zv)
+	q.Zr.Set(&p.Zr)
+	q.Zt.Set(&p.Zt)
+	return q
+}
+
+// EncryptSquare returns an encryption of the square of v, the value of
+// encryptedVote, and its secret randomness t, which should be passed to
+// ProveSquare and then destroyed. r is the randomness of encryptedVote:
+// it keeps t unpredictable even if reader is weak, since v alone is small
+// enough to be guessed.
+func EncryptSquare(
+	reader io.Reader,
+	encryptedVote *EncryptedVote,
+	v *arith.SecretScalar,
+	r *arith.SecretScalar,
+	pk *arith.CurvePoint) (*EncryptedVote, *arith.SecretScalar, error) {
+	nonces, err := hedgedReader(
+		reader,
+		squareEncryptionNonceDomain,
+		[]*arith.SecretScalar{v, r},
+		pk,
+		&encryptedVote.A,
+		&encryptedVote.B)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer nonces.destroy()
+	t, err := arith.RandomSecretScalar(nonces)
+	if err != nil {
+		return nil, nil, err
+	}
+	tG := new(arith.CurvePoint).ScalarBaseMultSecret(t)
+	tPk := new(arith.CurvePoint).ScalarMultSecret(pk, t)
+	square := new(EncryptedVote)
+	square.A.ScalarMultSecret(&encryptedVote.A, v)
+	square.A.Add(&square.A, tG)
+	square.B.ScalarMultSecret(&encryptedVote.B, v)
+	square.B.Add(&square.B, tPk)
+	return square, t, nil
+}
+
+// ProveSquare generates a proof that square encrypts the square of the
+// value v of encryptedVote. The parameters should be obtained in the
+// following way:
+//
+//	encryptedVote, r, err := vote.Encrypt(rand.Reader, voterSecret, pk)
+//	square, t, err := EncryptSquare(rand.Reader, encryptedVote, v, r, pk)
+func ProveSquare(
+	reader io.Reader,
+	encryptedVote *EncryptedVote,
+	square *EncryptedVote,
+	v *arith.SecretScalar,
+	r *arith.SecretScalar,
+	t *arith.SecretScalar,
+	pk *arith.CurvePoint) (*ProofSquare, error) {
+	nonces, err := hedgedReader(
+		reader,
+		squareNonceDomain,
+		[]*arith.SecretScalar{v, r, t},
+		pk,
+		&encryptedVote.A,
+		&encryptedVote.B,
+		&square.A,
+		&square.B)
+	if err != nil {
+		return nil, err
+	}
+	defer nonces.destroy()
+	kv, err := arith.RandomSecretScalar(nonces)
+	if err != nil {
+		return nil, err
+	}
+	defer kv.Destroy()
+	kr, err := arith.RandomSecretScalar(nonces)
+	if err != nil {
+		return nil, err
+	}
+	defer kr.Destroy()
+	kt, err := arith.RandomSecretScalar(nonces)
+	if err != nil {
+		return nil, err
+	}
+	defer kt.Destroy()
+
+	// t1 = kr*g
+	t1 := new(arith.CurvePoint).ScalarBaseMultSecret(kr)
+	// t2 = kr*pk + kv*g
+	t2 := new(arith.CurvePoint).ScalarMultSecret(pk, kr)
+	t2.Add(t2, new(arith.CurvePoint).ScalarBaseMultSecret(kv))
+	// t3 = kv*A + kt*g
+	t3 := new(arith.CurvePoint).ScalarMultSecret(&encryptedVote.A, kv)
+	t3.Add(t3, new(arith.CurvePoint).ScalarBaseMultSecret(kt))
+	// t4 = kv*B + kt*pk
+	t4 := new(arith.CurvePoint).ScalarMultSecret(&encryptedVote.B, kv)
+	t4.Add(t4, new(arith.CurvePoint).ScalarMultSecret(pk, kt))
+
+	points := []*arith.CurvePoint{
+		pk, &encryptedVote.A, &encryptedVote.B, &square.A, &square.B, t1, t2, t3, t4,
+	}
+	data := make([][]byte, len(points))
+	for i, p := range points {
+		if data[i], err = p.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	c := arith.FiatShamirChallenge(data...)
+
+	proof := new(ProofSquare)
+	proof.C.Set(c)
+	z := arith.NewSecretScalar(c.Scalar())
+	defer z.Destroy()
+	cs := arith.NewSecretScalar(c.Scalar())
+	defer cs.Destroy()
+	for _, w := range []struct {
+		k, x *arith.SecretScalar
+		z    *arith.Scalar
+	}{{kv, v, &proof.Zv}, {kr, r, &proof.Zr}, {kt, t, &proof.Zt}} {
+		// z = k + c*x
+		z.Mul(cs, w.x)
+		z.Add(w.k, z)
+		w.z.Set(z.Declassify())
+	}
+	return proof, nil
+}
+
+// VerifySquare verifies a proof that square encrypts the square of the
+// value of encryptedVote.
+func VerifySquare(
+	proof *ProofSquare,
+	encryptedVote *EncryptedVote,
+	square *EncryptedVote,
+	pk *arith.CurvePoint) error {
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	return v.VerifySquare(proof, encryptedVote, square, pk)
+}
+
+// VerifySquare is like the function VerifySquare.
+func (v *Verifier) VerifySquare(
+	proof *ProofSquare,
+	encryptedVote *EncryptedVote,
+	square *EncryptedVote,
+	pk *arith.CurvePoint) error {
+	if proof == nil {
+		return errors.New("missing square proof")
+	}
+	if err := v.checkEncryptedVote(encryptedVote, pk); err != nil {
+		return err
+	}
+	if err := v.checkEncryptedVote(square, pk); err != nil {
+		return err
+	}
+	pkCopy, a, b, a2, b2 := &v.p[0], &v.p[1], &v.p[2], &v.p[3], &v.p[4]
+	t1, t2, t3, t4, tmp := &v.p[5], &v.p[6], &v.p[7], &v.p[8], &v.p[9]
+	pkCopy.Set(pk)
+	a.Set(&encryptedVote.A)
+	b.Set(&encryptedVote.B)
+	a2.Set(&square.A)
+	b2.Set(&square.B)
+	negC := v.s[0].SetChallenge(&proof.C)
+	negC.Neg(negC)
+
+	// t1 = zr*g - c*A
+	t1.ScalarBaseMult(&proof.Zr)
+	t1.Add(t1, tmp.ScalarMult(a, negC))
+	// t2 = zr*pk + zv*g - c*B
+	t2.ScalarMult(pkCopy, &proof.Zr)
+	t2.Add(t2, tmp.ScalarBaseMult(&proof.Zv))
+	t2.Add(t2, tmp.ScalarMult(b, negC))
+	// t3 = zv*A + zt*g - c*A'
+	t3.ScalarMult(a, &proof.Zv)
+	t3.Add(t3, tmp.ScalarBaseMult(&proof.Zt))
+	t3.Add(t3, tmp.ScalarMult(a2, negC))
+	// t4 = zv*B + zt*pk - c*B'
+	t4.ScalarMult(b, &proof.Zv)
+	t4.Add(t4, tmp.ScalarMult(pkCopy, &proof.Zt))
+	t4.Add(t4, tmp.ScalarMult(b2, negC))
+
+	v.hasher.Reset()
+	if err := v.write(pkCopy, a, b, a2, b2, t1, t2, t3, t4); err != nil {
+		return err
+	}
+	if !v.hasher.Challenge(&v.c).Equal(&proof.C) {
+		return errors.New("square proof verification failed")
+	}
+	return nil
+}
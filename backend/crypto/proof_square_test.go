@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+func TestProveAndVerifySquare(t *testing.T) {
+	tests := map[string]int64{
+		"zero":  0,
+		"one":   1,
+		"small": 7,
+		"large": 1000,
+	}
+	keyPair := generateKeyPair(t, rand.Reader)
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			encryptedVote, square, proof := generateSquare(t, value, value, &keyPair.Pk)
+			if err := VerifySquare(proof, encryptedVote, square, &keyPair.Pk); err != nil {
+				t.Fatal(err)
+			}
+			got, err := square.Decrypt(&keyPair.Sk, value*value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if int64(got) != value*value {
+				t.Fatalf("expected: %d, got: %d", value*value, got)
+			}
+			other, _, err := Vote(value).Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := VerifySquare(proof, other, square, &keyPair.Pk); err == nil {
+				t.Fatal("successfully verified a square proof for another encrypted vote")
+			}
+		})
+	}
+}
+
+func TestVerifySquareWrongSquare(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	// the prover claims that the encryption of 3 has square 10
+	encryptedVote, square, proof := generateSquare(t, 3, 10, &keyPair.Pk)
+	if err := VerifySquare(proof, encryptedVote, square, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a square proof for the wrong square")
+	}
+	if err := VerifySquare(nil, encryptedVote, square, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a missing square proof")
+	}
+	if err := VerifySquare(proof, encryptedVote, &EncryptedVote{}, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a square proof for an invalid square")
+	}
+}
+
+// generateSquare encrypts value, encrypts the product of value and
+// factor with EncryptSquare, and proves it the square of value.
+func generateSquare(t *testing.T, value, factor int64, pk *arith.CurvePoint) (*EncryptedVote, *EncryptedVote, *ProofSquare) {
+	t.Helper()
+	encryptedVote, r, err := Vote(value).Encrypt(rand.Reader, testVoterSecret, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Destroy()
+	v := arith.NewSecretScalar(new(arith.Scalar).SetInt64(value))
+	f := arith.NewSecretScalar(new(arith.Scalar).SetInt64(factor))
+	square, s, err := EncryptSquare(rand.Reader, encryptedVote, f, r, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Destroy()
+	proof, err := ProveSquare(rand.Reader, encryptedVote, square, v, r, s, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encryptedVote, square, proof
+}
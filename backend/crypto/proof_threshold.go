@@ -0,0 +1,150 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// ProofThreshold is a cryptographic proof that the plaintext m of an
+// encrypted tally is at least a threshold t, or below it, which does not
+// reveal anything else about m.
+//
+// Let x = m - t if m >= t, and x = t - 1 - m otherwise, so that x is
+// non-negative exactly when the claimed outcome is correct. The proof
+// carries fresh encryptions of the bits of x, each one with a proof of
+// well-formedness, and a proof that the encrypted tally, shifted by the
+// threshold, encrypts the same value as the weighted sum of the bits. The
+// latter is a proof of correct decryption of their difference to zero.
+type ProofThreshold struct {
+	Bits      []EncryptedVote           `json:"bits"`
+	BitProofs []ProofVoteWellFormedness `json:"bitProofs"`
+	Equality  ProofCorrectDecryption    `json:"equality"`
+}
+
+// ThresholdNumBits is the number of bits of the range proof in a
+// ProofThreshold for a tally bounded by n and threshold t. It only depends
+// on public values, so that the size of the proof does not leak the
+// outcome.
+func ThresholdNumBits(n, t uint64) int {
+	if t > n {
+		return bits.Len64(t)
+	}
+	return bits.Len64(n)
+}
+
+// ProveThreshold decrypts an encrypted tally, whose plaintext should be at
+// most n, and proves whether it is at least threshold t. It returns the
+// outcome and its proof.
+func ProveThreshold(
+	reader io.Reader,
+	tally *EncryptedVote,
+	n uint64,
+	t uint64,
+	keyPair *KeyPair) (bool, *ProofThreshold, error) {
+	if n > math.MaxInt64 {
+		return false, nil, fmt.Errorf("upper bound %d overflows int64", n)
+	}
+	decrypted, err := tally.Decrypt(&keyPair.Sk, int64(n))
+	if err != nil {
+		return false, nil, err
+	}
+	m := uint64(decrypted)
+	passed := m >= t
+	var x uint64
+	if passed {
+		x = m - t
+	} else {
+		x = t - 1 - m
+	}
+
+	// the bits are encrypted with the nonces, which are hedged with sk since
+	// x alone may be guessed
+	nonces, err := hedgedReader(
+		reader,
+		thresholdNonceDomain,
+		[]*arith.SecretScalar{&keyPair.Sk},
+		&keyPair.Pk,
+		&tally.A,
+		&tally.B)
+	if err != nil {
+		return false, nil, err
+	}
+	defer nonces.destroy()
+	bits, bitProofs, r, err := encryptBits(nonces, x, powersOfTwo(ThresholdNumBits(n, t)), &keyPair.Pk)
+	if err != nil {
+		return false, nil, err
+	}
+	r.Destroy()
+	proof := &ProofThreshold{Bits: bits, BitProofs: bitProofs}
+
+	difference := thresholdDifference(tally, t, passed, proof.Bits)
+	equality, err := ProveCorrectDecryption(nonces, difference, keyPair)
+	if err != nil {
+		return false, nil, err
+	}
+	proof.Equality.Set(equality)
+	return passed, proof, nil
+}
+
+// VerifyThreshold verifies a proof that the plaintext of an encrypted
+// tally, which should be at most n, is at least threshold t if passed is
+// true, and below t otherwise.
+func VerifyThreshold(
+	proof *ProofThreshold,
+	tally *EncryptedVote,
+	n uint64,
+	t uint64,
+	passed bool,
+	pk *arith.CurvePoint) error {
+	if proof == nil {
+		return errors.New("missing threshold proof")
+	}
+	if tally == nil || !tally.A.IsValid() || !tally.B.IsValid() {
+		return errors.New("invalid encrypted tally")
+	}
+	if !passed && t == 0 {
+		return errors.New("a tally cannot be below threshold 0")
+	}
+	numBits := ThresholdNumBits(n, t)
+	if len(proof.Bits) != numBits || len(proof.BitProofs) != numBits {
+		return fmt.Errorf("threshold proof should have %d bits", numBits)
+	}
+
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	if err := verifyBits(v, proof.Bits, proof.BitProofs, pk); err != nil {
+		return err
+	}
+	difference := thresholdDifference(tally, t, passed, proof.Bits)
+	if err := v.VerifyCorrectDecryption(&proof.Equality, difference, No, pk); err != nil {
+		return errors.New("threshold proof verification failed")
+	}
+	return nil
+}
+
+// thresholdDifference returns the encryption of x - sum(2^i * bits[i]),
+// where x is obtained from the tally as described in ProofThreshold. It
+// encrypts 0 if and only if the bits encrypt the binary representation of
+// x.
+func thresholdDifference(tally *EncryptedVote, t uint64, passed bool, bits []EncryptedVote) *EncryptedVote {
+	res := new(EncryptedVote)
+	tG := new(arith.CurvePoint).ScalarBaseMult(new(arith.Scalar).SetUint64(t))
+	if passed {
+		// m - t
+		res.A.Set(&tally.A)
+		res.B.Add(&tally.B, new(arith.CurvePoint).Neg(tG))
+	} else {
+		// t - 1 - m
+		t1G := new(arith.CurvePoint).ScalarBaseMult(new(arith.Scalar).SetInt64(-1))
+		t1G.Add(t1G, tG)
+		res.Neg(tally)
+		res.B.Add(&res.B, t1G)
+	}
+
+	return res.Sub(res, recombineBits(bits, powersOfTwo(len(bits))))
+}
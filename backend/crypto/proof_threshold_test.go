@@ -0,0 +1,78 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+func TestProveAndVerifyThreshold(t *testing.T) {
+	tests := map[string]struct {
+		result, n, threshold uint64
+		passed               bool
+	}{
+		"well above":      {result: 40, n: 50, threshold: 26, passed: true},
+		"equal":           {result: 26, n: 50, threshold: 26, passed: true},
+		"just below":      {result: 25, n: 50, threshold: 26, passed: false},
+		"zero result":     {result: 0, n: 50, threshold: 1, passed: false},
+		"zero threshold":  {result: 0, n: 50, threshold: 0, passed: true},
+		"all in favor":    {result: 50, n: 50, threshold: 26, passed: true},
+		"above bound":     {result: 7, n: 7, threshold: 9, passed: false},
+		"power of two":    {result: 64, n: 64, threshold: 64, passed: true},
+		"no votes at all": {result: 0, n: 0, threshold: 1, passed: false},
+	}
+	keyPair := generateKeyPair(t, rand.Reader)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tally := encryptTally(t, tc.result, &keyPair.Pk)
+			passed, proof, err := ProveThreshold(rand.Reader, tally, tc.n, tc.threshold, keyPair)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if passed != tc.passed {
+				t.Fatalf("expected outcome: %t, got: %t", tc.passed, passed)
+			}
+			if err := VerifyThreshold(proof, tally, tc.n, tc.threshold, passed, &keyPair.Pk); err != nil {
+				t.Fatal(err)
+			}
+			if err := VerifyThreshold(proof, tally, tc.n, tc.threshold, !passed, &keyPair.Pk); err == nil {
+				t.Fatal("successfully verified a threshold proof for the wrong outcome")
+			}
+			otherTally := encryptTally(t, tc.result, &keyPair.Pk)
+			if err := VerifyThreshold(proof, otherTally, tc.n, tc.threshold, passed, &keyPair.Pk); err == nil {
+				t.Fatal("successfully verified a threshold proof for another tally")
+			}
+		})
+	}
+}
+
+func TestVerifyThresholdWrongThreshold(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	tally := encryptTally(t, 30, &keyPair.Pk)
+	passed, proof, err := ProveThreshold(rand.Reader, tally, 50, 26, keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// same number of bits, so only the equality proof can catch it
+	if err := VerifyThreshold(proof, tally, 50, 20, passed, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a threshold proof for another threshold")
+	}
+	proof.Bits = proof.Bits[1:]
+	if err := VerifyThreshold(proof, tally, 50, 26, passed, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a threshold proof with a missing bit")
+	}
+}
+
+// encryptTally returns an encryption of result with unknown randomness,
+// like a homomorphic tally.
+func encryptTally(t *testing.T, result uint64, pk *arith.CurvePoint) *EncryptedVote {
+	t.Helper()
+	encryptedVote, r, err := Vote(result).Encrypt(rand.Reader, testVoterSecret, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Destroy()
+	return encryptedVote
+}
@@ -1,7 +1,6 @@
 package crypto
 
 import (
-	"encoding/json"
 	"errors"
 	"io"
 	"math/big"
@@ -31,12 +30,12 @@ func (q *ProofVoteWellFormedness) Set(p *ProofVoteWellFormedness) *ProofVoteWell
 // In order to obtain a valid proof, the parameters should be obtained in the following
 // way:
 //
-//	encryptedVote, r, err := vote.Encrypt(rand.Reader, pk)
+//	encryptedVote, r, err := vote.Encrypt(rand.Reader, voterSecret, pk)
 func ProveVoteWellFormedness(
 	reader io.Reader,
 	encryptedVote *EncryptedVote,
 	vote Vote,
-	r *arith.Scalar,
+	r *arith.SecretScalar,
 	pk *arith.CurvePoint) (*ProofVoteWellFormedness, error) {
 	// Implementation based on https://eprint.iacr.org/2016/765.pdf, section 4.5
 
@@ -51,12 +50,26 @@ func ProveVoteWellFormedness(
 	default:
 		return nil, errors.New("proof of vote well formedness can only be generated for yes/no vote")
 	}
-	cCheat, err := arith.RandomChallenge(reader)
+	// the vote is secret too, since it selects the cheating alternative
+	secretVote := arith.NewSecretScalar(new(arith.Scalar).SetInt64(int64(vote)))
+	defer secretVote.Destroy()
+	nonces, err := hedgedReader(
+		reader,
+		voteWellFormednessNonceDomain,
+		[]*arith.SecretScalar{r, secretVote},
+		pk,
+		&encryptedVote.A,
+		&encryptedVote.B)
+	if err != nil {
+		return nil, err
+	}
+	defer nonces.destroy()
+	cCheat, err := arith.RandomChallenge(nonces)
 	if err != nil {
 		return nil, err
 	}
 
-	rCheat, rCheatG, err := arith.RandomCurvePoint(reader)
+	rCheat, rCheatG, err := arith.RandomCurvePoint(nonces)
 	if err != nil {
 		return nil, err
 	}
@@ -71,11 +84,13 @@ func ProveVoteWellFormedness(
 	bCheat := new(arith.CurvePoint).Add(rCheatPk, cCheatNegB)
 
 	// Generate honest proof for chosen alternative
-	rPrime, aHonest, err := arith.RandomCurvePoint(reader)
+	rPrime, err := arith.RandomSecretScalar(nonces)
 	if err != nil {
 		return nil, err
 	}
-	bHonest := new(arith.CurvePoint).ScalarMult(pk, rPrime)
+	defer rPrime.Destroy()
+	aHonest := new(arith.CurvePoint).ScalarBaseMultSecret(rPrime)
+	bHonest := new(arith.CurvePoint).ScalarMultSecret(pk, rPrime)
 
 	bytesPk, err := pk.MarshalBinary()
 	if err != nil {
@@ -130,8 +145,11 @@ func ProveVoteWellFormedness(
 
 	cHonest := new(arith.Challenge).Sub(c, cCheat)
 
-	cHonestR := new(arith.Scalar).Mul(cHonest.Scalar(), r)
-	rHonest := new(arith.Scalar).Add(rPrime, cHonestR)
+	s := arith.NewSecretScalar(cHonest.Scalar())
+	defer s.Destroy()
+	s.Mul(s, r)
+	s.Add(rPrime, s)
+	rHonest := s.Declassify()
 
 	proof := new(ProofVoteWellFormedness)
 	switch vote {
@@ -154,77 +172,7 @@ func VerifyVoteWellFormedness(
 	proof *ProofVoteWellFormedness,
 	vote *EncryptedVote,
 	pk *arith.CurvePoint) error {
-	// Implementation based on https://eprint.iacr.org/2016/765.pdf, section 4.5
-
-	m, err := json.Marshal(proof)
-	if err != nil {
-		return err
-	}
-	proof = new(ProofVoteWellFormedness)
-	err = json.Unmarshal(m, proof)
-	if err != nil {
-		return err
-	}
-
-	r0G := new(arith.CurvePoint).ScalarBaseMult(&proof.R0)
-	c0A := new(arith.CurvePoint).ScalarMult(&vote.A, proof.C0.Scalar())
-	a0 := new(arith.CurvePoint).Add(r0G, new(arith.CurvePoint).Neg(c0A))
-
-	r1G := new(arith.CurvePoint).ScalarBaseMult(&proof.R1)
-	c1A := new(arith.CurvePoint).ScalarMult(&vote.A, proof.C1.Scalar())
-	a1 := new(arith.CurvePoint).Add(r1G, new(arith.CurvePoint).Neg(c1A))
-
-	r0Pk := new(arith.CurvePoint).ScalarMult(pk, &proof.R0)
-	c0B := new(arith.CurvePoint).ScalarMult(&vote.B, proof.C0.Scalar())
-	b0 := new(arith.CurvePoint).Add(r0Pk, new(arith.CurvePoint).Neg(c0B))
-
-	r1Pk := new(arith.CurvePoint).ScalarMult(pk, &proof.R1)
-	gNeg := new(arith.CurvePoint).ScalarBaseMult(arith.NewScalar(big.NewInt(-1)))
-	bMinusG := new(arith.CurvePoint).Add(&vote.B, gNeg)
-	c1BMinusG := new(arith.CurvePoint).ScalarMult(bMinusG, proof.C1.Scalar())
-	b1 := new(arith.CurvePoint).Add(r1Pk, new(arith.CurvePoint).Neg(c1BMinusG))
-
-	bytesPk, err := pk.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	bytesA, err := vote.A.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	bytesB, err := vote.B.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	bytesA0, err := a0.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	bytesB0, err := b0.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	bytesA1, err := a1.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	bytesB1, err := b1.MarshalBinary()
-	if err != nil {
-		return err
-	}
-
-	c := arith.FiatShamirChallenge(
-		bytesPk,
-		bytesA,
-		bytesB,
-		bytesA0,
-		bytesB0,
-		bytesA1,
-		bytesB1,
-	)
-
-	if !c.Equal(new(arith.Challenge).Add(&proof.C0, &proof.C1)) {
-		return errors.New("vote well-formedness proof verification failed")
-	}
-	return nil
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	return v.VerifyVoteWellFormedness(proof, vote, pk)
 }
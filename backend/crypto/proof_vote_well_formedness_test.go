@@ -11,7 +11,7 @@ func TestProveAndVerifyVoteWellFormedness(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			encryptedVote, secret, err := tc.vote.Encrypt(rand.Reader, &keyPair.Pk)
+			encryptedVote, secret, err := tc.vote.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -40,7 +40,7 @@ func TestVerifyVoteWellFormednessWithDifferentVote(t *testing.T) {
 	for name, tc := range tests {
 		if tc.isProvable {
 			t.Run(name, func(t *testing.T) {
-				encryptedVote, secret, err := tc.vote.Encrypt(rand.Reader, &keyPair.Pk)
+				encryptedVote, secret, err := tc.vote.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -48,7 +48,7 @@ func TestVerifyVoteWellFormednessWithDifferentVote(t *testing.T) {
 				if err != nil {
 					t.Fatal(err)
 				}
-				newVote, _, err := tc.vote.Encrypt(rand.Reader, &keyPair.Pk)
+				newVote, _, err := tc.vote.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -74,3 +74,33 @@ func generateVoteWellFormednessTests() voteWellFormednessTests {
 	}
 	return tests
 }
+
+func BenchmarkProveVoteWellFormedness(b *testing.B) {
+	keyPair := generateKeyPair(b, rand.Reader)
+	encryptedVote, r, err := Yes.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ProveVoteWellFormedness(rand.Reader, encryptedVote, Yes, r, &keyPair.Pk); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerifyVoteWellFormedness(b *testing.B) {
+	keyPair := generateKeyPair(b, rand.Reader)
+	encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := VerifyVoteWellFormedness(proof, encryptedVote, &keyPair.Pk); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
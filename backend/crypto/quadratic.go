@@ -0,0 +1,196 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// QuadraticBallot is a vote which spends a budget of credits across a list
+// of options, where casting v votes for an option costs v^2 credits.
+//
+// Votes[i] encrypts the number of votes v_i for option i, which
+// VoteProofs[i] proves in [0, MaxQuadraticVotes(budget)], so that v_i^2
+// cannot wrap around the order of the group. Squares[i] encrypts v_i^2,
+// which SquareProofs[i] proves. Budget proves that the sum of the squares,
+// i.e. the credits spent, is in [0, budget].
+type QuadraticBallot struct {
+	Votes        []EncryptedVote `json:"votes"`
+	Squares      []EncryptedVote `json:"squares"`
+	VoteProofs   []ProofRange    `json:"voteProofs"`
+	SquareProofs []ProofSquare   `json:"squareProofs"`
+	Budget       ProofRange      `json:"budget"`
+}
+
+// MaxQuadraticVotes returns the largest number of votes which can be cast
+// for a single option with budget credits, i.e. the integer square root of
+// budget.
+func MaxQuadraticVotes(budget uint64) uint64 {
+	x := uint64(math.Sqrt(float64(budget)))
+	// fix the rounding errors of float64, keeping x*x within uint64
+	if x > math.MaxUint32 {
+		x = math.MaxUint32
+	}
+	for x*x > budget {
+		x--
+	}
+	for x < math.MaxUint32 && (x+1)*(x+1) <= budget {
+		x++
+	}
+	return x
+}
+
+// EncryptQuadraticBallot encrypts a quadratic ballot which casts votes[i]
+// votes for option i, spending at most budget credits. The randomness of
+// the encryptions is hedged with voterSecret (see NewVoterSecret).
+func EncryptQuadraticBallot(
+	reader io.Reader,
+	votes []uint64,
+	budget uint64,
+	voterSecret *arith.SecretScalar,
+	pk *arith.CurvePoint) (*QuadraticBallot, error) {
+	if len(votes) == 0 {
+		return nil, errors.New("quadratic ballot should have at least one option")
+	}
+	if err := checkQuadraticBudget(budget); err != nil {
+		return nil, err
+	}
+	maxVotes := MaxQuadraticVotes(budget)
+	var spent, carry uint64
+	for i, v := range votes {
+		if v > maxVotes {
+			return nil, fmt.Errorf("option %d: %d votes cost more than the budget", i, v)
+		}
+		spent, carry = bits.Add64(spent, v*v, carry)
+	}
+	if carry != 0 || spent > budget {
+		return nil, fmt.Errorf("votes cost more than the budget %d", budget)
+	}
+	nonces, err := newVoterReader(reader, voterSecret, pk)
+	if err != nil {
+		return nil, err
+	}
+	defer nonces.destroy()
+
+	n := len(votes)
+	ballot := &QuadraticBallot{
+		Votes:        make([]EncryptedVote, n),
+		Squares:      make([]EncryptedVote, n),
+		VoteProofs:   make([]ProofRange, n),
+		SquareProofs: make([]ProofSquare, n),
+	}
+	// randomness of the sum of the squares
+	rSum := new(arith.SecretScalar)
+	defer rSum.Destroy()
+	rSquare := new(arith.SecretScalar)
+	defer rSquare.Destroy()
+	for i, vote := range votes {
+		if err := ballot.encryptOption(nonces, i, vote, maxVotes, rSquare, pk); err != nil {
+			return nil, fmt.Errorf("option %d: %w", i, err)
+		}
+		rSum.Add(rSum, rSquare)
+	}
+
+	budgetProof, err := ProveRange(nonces, sumEntries(ballot.Squares), spent, rSum, budget, pk)
+	if err != nil {
+		return nil, err
+	}
+	ballot.Budget = *budgetProof
+	return ballot, nil
+}
+
+// encryptOption encrypts vote for option i, its square and their proofs,
+// and sets rSquare to the randomness of the square. reader should be
+// hedged with the voter secret (see newVoterReader).
+func (ballot *QuadraticBallot) encryptOption(
+	reader io.Reader,
+	i int,
+	vote uint64,
+	maxVotes uint64,
+	rSquare *arith.SecretScalar,
+	pk *arith.CurvePoint) error {
+	encryptedVote, r, err := Vote(vote).encrypt(reader, pk)
+	if err != nil {
+		return err
+	}
+	defer r.Destroy()
+	voteProof, err := ProveRange(reader, encryptedVote, vote, r, maxVotes, pk)
+	if err != nil {
+		return err
+	}
+
+	v := arith.NewSecretScalar(new(arith.Scalar).SetUint64(vote))
+	defer v.Destroy()
+	square, t, err := EncryptSquare(reader, encryptedVote, v, r, pk)
+	if err != nil {
+		return err
+	}
+	defer t.Destroy()
+	squareProof, err := ProveSquare(reader, encryptedVote, square, v, r, t, pk)
+	if err != nil {
+		return err
+	}
+
+	// the square is encrypted with randomness v*r + t
+	rSquare.Mul(v, r)
+	rSquare.Add(rSquare, t)
+	ballot.Votes[i].Set(encryptedVote)
+	ballot.Squares[i].Set(square)
+	ballot.VoteProofs[i] = *voteProof
+	ballot.SquareProofs[i].Set(squareProof)
+	return nil
+}
+
+// VerifyQuadraticBallot verifies a quadratic ballot on numOptions options,
+// which should spend at most budget credits.
+func VerifyQuadraticBallot(ballot *QuadraticBallot, numOptions int, budget uint64, pk *arith.CurvePoint) error {
+	if ballot == nil {
+		return errors.New("missing quadratic ballot")
+	}
+	if err := checkQuadraticBudget(budget); err != nil {
+		return err
+	}
+	if len(ballot.Votes) != numOptions ||
+		len(ballot.Squares) != numOptions ||
+		len(ballot.VoteProofs) != numOptions ||
+		len(ballot.SquareProofs) != numOptions {
+		return fmt.Errorf("quadratic ballot should have %d votes, squares and proofs", numOptions)
+	}
+	maxVotes := MaxQuadraticVotes(budget)
+	v := verifierPool.Get().(*Verifier)
+	defer verifierPool.Put(v)
+	for i := range ballot.Votes {
+		if err := VerifyRange(&ballot.VoteProofs[i], &ballot.Votes[i], maxVotes, pk); err != nil {
+			return fmt.Errorf("option %d: %w", i, err)
+		}
+		if err := v.VerifySquare(&ballot.SquareProofs[i], &ballot.Votes[i], &ballot.Squares[i], pk); err != nil {
+			return fmt.Errorf("option %d: %w", i, err)
+		}
+	}
+	if err := VerifyRange(&ballot.Budget, sumEntries(ballot.Squares), budget, pk); err != nil {
+		return fmt.Errorf("votes cost more than the budget: %w", err)
+	}
+	return nil
+}
+
+// QuadraticBallotCost returns the cost of the on-chain verification of a
+// quadratic ballot (see VerificationCost).
+func QuadraticBallotCost(numOptions int, budget uint64) VerificationCost {
+	perOption := RangeProofCost(MaxQuadraticVotes(budget)).Add(SquareCost)
+	return perOption.Times(numOptions).
+		Add(VerificationCost{ECAdd: 2 * (numOptions - 1)}).
+		Add(RangeProofCost(budget))
+}
+
+// checkQuadraticBudget rejects budgets whose tallies could not be
+// decrypted.
+func checkQuadraticBudget(budget uint64) error {
+	if budget > math.MaxInt64 {
+		return fmt.Errorf("budget %d overflows int64", budget)
+	}
+	return nil
+}
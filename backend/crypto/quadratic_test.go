@@ -0,0 +1,119 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"math"
+	"testing"
+)
+
+func TestMaxQuadraticVotes(t *testing.T) {
+	tests := map[uint64]uint64{
+		0:              0,
+		1:              1,
+		3:              1,
+		4:              2,
+		99:             9,
+		100:            10,
+		1<<62 - 1:      1<<31 - 1,
+		math.MaxInt64:  3037000499,
+		math.MaxUint64: 1<<32 - 1,
+	}
+	for budget, expected := range tests {
+		if got := MaxQuadraticVotes(budget); got != expected {
+			t.Fatalf("budget %d: expected: %d, got: %d", budget, expected, got)
+		}
+	}
+}
+
+func TestQuadraticVoting(t *testing.T) {
+	tests := map[string]struct {
+		ballots [][]uint64
+		budget  uint64
+	}{
+		"single voter":     {ballots: [][]uint64{{3, 0, 1}}, budget: 10},
+		"whole budget":     {ballots: [][]uint64{{3, 1}, {0, 3}}, budget: 10},
+		"several voters":   {ballots: [][]uint64{{1, 2, 3}, {4, 0, 0}, {2, 2, 2}, {0, 0, 0}}, budget: 16},
+		"concentrated":     {ballots: [][]uint64{{0, 0, 4}}, budget: 16},
+		"unspent budget":   {ballots: [][]uint64{{1, 1}}, budget: 100},
+		"zero budget":      {ballots: [][]uint64{{0, 0}}, budget: 0},
+		"odd sized budget": {ballots: [][]uint64{{2, 2, 2}}, budget: 13},
+	}
+	keyPair := generateKeyPair(t, rand.Reader)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			numOptions := len(tc.ballots[0])
+			tally := NewOptionTally(numOptions)
+			expected := make([]int64, numOptions)
+			for _, votes := range tc.ballots {
+				ballot, err := EncryptQuadraticBallot(rand.Reader, votes, tc.budget, testVoterSecret, &keyPair.Pk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := VerifyQuadraticBallot(ballot, numOptions, tc.budget, &keyPair.Pk); err != nil {
+					t.Fatal(err)
+				}
+				if err := tally.Add(ballot.Votes, 1); err != nil {
+					t.Fatal(err)
+				}
+				for i, v := range votes {
+					expected[i] += int64(v)
+				}
+			}
+
+			n := int64(MaxQuadraticVotes(tc.budget)) * int64(len(tc.ballots))
+			results, proofs, err := DecryptOptionTallyWithProof(rand.Reader, tally, n, keyPair)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range expected {
+				if results[i] != expected[i] {
+					t.Fatalf("expected: %v, got: %v", expected, results)
+				}
+			}
+			if err := VerifyOptionTally(tally, results, proofs, &keyPair.Pk); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestQuadraticBallotOverBudget(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	tests := map[string][]uint64{
+		"single option over budget": {4, 0},
+		"sum over budget":           {3, 2},
+	}
+	for name, votes := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := EncryptQuadraticBallot(rand.Reader, votes, 10, testVoterSecret, &keyPair.Pk); err == nil {
+				t.Fatal("encrypted a quadratic ballot over the budget")
+			}
+		})
+	}
+
+	// a valid ballot for budget 13 is over budget 10
+	ballot, err := EncryptQuadraticBallot(rand.Reader, []uint64{3, 2}, 13, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyQuadraticBallot(ballot, 2, 10, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a quadratic ballot over the budget")
+	}
+	if err := VerifyQuadraticBallot(ballot, 3, 13, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a quadratic ballot with a missing option")
+	}
+	// swapping the squares breaks the square proofs
+	ballot.Squares[0], ballot.Squares[1] = ballot.Squares[1], ballot.Squares[0]
+	if err := VerifyQuadraticBallot(ballot, 2, 13, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a quadratic ballot with swapped squares")
+	}
+}
+
+func TestQuadraticBallotCost(t *testing.T) {
+	perOption := RangeProofCost(3).Add(SquareCost)
+	expected := perOption.Times(4).Add(VerificationCost{ECAdd: 6}).Add(RangeProofCost(10))
+	if got := QuadraticBallotCost(4, 10); got != expected {
+		t.Fatalf("expected: %+v, got: %+v", expected, got)
+	}
+}
@@ -0,0 +1,192 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+var (
+	// ErrAlreadyCast is returned by BallotRegistry.Cast when the voter
+	// already cast a ballot, which can only be replaced with Recast.
+	ErrAlreadyCast = errors.New("voter already cast a ballot")
+	// ErrNoPreviousBallot is returned by BallotRegistry.Recast when the
+	// voter has no ballot to replace.
+	ErrNoPreviousBallot = errors.New("voter has no ballot to replace")
+)
+
+// RecastBallot replaces the previous ballot of a voter: it carries a new
+// encrypted vote with its proof of well-formedness, and a cancellation, a
+// re-encryption of the previous ballot with its proof. Subtracting the
+// cancellation from the tally and adding the new vote leaves only the last
+// ballot of the voter in the tally.
+//
+// Since the cancellation is a fresh encryption, neither the new vote nor
+// the cancelled one is revealed. The fact that the voter revoted is public,
+// though: a recast ballot is tied to its voter.
+type RecastBallot struct {
+	EncryptedVote     EncryptedVote           `json:"encryptedVote"`
+	Proof             ProofVoteWellFormedness `json:"proof"`
+	Cancellation      EncryptedVote           `json:"cancellation"`
+	CancellationProof ProofReEncryption       `json:"cancellationProof"`
+}
+
+// NewRecastBallot encrypts vote, which should be 0 or 1, to replace the
+// ballot previous, and proves both the new vote and the cancellation. The
+// randomness of the encryptions is hedged with voterSecret (see
+// NewVoterSecret).
+func NewRecastBallot(
+	reader io.Reader,
+	vote int64,
+	previous *EncryptedVote,
+	voterSecret *arith.SecretScalar,
+	pk *arith.CurvePoint) (*RecastBallot, error) {
+	nonces, err := newVoterReader(reader, voterSecret, pk)
+	if err != nil {
+		return nil, err
+	}
+	defer nonces.destroy()
+	encryptedVote, proof, err := EncryptVoteWithProof(nonces, vote, voterSecret, pk)
+	if err != nil {
+		return nil, err
+	}
+	cancellation, s, err := previous.ReEncrypt(nonces, voterSecret, pk)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Destroy()
+	cancellationProof, err := ProveReEncryption(nonces, previous, cancellation, s, pk)
+	if err != nil {
+		return nil, err
+	}
+	ballot := new(RecastBallot)
+	ballot.EncryptedVote.Set(encryptedVote)
+	ballot.Proof.Set(proof)
+	ballot.Cancellation.Set(cancellation)
+	ballot.CancellationProof.Set(cancellationProof)
+	return ballot, nil
+}
+
+// Verify verifies that the new vote of b is well-formed and that its
+// cancellation is a re-encryption of previous.
+func (b *RecastBallot) Verify(previous *EncryptedVote, pk *arith.CurvePoint) error {
+	if err := VerifyVoteWellFormedness(&b.Proof, &b.EncryptedVote, pk); err != nil {
+		return err
+	}
+	if err := VerifyReEncryption(&b.CancellationProof, previous, &b.Cancellation, pk); err != nil {
+		return fmt.Errorf("invalid cancellation: %w", err)
+	}
+	return nil
+}
+
+// BallotRegistry keeps the last ballot of each voter, and a weighted
+// encrypted tally in which only the last ballot of each voter counts. It
+// is safe for concurrent use.
+type BallotRegistry struct {
+	mu      sync.RWMutex
+	pk      arith.CurvePoint
+	ballots map[string]*registeredBallot
+	tally   EncryptedVote
+}
+
+type registeredBallot struct {
+	vote   EncryptedVote
+	weight uint64
+}
+
+// NewBallotRegistry returns an empty registry of ballots encrypted with
+// public key pk.
+func NewBallotRegistry(pk *arith.CurvePoint) *BallotRegistry {
+	r := &BallotRegistry{ballots: make(map[string]*registeredBallot)}
+	r.pk.Set(pk)
+	r.tally.Set(NewEncryptedVote())
+	return r
+}
+
+// Cast verifies and records the first ballot of voter, with the given
+// weight, which also applies to the later ballots of voter. It returns the
+// change of the tally, i.e. the vote scaled by weight.
+func (r *BallotRegistry) Cast(
+	voter string,
+	weight uint64,
+	vote *EncryptedVote,
+	proof *ProofVoteWellFormedness) (*EncryptedVote, error) {
+	// the public key never changes, so the proof can be verified without
+	// holding the lock
+	if err := VerifyVoteWellFormedness(proof, vote, &r.pk); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.ballots[voter]; ok {
+		return nil, ErrAlreadyCast
+	}
+	b := &registeredBallot{weight: weight}
+	b.vote.Set(vote)
+	r.ballots[voter] = b
+	delta := new(EncryptedVote).Scale(vote, new(arith.Scalar).SetUint64(weight))
+	r.tally.Add(&r.tally, delta)
+	return delta, nil
+}
+
+// Recast verifies and records a ballot of voter which replaces the last
+// one. It returns the change of the tally, i.e. the difference of the new
+// vote and the cancellation, scaled by the weight of voter.
+func (r *BallotRegistry) Recast(voter string, ballot *RecastBallot) (*EncryptedVote, error) {
+	if ballot == nil {
+		return nil, errors.New("missing ballot")
+	}
+	r.mu.RLock()
+	b, ok := r.ballots[voter]
+	var previous EncryptedVote
+	if ok {
+		previous.Set(&b.vote)
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return nil, ErrNoPreviousBallot
+	}
+	if err := ballot.Verify(&previous, &r.pk); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// another ballot of the same voter may have been recorded meanwhile
+	if !b.vote.A.Equal(&previous.A) || !b.vote.B.Equal(&previous.B) {
+		return nil, errors.New("previous ballot changed concurrently")
+	}
+	b.vote.Set(&ballot.EncryptedVote)
+	delta := new(EncryptedVote).Sub(&ballot.EncryptedVote, &ballot.Cancellation)
+	delta.Scale(delta, new(arith.Scalar).SetUint64(b.weight))
+	r.tally.Add(&r.tally, delta)
+	return delta, nil
+}
+
+// LastBallot returns the last ballot cast by voter.
+func (r *BallotRegistry) LastBallot(voter string) (*EncryptedVote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	b, ok := r.ballots[voter]
+	if !ok {
+		return nil, ErrNoPreviousBallot
+	}
+	return new(EncryptedVote).Set(&b.vote), nil
+}
+
+// NumVoters returns the number of voters who cast at least one ballot.
+func (r *BallotRegistry) NumVoters() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.ballots)
+}
+
+// Tally returns the encrypted tally of the last ballots of the voters.
+func (r *BallotRegistry) Tally() *EncryptedVote {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return new(EncryptedVote).Set(&r.tally)
+}
@@ -0,0 +1,141 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestBallotRegistryRevote(t *testing.T) {
+	type cast struct {
+		voter  string
+		weight uint64
+		votes  []Vote
+	}
+	tests := map[string]struct {
+		casts    []cast
+		expected Vote
+	}{
+		"no revote": {
+			casts:    []cast{{"alice", 3, []Vote{Yes}}, {"bob", 2, []Vote{No}}},
+			expected: 3,
+		},
+		"change of mind": {
+			casts:    []cast{{"alice", 3, []Vote{Yes, No}}, {"bob", 2, []Vote{No, Yes}}},
+			expected: 2,
+		},
+		"same vote again": {
+			casts:    []cast{{"alice", 3, []Vote{Yes, Yes, Yes}}},
+			expected: 3,
+		},
+		"last vote counts": {
+			casts:    []cast{{"alice", 5, []Vote{No, Yes, No, Yes}}, {"bob", 1, []Vote{Yes, No}}},
+			expected: 5,
+		},
+	}
+	keyPair := generateKeyPair(t, rand.Reader)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := NewBallotRegistry(&keyPair.Pk)
+			expected := NewEncryptedVote()
+			for _, c := range tc.casts {
+				encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(c.votes[0]), testVoterSecret, &keyPair.Pk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				delta, err := r.Cast(c.voter, c.weight, encryptedVote, proof)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected.Add(expected, delta)
+				for _, vote := range c.votes[1:] {
+					previous, err := r.LastBallot(c.voter)
+					if err != nil {
+						t.Fatal(err)
+					}
+					ballot, err := NewRecastBallot(rand.Reader, int64(vote), previous, testVoterSecret, &keyPair.Pk)
+					if err != nil {
+						t.Fatal(err)
+					}
+					delta, err := r.Recast(c.voter, ballot)
+					if err != nil {
+						t.Fatal(err)
+					}
+					expected.Add(expected, delta)
+				}
+			}
+			if r.NumVoters() != len(tc.casts) {
+				t.Fatalf("expected %d voters, got %d", len(tc.casts), r.NumVoters())
+			}
+			tally := r.Tally()
+			if !expected.A.Equal(&tally.A) || !expected.B.Equal(&tally.B) {
+				t.Fatal("tally is different from the sum of the changes")
+			}
+			got, err := tally.Decrypt(&keyPair.Sk, 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected: %d, got: %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestBallotRegistryRules(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	r := NewBallotRegistry(&keyPair.Pk)
+	encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, 1, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Cast("alice", 1, encryptedVote, proof); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Cast("alice", 1, encryptedVote, proof); !errors.Is(err, ErrAlreadyCast) {
+		t.Fatalf("expected error %v, got %v", ErrAlreadyCast, err)
+	}
+	if _, err := r.Cast("bob", 1, encryptedVote, &ProofVoteWellFormedness{}); err == nil {
+		t.Fatal("successfully cast a vote with an invalid proof")
+	}
+
+	ballot, err := NewRecastBallot(rand.Reader, 0, encryptedVote, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Recast("bob", ballot); !errors.Is(err, ErrNoPreviousBallot) {
+		t.Fatalf("expected error %v, got %v", ErrNoPreviousBallot, err)
+	}
+	if _, err := r.Recast("alice", nil); err == nil {
+		t.Fatal("successfully recast a missing ballot")
+	}
+
+	// a cancellation of another ballot than the last one
+	other, _, err := EncryptVoteWithProof(rand.Reader, 1, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong, err := NewRecastBallot(rand.Reader, 0, other, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Recast("alice", wrong); err == nil {
+		t.Fatal("successfully recast a ballot cancelling another ballot")
+	}
+
+	if _, err := r.Recast("alice", ballot); err != nil {
+		t.Fatal(err)
+	}
+	// the same ballot cannot be replayed, since it cancels a replaced ballot
+	if _, err := r.Recast("alice", ballot); err == nil {
+		t.Fatal("successfully replayed a recast ballot")
+	}
+	last, err := r.LastBallot("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !last.A.Equal(&ballot.EncryptedVote.A) || !last.B.Equal(&ballot.EncryptedVote.B) {
+		t.Fatal("last ballot is not the recast one")
+	}
+}
@@ -0,0 +1,72 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// ScoreBallot is a vote which gives each option of a list a score in
+// [0, S]. Scores[i] encrypts the score of option i, and Proofs[i] proves
+// it in range.
+type ScoreBallot struct {
+	Scores []EncryptedVote `json:"scores"`
+	Proofs []ProofRange    `json:"proofs"`
+}
+
+// EncryptScoreBallot encrypts a score ballot which gives scores[i], at
+// most maxScore, to option i. The randomness of the encryptions is hedged
+// with voterSecret (see NewVoterSecret).
+func EncryptScoreBallot(
+	reader io.Reader,
+	scores []uint64,
+	maxScore uint64,
+	voterSecret *arith.SecretScalar,
+	pk *arith.CurvePoint) (*ScoreBallot, error) {
+	if len(scores) == 0 {
+		return nil, errors.New("score ballot should have at least one option")
+	}
+	nonces, err := newVoterReader(reader, voterSecret, pk)
+	if err != nil {
+		return nil, err
+	}
+	defer nonces.destroy()
+	ballot := &ScoreBallot{
+		Scores: make([]EncryptedVote, len(scores)),
+		Proofs: make([]ProofRange, len(scores)),
+	}
+	for i, score := range scores {
+		encryptedScore, proof, err := encryptValueWithRangeProof(nonces, score, maxScore, pk)
+		if err != nil {
+			return nil, fmt.Errorf("option %d: %w", i, err)
+		}
+		ballot.Scores[i].Set(encryptedScore)
+		ballot.Proofs[i] = *proof
+	}
+	return ballot, nil
+}
+
+// VerifyScoreBallot verifies a score ballot on numOptions options, with
+// scores at most maxScore.
+func VerifyScoreBallot(ballot *ScoreBallot, numOptions int, maxScore uint64, pk *arith.CurvePoint) error {
+	if ballot == nil {
+		return errors.New("missing score ballot")
+	}
+	if len(ballot.Scores) != numOptions || len(ballot.Proofs) != numOptions {
+		return fmt.Errorf("score ballot should have %d scores and proofs", numOptions)
+	}
+	for i := range ballot.Scores {
+		if err := VerifyRange(&ballot.Proofs[i], &ballot.Scores[i], maxScore, pk); err != nil {
+			return fmt.Errorf("option %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ScoreBallotCost returns the cost of the on-chain verification of a score
+// ballot (see VerificationCost).
+func ScoreBallotCost(numOptions int, maxScore uint64) VerificationCost {
+	return RangeProofCost(maxScore).Times(numOptions)
+}
@@ -0,0 +1,74 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestScoreVoting(t *testing.T) {
+	const maxScore = 5
+	tests := map[string][][]uint64{
+		"single voter":  {{0, 3, 5}},
+		"several votes": {{5, 0, 1, 2}, {4, 4, 4, 4}, {0, 0, 5, 1}},
+		"one option":    {{1}, {2}, {5}},
+	}
+	keyPair := generateKeyPair(t, rand.Reader)
+
+	for name, ballots := range tests {
+		t.Run(name, func(t *testing.T) {
+			numOptions := len(ballots[0])
+			tally := NewOptionTally(numOptions)
+			expected := make([]int64, numOptions)
+			for _, scores := range ballots {
+				ballot, err := EncryptScoreBallot(rand.Reader, scores, maxScore, testVoterSecret, &keyPair.Pk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := VerifyScoreBallot(ballot, numOptions, maxScore, &keyPair.Pk); err != nil {
+					t.Fatal(err)
+				}
+				if err := tally.Add(ballot.Scores, 1); err != nil {
+					t.Fatal(err)
+				}
+				for j, score := range scores {
+					expected[j] += int64(score)
+				}
+			}
+
+			n := int64(maxScore * len(ballots))
+			results, proofs, err := DecryptOptionTallyWithProof(rand.Reader, tally, n, keyPair)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for j := range expected {
+				if results[j] != expected[j] {
+					t.Fatalf("expected: %v, got: %v", expected, results)
+				}
+			}
+			if err := VerifyOptionTally(tally, results, proofs, &keyPair.Pk); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestScoreBallotInvalid(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	if _, err := EncryptScoreBallot(rand.Reader, []uint64{1, 6}, 5, testVoterSecret, &keyPair.Pk); err == nil {
+		t.Fatal("encrypted a score over the maximum")
+	}
+	ballot, err := EncryptScoreBallot(rand.Reader, []uint64{1, 5}, 5, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyScoreBallot(ballot, 2, 4, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a score ballot with a lower maximum")
+	}
+	if err := VerifyScoreBallot(ballot, 3, 5, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a score ballot with a missing option")
+	}
+	ballot.Scores[0], ballot.Scores[1] = ballot.Scores[1], ballot.Scores[0]
+	if err := VerifyScoreBallot(ballot, 2, 5, &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a score ballot with swapped scores")
+	}
+}
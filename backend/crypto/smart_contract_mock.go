@@ -2,15 +2,36 @@ package crypto
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/HorizenLabs/e-voting-poc/backend/arith"
 )
 
+// SmartContractMock mimics the Voting smart contract. It is safe for
+// concurrent use, and emits the same events as the smart contract (see
+// Subscribe).
 type SmartContractMock struct {
+	mu             sync.RWMutex
 	pk             *arith.CurvePoint
+	pkProof        *ProofSkKnowledge
+	ballots        []mockBallot
+	voters         *BallotRegistry
 	encryptedTally *EncryptedVote
 	result         uint64
+	resultProof    *ProofCorrectDecryption
 	status         Status
+	subscribers    map[*subscription]struct{}
+}
+
+// mockBallot is an entry of the ballot log of SmartContractMock. Voter is
+// empty for anonymous votes cast with CastVote. Cancellation and its proof
+// are only set for votes recast with Revote.
+type mockBallot struct {
+	Voter             string                  `json:"voter,omitempty"`
+	EncryptedVote     EncryptedVote           `json:"encryptedVote"`
+	Proof             ProofVoteWellFormedness `json:"proof"`
+	Cancellation      *EncryptedVote          `json:"cancellation,omitempty"`
+	CancellationProof *ProofReEncryption      `json:"cancellationProof,omitempty"`
 }
 
 type Status int
@@ -27,68 +48,175 @@ func NewSmartContractMock() *SmartContractMock {
 	return &SmartContractMock{
 		encryptedTally: NewEncryptedVote(),
 		status:         Init,
+		subscribers:    make(map[*subscription]struct{}),
 	}
 }
 
 func (sc *SmartContractMock) DeclarePk(pk *arith.CurvePoint, proof *ProofSkKnowledge) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
 	if sc.status != Init {
 		return fmt.Errorf("wrong status")
 	}
 	err := VerifySkKnowledge(proof, pk)
 	if err == nil {
-		sc.pk = pk
+		sc.pk = new(arith.CurvePoint).Set(pk)
+		sc.pkProof = new(ProofSkKnowledge).Set(proof)
+		sc.voters = NewBallotRegistry(pk)
 		sc.status = Declared
 	}
 	return err
 }
 
 func (sc *SmartContractMock) StartVotingPhase() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
 	if sc.status != Declared {
 		return fmt.Errorf("wrong status")
 	}
 	sc.status = Voting
+	sc.emit(&VotingStartedEvent{Pk: *new(arith.CurvePoint).Set(sc.pk)})
 	return nil
 }
 
 func (sc *SmartContractMock) CastVote(proof *ProofVoteWellFormedness, vote *EncryptedVote) error {
+	// Verification is the expensive part, and does not depend on the state
+	// of the contract apart from pk, which cannot change during voting.
+	sc.mu.RLock()
 	if sc.status != Voting {
+		sc.mu.RUnlock()
 		return fmt.Errorf("wrong status")
 	}
-	err := VerifyVoteWellFormedness(proof, vote, sc.pk)
-	if err == nil {
-		sc.encryptedTally.Add(sc.encryptedTally, vote)
+	pk := sc.pk
+	sc.mu.RUnlock()
+	if err := VerifyVoteWellFormedness(proof, vote, pk); err != nil {
+		return err
 	}
-	return err
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Voting {
+		return fmt.Errorf("wrong status")
+	}
+	sc.encryptedTally.Add(sc.encryptedTally, vote)
+	sc.ballots = append(sc.ballots, mockBallot{})
+	sc.ballots[len(sc.ballots)-1].EncryptedVote.Set(vote)
+	sc.ballots[len(sc.ballots)-1].Proof.Set(proof)
+	event := new(EncryptedVoteCastEvent)
+	event.EncryptedVote.Set(vote)
+	event.Proof.Set(proof)
+	sc.emit(event)
+	return nil
+}
+
+// CastVoteAs casts the first vote of voter. Unlike votes cast with
+// CastVote, it can later be replaced with Revote.
+func (sc *SmartContractMock) CastVoteAs(voter string, proof *ProofVoteWellFormedness, vote *EncryptedVote) error {
+	if voter == "" {
+		return fmt.Errorf("missing voter")
+	}
+	// The registry verifies the proof, so the lock is held throughout, in
+	// order not to record a vote after the voting phase stopped.
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Voting {
+		return fmt.Errorf("wrong status")
+	}
+	delta, err := sc.voters.Cast(voter, 1, vote, proof)
+	if err != nil {
+		return err
+	}
+	sc.encryptedTally.Add(sc.encryptedTally, delta)
+	sc.ballots = append(sc.ballots, mockBallot{Voter: voter})
+	sc.ballots[len(sc.ballots)-1].EncryptedVote.Set(vote)
+	sc.ballots[len(sc.ballots)-1].Proof.Set(proof)
+	event := &EncryptedVoteCastEvent{Voter: voter}
+	event.EncryptedVote.Set(vote)
+	event.Proof.Set(proof)
+	sc.emit(event)
+	return nil
+}
+
+// Revote replaces the last vote of voter, cast with CastVoteAs or Revote,
+// so that only the new vote is counted in the tally.
+func (sc *SmartContractMock) Revote(voter string, ballot *RecastBallot) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Voting {
+		return fmt.Errorf("wrong status")
+	}
+	delta, err := sc.voters.Recast(voter, ballot)
+	if err != nil {
+		return err
+	}
+	sc.encryptedTally.Add(sc.encryptedTally, delta)
+	b := mockBallot{
+		Voter:             voter,
+		Cancellation:      new(EncryptedVote).Set(&ballot.Cancellation),
+		CancellationProof: new(ProofReEncryption).Set(&ballot.CancellationProof),
+	}
+	b.EncryptedVote.Set(&ballot.EncryptedVote)
+	b.Proof.Set(&ballot.Proof)
+	sc.ballots = append(sc.ballots, b)
+	event := &VoteRecastEvent{Voter: voter}
+	event.Ballot.EncryptedVote.Set(&ballot.EncryptedVote)
+	event.Ballot.Proof.Set(&ballot.Proof)
+	event.Ballot.Cancellation.Set(&ballot.Cancellation)
+	event.Ballot.CancellationProof.Set(&ballot.CancellationProof)
+	sc.emit(event)
+	return nil
+}
+
+// GetLastBallot returns the last vote cast by voter with CastVoteAs or
+// Revote, which the next Revote of voter must cancel.
+func (sc *SmartContractMock) GetLastBallot(voter string) (*EncryptedVote, error) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	if sc.voters == nil {
+		return nil, ErrNoPreviousBallot
+	}
+	return sc.voters.LastBallot(voter)
 }
 
 func (sc *SmartContractMock) StopVotingPhase() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
 	if sc.status != Voting {
 		return fmt.Errorf("wrong status")
 	}
 	sc.status = Tallying
+	sc.emit(&VotingStoppedEvent{Tally: *new(EncryptedVote).Set(sc.encryptedTally)})
 	return nil
 }
 
 func (sc *SmartContractMock) Tally(proof *ProofCorrectDecryption, decryptedTally uint64) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
 	if sc.status != Tallying {
 		return fmt.Errorf("wrong status")
 	}
 	err := VerifyCorrectDecryption(proof, sc.encryptedTally, Vote(decryptedTally), sc.pk)
 	if err == nil {
 		sc.result = decryptedTally
+		sc.resultProof = new(ProofCorrectDecryption).Set(proof)
 		sc.status = Fini
+		sc.emit(&ResultEvent{Result: decryptedTally})
 	}
 	return err
 }
 
 func (sc *SmartContractMock) GetPk() (*arith.CurvePoint, error) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
 	if sc.status == Init {
 		return nil, fmt.Errorf("wrong status")
 	}
-	return sc.pk, nil
+	return new(arith.CurvePoint).Set(sc.pk), nil
 }
 
 func (sc *SmartContractMock) GetResult() (uint64, error) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
 	if sc.status != Fini {
 		return 0, fmt.Errorf("wrong status")
 	}
@@ -96,5 +224,34 @@ func (sc *SmartContractMock) GetResult() (uint64, error) {
 }
 
 func (sc *SmartContractMock) GetEncryptedTally() (*EncryptedVote, error) {
-	return sc.encryptedTally, nil
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return new(EncryptedVote).Set(sc.encryptedTally), nil
+}
+
+// GetNumBallots returns the number of votes cast so far, including the
+// recast ones.
+func (sc *SmartContractMock) GetNumBallots() int {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return len(sc.ballots)
+}
+
+// GetBallot returns the i-th vote cast, together with its proof of
+// well-formedness.
+func (sc *SmartContractMock) GetBallot(i int) (*EncryptedVote, *ProofVoteWellFormedness, error) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	if i < 0 || i >= len(sc.ballots) {
+		return nil, nil, fmt.Errorf("ballot index %d out of range", i)
+	}
+	b := &sc.ballots[i]
+	return new(EncryptedVote).Set(&b.EncryptedVote), new(ProofVoteWellFormedness).Set(&b.Proof), nil
+}
+
+// GetStatus returns the current status of the contract.
+func (sc *SmartContractMock) GetStatus() Status {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.status
 }
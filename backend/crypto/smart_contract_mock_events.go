@@ -0,0 +1,146 @@
+package crypto
+
+import (
+	"sync"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// Event is an event emitted by SmartContractMock. It is one of
+// *VotingStartedEvent, *EncryptedVoteCastEvent, *VoteRecastEvent,
+// *VotingStoppedEvent and *ResultEvent, which mirror the events of the
+// Voting smart contract.
+type Event interface {
+	event()
+}
+
+// VotingStartedEvent is emitted when the voting phase starts.
+type VotingStartedEvent struct {
+	Pk arith.CurvePoint `json:"pk"`
+}
+
+// EncryptedVoteCastEvent is emitted when a vote is cast. The smart contract
+// event only carries the address of the voter, which the mock only models
+// for votes cast with CastVoteAs; the encrypted vote and its proof, which
+// an indexer would decode from the transaction, are included as well.
+type EncryptedVoteCastEvent struct {
+	Voter         string                  `json:"voter,omitempty"`
+	EncryptedVote EncryptedVote           `json:"encryptedVote"`
+	Proof         ProofVoteWellFormedness `json:"proof"`
+}
+
+// VoteRecastEvent is emitted when a voter replaces its last vote.
+type VoteRecastEvent struct {
+	Voter  string       `json:"voter"`
+	Ballot RecastBallot `json:"ballot"`
+}
+
+// VotingStoppedEvent is emitted when the voting phase stops, and carries
+// the final encrypted tally.
+type VotingStoppedEvent struct {
+	Tally EncryptedVote `json:"tally"`
+}
+
+// ResultEvent is emitted when the decrypted tally is posted.
+type ResultEvent struct {
+	Result uint64 `json:"result"`
+}
+
+func (*VotingStartedEvent) event()     {}
+func (*EncryptedVoteCastEvent) event() {}
+func (*VoteRecastEvent) event()        {}
+func (*VotingStoppedEvent) event()     {}
+func (*ResultEvent) event()            {}
+
+// Subscribe returns a channel which receives all the events emitted by sc
+// from now on, in the order in which they are emitted, and a function to
+// cancel the subscription. Events are buffered without bound, so that slow
+// subscribers never block the contract. The channel is closed after the
+// subscription is canceled.
+func (sc *SmartContractMock) Subscribe() (<-chan Event, func()) {
+	s := newSubscription()
+	sc.mu.Lock()
+	sc.subscribers[s] = struct{}{}
+	sc.mu.Unlock()
+	go s.pump()
+
+	cancel := func() {
+		sc.mu.Lock()
+		delete(sc.subscribers, s)
+		sc.mu.Unlock()
+		s.close()
+	}
+	return s.out, cancel
+}
+
+// emit delivers event to all subscribers. sc.mu must be held.
+func (sc *SmartContractMock) emit(event Event) {
+	for s := range sc.subscribers {
+		s.push(event)
+	}
+}
+
+// subscription is an unbounded queue of events, which are moved to out by
+// pump.
+type subscription struct {
+	mu     sync.Mutex
+	queue  []Event
+	notify chan struct{}
+	out    chan Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newSubscription() *subscription {
+	return &subscription{
+		notify: make(chan struct{}, 1),
+		out:    make(chan Event),
+		done:   make(chan struct{}),
+	}
+}
+
+func (s *subscription) push(event Event) {
+	s.mu.Lock()
+	s.queue = append(s.queue, event)
+	s.mu.Unlock()
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscription) pop() (Event, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queue) == 0 {
+		return nil, false
+	}
+	event := s.queue[0]
+	s.queue[0] = nil
+	s.queue = s.queue[1:]
+	return event, true
+}
+
+func (s *subscription) pump() {
+	defer close(s.out)
+	for {
+		event, ok := s.pop()
+		if !ok {
+			select {
+			case <-s.notify:
+				continue
+			case <-s.done:
+				return
+			}
+		}
+		select {
+		case s.out <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *subscription) close() {
+	s.once.Do(func() { close(s.done) })
+}
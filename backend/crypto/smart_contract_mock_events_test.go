@@ -0,0 +1,92 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+)
+
+func TestSmartContractMockEvents(t *testing.T) {
+	sc := NewSmartContractMock()
+	events, cancel := sc.Subscribe()
+	defer cancel()
+	// a subscriber which is never read from must not block the contract
+	_, cancelIdle := sc.Subscribe()
+	defer cancelIdle()
+
+	keyPair, proofSk, err := NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.DeclarePk(&keyPair.Pk, proofSk); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.StartVotingPhase(); err != nil {
+		t.Fatal(err)
+	}
+	votes := []Vote{Yes, No, Yes}
+	for _, vote := range votes {
+		encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(vote), testVoterSecret, &keyPair.Pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sc.CastVote(proof, encryptedVote); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sc.StopVotingPhase(); err != nil {
+		t.Fatal(err)
+	}
+	tally, err := sc.GetEncryptedTally()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, proof, err := DecryptTallyWithProof(rand.Reader, tally, int64(len(votes)), keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.Tally(proof, uint64(result)); err != nil {
+		t.Fatal(err)
+	}
+
+	// an indexer can rebuild the tally, and check the result, from events only
+	started, ok := receive(t, events).(*VotingStartedEvent)
+	if !ok || !started.Pk.Equal(&keyPair.Pk) {
+		t.Fatal("expected VotingStarted event with the election pk")
+	}
+	indexedTally := NewEncryptedVote()
+	for range votes {
+		cast, ok := receive(t, events).(*EncryptedVoteCastEvent)
+		if !ok {
+			t.Fatal("expected EncryptedVoteCast event")
+		}
+		if err := VerifyVoteWellFormedness(&cast.Proof, &cast.EncryptedVote, &started.Pk); err != nil {
+			t.Fatal(err)
+		}
+		indexedTally.Add(indexedTally, &cast.EncryptedVote)
+	}
+	stopped, ok := receive(t, events).(*VotingStoppedEvent)
+	if !ok || !stopped.Tally.A.Equal(&indexedTally.A) || !stopped.Tally.B.Equal(&indexedTally.B) {
+		t.Fatal("expected VotingStopped event with the tally of the cast votes")
+	}
+	res, ok := receive(t, events).(*ResultEvent)
+	if !ok || res.Result != uint64(result) {
+		t.Fatal("expected Result event with the decrypted tally")
+	}
+
+	cancel()
+	for range events {
+		// drain until the channel is closed
+	}
+}
+
+func receive(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for event")
+		return nil
+	}
+}
@@ -0,0 +1,201 @@
+package crypto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// SnapshotVersion is the version of the snapshot format written by
+// SmartContractMock.WriteSnapshot.
+const SnapshotVersion = 1
+
+var statusNames = []string{"init", "declared", "voting", "tallying", "fini"}
+
+func (s Status) String() string {
+	if s < 0 || int(s) >= len(statusNames) {
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+	return statusNames[s]
+}
+
+func (s Status) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(statusNames) {
+		return nil, fmt.Errorf("invalid status %d", int(s))
+	}
+	return []byte(statusNames[s]), nil
+}
+
+func (s *Status) UnmarshalText(text []byte) error {
+	for i, name := range statusNames {
+		if string(text) == name {
+			*s = Status(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid status %q", text)
+}
+
+// mockSnapshot is the JSON representation of the state of a
+// SmartContractMock. Ballots is nil for snapshots which carry no ballot
+// log, in which case the encrypted tally cannot be recomputed.
+type mockSnapshot struct {
+	Version        int                     `json:"version"`
+	Status         Status                  `json:"status"`
+	Pk             *arith.CurvePoint       `json:"pk,omitempty"`
+	PkProof        *ProofSkKnowledge       `json:"pkProof,omitempty"`
+	Ballots        *[]mockBallot           `json:"ballots,omitempty"`
+	EncryptedTally *EncryptedVote          `json:"encryptedTally"`
+	Result         uint64                  `json:"result"`
+	ResultProof    *ProofCorrectDecryption `json:"resultProof,omitempty"`
+}
+
+// WriteSnapshot writes the state of sc to w as versioned JSON, so that it
+// can be restored with RestoreSmartContractMock. Subscriptions are not part
+// of the snapshot.
+func (sc *SmartContractMock) WriteSnapshot(w io.Writer) error {
+	sc.mu.RLock()
+	snapshot := &mockSnapshot{
+		Version: SnapshotVersion,
+		Status:  sc.status,
+		Result:  sc.result,
+	}
+	if sc.pk != nil {
+		snapshot.Pk = new(arith.CurvePoint).Set(sc.pk)
+		snapshot.PkProof = new(ProofSkKnowledge).Set(sc.pkProof)
+	}
+	ballots := make([]mockBallot, len(sc.ballots))
+	for i := range sc.ballots {
+		b := &sc.ballots[i]
+		ballots[i].Voter = b.Voter
+		ballots[i].EncryptedVote.Set(&b.EncryptedVote)
+		ballots[i].Proof.Set(&b.Proof)
+		if b.Cancellation != nil {
+			ballots[i].Cancellation = new(EncryptedVote).Set(b.Cancellation)
+			ballots[i].CancellationProof = new(ProofReEncryption).Set(b.CancellationProof)
+		}
+	}
+	snapshot.Ballots = &ballots
+	snapshot.EncryptedTally = new(EncryptedVote).Set(sc.encryptedTally)
+	if sc.resultProof != nil {
+		snapshot.ResultProof = new(ProofCorrectDecryption).Set(sc.resultProof)
+	}
+	sc.mu.RUnlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snapshot)
+}
+
+// RestoreSmartContractMock reads a snapshot written by
+// SmartContractMock.WriteSnapshot and returns the corresponding mock. All
+// the proofs in the snapshot are verified, and, if the snapshot carries a
+// ballot log, the encrypted tally is recomputed from it.
+func RestoreSmartContractMock(r io.Reader) (*SmartContractMock, error) {
+	snapshot := new(mockSnapshot)
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", snapshot.Version)
+	}
+	if snapshot.EncryptedTally == nil {
+		return nil, errors.New("missing encrypted tally")
+	}
+
+	sc := NewSmartContractMock()
+	sc.status = snapshot.Status
+	if snapshot.Status == Init {
+		if snapshot.Pk != nil || snapshot.PkProof != nil {
+			return nil, errors.New("pk declared in status init")
+		}
+	} else {
+		if snapshot.Pk == nil || snapshot.PkProof == nil {
+			return nil, errors.New("missing pk or its proof")
+		}
+		if err := VerifySkKnowledge(snapshot.PkProof, snapshot.Pk); err != nil {
+			return nil, fmt.Errorf("invalid pk: %w", err)
+		}
+		sc.pk = snapshot.Pk
+		sc.pkProof = snapshot.PkProof
+		sc.voters = NewBallotRegistry(sc.pk)
+	}
+
+	if snapshot.Ballots != nil {
+		if snapshot.Status < Voting && len(*snapshot.Ballots) > 0 {
+			return nil, fmt.Errorf("ballots cast in status %s", snapshot.Status)
+		}
+		for i := range *snapshot.Ballots {
+			if err := sc.replay(&(*snapshot.Ballots)[i]); err != nil {
+				return nil, fmt.Errorf("invalid ballot %d: %w", i, err)
+			}
+		}
+		if !sc.encryptedTally.A.Equal(&snapshot.EncryptedTally.A) || !sc.encryptedTally.B.Equal(&snapshot.EncryptedTally.B) {
+			return nil, errors.New("encrypted tally does not match the ballot log")
+		}
+		sc.ballots = *snapshot.Ballots
+	} else {
+		if snapshot.Status < Voting {
+			identity := NewEncryptedVote()
+			if !identity.A.Equal(&snapshot.EncryptedTally.A) || !identity.B.Equal(&snapshot.EncryptedTally.B) {
+				return nil, fmt.Errorf("non-empty encrypted tally in status %s", snapshot.Status)
+			}
+		}
+		sc.encryptedTally.Set(snapshot.EncryptedTally)
+	}
+
+	if snapshot.Status == Fini {
+		if snapshot.ResultProof == nil {
+			return nil, errors.New("missing proof of the result")
+		}
+		err := VerifyCorrectDecryption(snapshot.ResultProof, sc.encryptedTally, Vote(snapshot.Result), sc.pk)
+		if err != nil {
+			return nil, fmt.Errorf("invalid result: %w", err)
+		}
+		sc.result = snapshot.Result
+		sc.resultProof = snapshot.ResultProof
+	} else if snapshot.Result != 0 || snapshot.ResultProof != nil {
+		return nil, fmt.Errorf("result posted in status %s", snapshot.Status)
+	}
+	return sc, nil
+}
+
+// replay verifies a ballot of the log and adds it to the encrypted tally,
+// replacing the last ballot of its voter if it was recast.
+func (sc *SmartContractMock) replay(b *mockBallot) error {
+	switch {
+	case b.Cancellation == nil && b.CancellationProof == nil:
+		if b.Voter == "" {
+			if err := VerifyVoteWellFormedness(&b.Proof, &b.EncryptedVote, sc.pk); err != nil {
+				return err
+			}
+			sc.encryptedTally.Add(sc.encryptedTally, &b.EncryptedVote)
+			return nil
+		}
+		delta, err := sc.voters.Cast(b.Voter, 1, &b.EncryptedVote, &b.Proof)
+		if err != nil {
+			return err
+		}
+		sc.encryptedTally.Add(sc.encryptedTally, delta)
+		return nil
+	case b.Cancellation == nil || b.CancellationProof == nil:
+		return errors.New("missing cancellation or its proof")
+	default:
+		ballot := &RecastBallot{}
+		ballot.EncryptedVote.Set(&b.EncryptedVote)
+		ballot.Proof.Set(&b.Proof)
+		ballot.Cancellation.Set(b.Cancellation)
+		ballot.CancellationProof.Set(b.CancellationProof)
+		delta, err := sc.voters.Recast(b.Voter, ballot)
+		if err != nil {
+			return err
+		}
+		sc.encryptedTally.Add(sc.encryptedTally, delta)
+		return nil
+	}
+}
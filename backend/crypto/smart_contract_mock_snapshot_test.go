@@ -0,0 +1,214 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	keyPair, proofSk, err := NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	votes := []Vote{Yes, No, Yes}
+
+	steps := map[Status]func(t *testing.T, sc *SmartContractMock){
+		Init: func(t *testing.T, sc *SmartContractMock) {},
+		Declared: func(t *testing.T, sc *SmartContractMock) {
+			if err := sc.DeclarePk(&keyPair.Pk, proofSk); err != nil {
+				t.Fatal(err)
+			}
+		},
+		Voting: func(t *testing.T, sc *SmartContractMock) {
+			if err := sc.StartVotingPhase(); err != nil {
+				t.Fatal(err)
+			}
+			for _, vote := range votes {
+				encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(vote), testVoterSecret, &keyPair.Pk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := sc.CastVote(proof, encryptedVote); err != nil {
+					t.Fatal(err)
+				}
+			}
+		},
+		Tallying: func(t *testing.T, sc *SmartContractMock) {
+			if err := sc.StopVotingPhase(); err != nil {
+				t.Fatal(err)
+			}
+		},
+		Fini: func(t *testing.T, sc *SmartContractMock) {
+			tally, _ := sc.GetEncryptedTally()
+			result, proof, err := DecryptTallyWithProof(rand.Reader, tally, int64(len(votes)), keyPair)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := sc.Tally(proof, uint64(result)); err != nil {
+				t.Fatal(err)
+			}
+		},
+	}
+
+	sc := NewSmartContractMock()
+	for status := Init; status <= Fini; status++ {
+		t.Run(status.String(), func(t *testing.T) {
+			steps[status](t, sc)
+			restored := snapshotRestore(t, sc)
+			if restored.GetStatus() != status {
+				t.Fatalf("expected status %s, got %s", status, restored.GetStatus())
+			}
+			if restored.GetNumBallots() != sc.GetNumBallots() {
+				t.Fatalf("expected %d ballots, got %d", sc.GetNumBallots(), restored.GetNumBallots())
+			}
+			expected, _ := sc.GetEncryptedTally()
+			got, _ := restored.GetEncryptedTally()
+			if !expected.A.Equal(&got.A) || !expected.B.Equal(&got.B) {
+				t.Fatal("restored encrypted tally is different from the original one")
+			}
+			// the restored mock must be fully functional: carry on from its state
+			sc = restored
+		})
+	}
+	if result, err := sc.GetResult(); err != nil || result != 2 {
+		t.Fatalf("expected result 2, got %d, %v", result, err)
+	}
+}
+
+func TestRestoreInvalidSnapshot(t *testing.T) {
+	keyPair, proofSk, err := NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := NewSmartContractMock()
+	if err := sc.DeclarePk(&keyPair.Pk, proofSk); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.StartVotingPhase(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sc.CastVote(proof, encryptedVote); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a vote cast by alice and recast, which are ballots 2 and 3
+	encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.CastVoteAs("alice", proof, encryptedVote); err != nil {
+		t.Fatal(err)
+	}
+	ballot, err := NewRecastBallot(rand.Reader, int64(No), encryptedVote, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.Revote("alice", ballot); err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if err := sc.WriteSnapshot(buf); err != nil {
+		t.Fatal(err)
+	}
+	valid := buf.Bytes()
+
+	tests := map[string]func(m map[string]interface{}){
+		"unknown version":       func(m map[string]interface{}) { m["version"] = 2 },
+		"unknown status":        func(m map[string]interface{}) { m["status"] = "paused" },
+		"unknown field":         func(m map[string]interface{}) { m["extra"] = 1 },
+		"missing pk":            func(m map[string]interface{}) { delete(m, "pk") },
+		"missing tally":         func(m map[string]interface{}) { delete(m, "encryptedTally") },
+		"wrong pk proof":        func(m map[string]interface{}) { m["pkProof"].(map[string]interface{})["s"] = json.Number("1") },
+		"result in voting":      func(m map[string]interface{}) { m["result"] = 1 },
+		"ballots before voting": func(m map[string]interface{}) { m["status"] = "declared" },
+		"dropped ballot": func(m map[string]interface{}) {
+			m["ballots"] = m["ballots"].([]interface{})[1:]
+		},
+		"duplicated ballot": func(m map[string]interface{}) {
+			ballots := m["ballots"].([]interface{})
+			m["ballots"] = append(ballots, ballots[0])
+		},
+		"tampered ballot": func(m map[string]interface{}) {
+			ballot := m["ballots"].([]interface{})[0].(map[string]interface{})
+			ballot["encryptedVote"] = m["encryptedTally"]
+		},
+		"tampered tally": func(m map[string]interface{}) {
+			tally := m["encryptedTally"].(map[string]interface{})
+			tally["a"], tally["b"] = tally["b"], tally["a"]
+		},
+		"fini without proof": func(m map[string]interface{}) { m["status"] = "fini" },
+		"recast before cast": func(m map[string]interface{}) {
+			ballots := m["ballots"].([]interface{})
+			ballots[2], ballots[3] = ballots[3], ballots[2]
+		},
+		"recast by anonymous voter": func(m map[string]interface{}) {
+			delete(m["ballots"].([]interface{})[3].(map[string]interface{}), "voter")
+		},
+		"missing cancellation proof": func(m map[string]interface{}) {
+			delete(m["ballots"].([]interface{})[3].(map[string]interface{}), "cancellationProof")
+		},
+		"dropped cancellation": func(m map[string]interface{}) {
+			ballot := m["ballots"].([]interface{})[3].(map[string]interface{})
+			delete(ballot, "cancellation")
+			delete(ballot, "cancellationProof")
+		},
+	}
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := decodeSnapshot(t, valid)
+			tamper(m)
+			data, err := json.Marshal(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := RestoreSmartContractMock(bytes.NewReader(data)); err == nil {
+				t.Fatalf("successfully restored invalid snapshot: %s", name)
+			}
+		})
+	}
+
+	// a snapshot without ballot log is accepted as is
+	m := decodeSnapshot(t, valid)
+	delete(m, "ballots")
+	data, _ := json.Marshal(m)
+	restored, err := RestoreSmartContractMock(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.GetNumBallots() != 0 {
+		t.Fatalf("expected no ballots, got %d", restored.GetNumBallots())
+	}
+}
+
+func snapshotRestore(t *testing.T, sc *SmartContractMock) *SmartContractMock {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := sc.WriteSnapshot(buf); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := RestoreSmartContractMock(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return restored
+}
+
+// decodeSnapshot decodes a snapshot into a generic map, preserving the
+// precision of numbers, so that it can be tampered with.
+func decodeSnapshot(t *testing.T, data []byte) map[string]interface{} {
+	t.Helper()
+	m := make(map[string]interface{})
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
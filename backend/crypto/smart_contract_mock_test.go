@@ -0,0 +1,195 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"sync"
+	"testing"
+)
+
+func TestSmartContractMockConcurrentVoting(t *testing.T) {
+	const numVoters = 32
+	sc := NewSmartContractMock()
+	keyPair, proofSk, err := NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.DeclarePk(&keyPair.Pk, proofSk); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.StartVotingPhase(); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, numVoters)
+	numYes := 0
+	for i := 0; i < numVoters; i++ {
+		vote := Vote(i % 3 % 2)
+		numYes += int(vote)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(vote), testVoterSecret, &keyPair.Pk)
+			if err == nil {
+				err = sc.CastVote(proof, encryptedVote)
+			}
+			errs <- err
+		}()
+	}
+	// readers run concurrently with voters
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numVoters; i++ {
+			if _, err := sc.GetEncryptedTally(); err != nil {
+				errs <- err
+			}
+		}
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := sc.StopVotingPhase(); err != nil {
+		t.Fatal(err)
+	}
+	tally, err := sc.GetEncryptedTally()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, proof, err := DecryptTallyWithProof(rand.Reader, tally, numVoters, keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(result) != numYes {
+		t.Fatalf("expected: %d yes, got: %d", numYes, result)
+	}
+	if err := sc.Tally(proof, uint64(result)); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := sc.GetResult(); err != nil || got != uint64(numYes) {
+		t.Fatalf("expected result %d, got %d, %v", numYes, got, err)
+	}
+}
+
+func TestSmartContractMockWrongStatus(t *testing.T) {
+	sc := NewSmartContractMock()
+	keyPair, proofSk, err := NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sc.CastVote(proof, encryptedVote); err == nil {
+		t.Fatal("successfully cast a vote before declaring pk")
+	}
+	if err := sc.DeclarePk(&keyPair.Pk, proofSk); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.CastVote(proof, encryptedVote); err == nil {
+		t.Fatal("successfully cast a vote before starting the voting phase")
+	}
+	if err := sc.StartVotingPhase(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.StopVotingPhase(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.CastVote(proof, encryptedVote); err == nil {
+		t.Fatal("successfully cast a vote after stopping the voting phase")
+	}
+	if sc.GetStatus() != Tallying {
+		t.Fatalf("expected status %d, got %d", Tallying, sc.GetStatus())
+	}
+}
+
+func TestSmartContractMockRevote(t *testing.T) {
+	sc := NewSmartContractMock()
+	keyPair, proofSk, err := NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.DeclarePk(&keyPair.Pk, proofSk); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.StartVotingPhase(); err != nil {
+		t.Fatal(err)
+	}
+	events, cancel := sc.Subscribe()
+	defer cancel()
+
+	// alice and bob vote yes, then alice revotes twice and bob once;
+	// an anonymous voter votes yes and cannot revote
+	for _, voter := range []string{"alice", "bob", ""} {
+		encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if voter == "" {
+			err = sc.CastVote(proof, encryptedVote)
+		} else {
+			err = sc.CastVoteAs(voter, proof, encryptedVote)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, revote := range []struct {
+		voter string
+		vote  Vote
+	}{{"alice", No}, {"bob", No}, {"alice", Yes}} {
+		previous, err := sc.GetLastBallot(revote.voter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ballot, err := NewRecastBallot(rand.Reader, int64(revote.vote), previous, testVoterSecret, &keyPair.Pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sc.Revote(revote.voter, ballot); err != nil {
+			t.Fatal(err)
+		}
+		if err := sc.Revote(revote.voter, ballot); err == nil {
+			t.Fatal("successfully replayed a recast ballot")
+		}
+		if err := sc.Revote("carol", ballot); err == nil {
+			t.Fatal("successfully recast the ballot of another voter")
+		}
+	}
+	if sc.GetNumBallots() != 6 {
+		t.Fatalf("expected 6 ballots, got %d", sc.GetNumBallots())
+	}
+	for i := 0; i < 6; i++ {
+		event := <-events
+		if _, ok := event.(*VoteRecastEvent); ok != (i >= 3) {
+			t.Fatalf("unexpected event %d: %T", i, event)
+		}
+	}
+
+	// the log of ballots replays to the same tally
+	sc = snapshotRestore(t, sc)
+	if err := sc.StopVotingPhase(); err != nil {
+		t.Fatal(err)
+	}
+	tally, err := sc.GetEncryptedTally()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, proof, err := DecryptTallyWithProof(rand.Reader, tally, 3, keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != 2 {
+		t.Fatalf("expected: 2 yes, got: %d", result)
+	}
+	if err := sc.Tally(proof, uint64(result)); err != nil {
+		t.Fatal(err)
+	}
+}
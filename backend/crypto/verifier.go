@@ -0,0 +1,189 @@
+package crypto
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// negGenerator is -g, where g is the generator of bn256.G1. It is only read.
+var negGenerator = new(arith.CurvePoint).ScalarBaseMult(arith.NewScalar(big.NewInt(-1)))
+
+var verifierPool = sync.Pool{
+	New: func() interface{} { return NewVerifier() },
+}
+
+// Verifier verifies proofs reusing its scratch space across calls, which
+// saves most of the allocations of a verification. A Verifier is not safe
+// for concurrent use, but it holds no state between calls, so Verifiers can
+// be pooled across goroutines: VerifySkKnowledge, VerifyCorrectDecryption
+// and VerifyVoteWellFormedness take theirs from a sync.Pool.
+//
+// The inputs are copied before use, so that a Verifier never writes to
+// them, and they can be shared by concurrent verifications.
+type Verifier struct {
+	hasher *arith.ChallengeHasher
+	c      arith.Challenge
+	sum    arith.Challenge
+	s      [2]arith.Scalar
+	p      [10]arith.CurvePoint
+}
+
+// NewVerifier returns a new Verifier.
+func NewVerifier() *Verifier {
+	return &Verifier{hasher: arith.NewChallengeHasher()}
+}
+
+// VerifySkKnowledge is like the function VerifySkKnowledge.
+func (v *Verifier) VerifySkKnowledge(proof *ProofSkKnowledge, pk *arith.CurvePoint) error {
+	// Implementation based on https://eprint.iacr.org/2016/765.pdf, section 4.3
+	if proof == nil {
+		return errors.New("missing sk knowledge proof")
+	}
+	if pk == nil || !pk.IsValid() {
+		return errors.New("invalid public key")
+	}
+	pkCopy, u, t := &v.p[0], &v.p[1], &v.p[2]
+	pkCopy.Set(pk)
+
+	// u = s*g - c*pk
+	negC := v.s[0].SetChallenge(&proof.C)
+	negC.Neg(negC)
+	t.ScalarMult(pkCopy, negC)
+	u.ScalarBaseMult(&proof.S)
+	u.Add(u, t)
+
+	v.hasher.Reset()
+	if err := v.write(pkCopy, u); err != nil {
+		return err
+	}
+	if !v.hasher.Challenge(&v.c).Equal(&proof.C) {
+		return errors.New("sk knowledge proof verification failed")
+	}
+	return nil
+}
+
+// VerifyCorrectDecryption is like the function VerifyCorrectDecryption.
+func (v *Verifier) VerifyCorrectDecryption(
+	proof *ProofCorrectDecryption,
+	encryptedVote *EncryptedVote,
+	decryptedVote Vote,
+	pk *arith.CurvePoint) error {
+	// Implementation based on https://eprint.iacr.org/2016/765.pdf, section 4.4
+	if proof == nil {
+		return errors.New("missing decryption proof")
+	}
+	if err := v.checkEncryptedVote(encryptedVote, pk); err != nil {
+		return err
+	}
+	pkCopy, a, b := &v.p[0], &v.p[1], &v.p[2]
+	d, u, w, t := &v.p[3], &v.p[4], &v.p[5], &v.p[6]
+	pkCopy.Set(pk)
+	a.Set(&encryptedVote.A)
+	b.Set(&encryptedVote.B)
+
+	// d = b - m*g
+	m := v.s[0].SetInt64(-int64(decryptedVote))
+	d.ScalarBaseMult(m)
+	d.Add(d, b)
+
+	negC := v.s[0].SetChallenge(&proof.C)
+	negC.Neg(negC)
+
+	// u = s*a - c*d
+	u.ScalarMult(a, &proof.S)
+	t.ScalarMult(d, negC)
+	u.Add(u, t)
+
+	// w = s*g - c*pk
+	w.ScalarBaseMult(&proof.S)
+	t.ScalarMult(pkCopy, negC)
+	w.Add(w, t)
+
+	v.hasher.Reset()
+	if err := v.write(pkCopy, a, b, u, w); err != nil {
+		return err
+	}
+	if !v.hasher.Challenge(&v.c).Equal(&proof.C) {
+		return errors.New("decryption proof verification failed")
+	}
+	return nil
+}
+
+// VerifyVoteWellFormedness is like the function VerifyVoteWellFormedness.
+func (v *Verifier) VerifyVoteWellFormedness(
+	proof *ProofVoteWellFormedness,
+	vote *EncryptedVote,
+	pk *arith.CurvePoint) error {
+	// Implementation based on https://eprint.iacr.org/2016/765.pdf, section 4.5
+	if proof == nil {
+		return errors.New("missing vote well-formedness proof")
+	}
+	if err := v.checkEncryptedVote(vote, pk); err != nil {
+		return err
+	}
+	pkCopy, a, b := &v.p[0], &v.p[1], &v.p[2]
+	a0, b0, a1, b1, t := &v.p[3], &v.p[4], &v.p[5], &v.p[6], &v.p[7]
+	pkCopy.Set(pk)
+	a.Set(&vote.A)
+	b.Set(&vote.B)
+	negC0 := v.s[0].SetChallenge(&proof.C0)
+	negC0.Neg(negC0)
+	negC1 := v.s[1].SetChallenge(&proof.C1)
+	negC1.Neg(negC1)
+
+	// a0 = r0*g - c0*a
+	a0.ScalarBaseMult(&proof.R0)
+	t.ScalarMult(a, negC0)
+	a0.Add(a0, t)
+
+	// a1 = r1*g - c1*a
+	a1.ScalarBaseMult(&proof.R1)
+	t.ScalarMult(a, negC1)
+	a1.Add(a1, t)
+
+	// b0 = r0*pk - c0*b
+	b0.ScalarMult(pkCopy, &proof.R0)
+	t.ScalarMult(b, negC0)
+	b0.Add(b0, t)
+
+	// b1 = r1*pk - c1*(b - g)
+	b1.ScalarMult(pkCopy, &proof.R1)
+	t.Add(b, negGenerator)
+	t.ScalarMult(t, negC1)
+	b1.Add(b1, t)
+
+	v.hasher.Reset()
+	if err := v.write(pkCopy, a, b, a0, b0, a1, b1); err != nil {
+		return err
+	}
+	v.hasher.Challenge(&v.c)
+	if !v.c.Equal(v.sum.Add(&proof.C0, &proof.C1)) {
+		return errors.New("vote well-formedness proof verification failed")
+	}
+	return nil
+}
+
+func (v *Verifier) checkEncryptedVote(vote *EncryptedVote, pk *arith.CurvePoint) error {
+	if vote == nil || !vote.A.IsValid() || !vote.B.IsValid() {
+		return errors.New("invalid encrypted vote")
+	}
+	if pk == nil || !pk.IsValid() {
+		return errors.New("invalid public key")
+	}
+	return nil
+}
+
+// write feeds points to the challenge hasher.
+func (v *Verifier) write(points ...*arith.CurvePoint) error {
+	for _, p := range points {
+		data, err := p.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		v.hasher.Write(data)
+	}
+	return nil
+}
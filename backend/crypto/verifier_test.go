@@ -0,0 +1,148 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"sync"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+func TestVerifierReuse(t *testing.T) {
+	keyPair, proofSk, err := NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := NewVerifier()
+	tally := NewEncryptedVote()
+	var numYes int64
+	for i, vote := range []Vote{Yes, No, Yes, Yes, No} {
+		encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(vote), testVoterSecret, &keyPair.Pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := v.VerifyVoteWellFormedness(proof, encryptedVote, &keyPair.Pk); err != nil {
+			t.Fatalf("ballot %d: %v", i, err)
+		}
+		// a verification failure does not affect the next verifications
+		if err := v.VerifyVoteWellFormedness(proof, tally, &keyPair.Pk); err == nil {
+			t.Fatalf("ballot %d: successfully verified a proof for the wrong vote", i)
+		}
+		tally.Add(tally, encryptedVote)
+		numYes += int64(vote)
+	}
+	if err := v.VerifySkKnowledge(proofSk, &keyPair.Pk); err != nil {
+		t.Fatal(err)
+	}
+	result, proof, err := DecryptTallyWithProof(rand.Reader, tally, 5, keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != numYes {
+		t.Fatalf("expected result: %d, got: %d", numYes, result)
+	}
+	if err := v.VerifyCorrectDecryption(proof, tally, Vote(result), &keyPair.Pk); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.VerifyCorrectDecryption(proof, tally, Vote(result+1), &keyPair.Pk); err == nil {
+		t.Fatal("successfully verified a proof of decryption for the wrong result")
+	}
+}
+
+func TestVerifierInvalidInputs(t *testing.T) {
+	keyPair, proofSk, err := NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unset := new(arith.CurvePoint)
+	halfSet := new(EncryptedVote)
+	halfSet.A.Set(&encryptedVote.A)
+
+	tests := map[string]func() error{
+		"nil sk proof": func() error { return VerifySkKnowledge(nil, &keyPair.Pk) },
+		"unset pk":     func() error { return VerifySkKnowledge(proofSk, unset) },
+		"nil pk":       func() error { return VerifyVoteWellFormedness(proof, encryptedVote, nil) },
+		"nil ballot proof": func() error {
+			return VerifyVoteWellFormedness(nil, encryptedVote, &keyPair.Pk)
+		},
+		"unset encrypted vote": func() error {
+			return VerifyVoteWellFormedness(proof, halfSet, &keyPair.Pk)
+		},
+		"nil encrypted vote": func() error {
+			return VerifyCorrectDecryption(new(ProofCorrectDecryption), nil, Yes, &keyPair.Pk)
+		},
+		"nil decryption proof": func() error {
+			return VerifyCorrectDecryption(nil, encryptedVote, Yes, &keyPair.Pk)
+		},
+	}
+	for name, verify := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := verify(); err == nil {
+				t.Fatalf("successfully verified with %s", name)
+			}
+		})
+	}
+}
+
+func TestVerifierConcurrentUse(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(No), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the inputs are shared by all the goroutines, which must not write to them
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- VerifyVoteWellFormedness(proof, encryptedVote, &keyPair.Pk)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerifierVoteWellFormedness(b *testing.B) {
+	keyPair := generateKeyPair(b, rand.Reader)
+	encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		b.Fatal(err)
+	}
+	v := NewVerifier()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := v.VerifyVoteWellFormedness(proof, encryptedVote, &keyPair.Pk); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerifyVoteWellFormednessParallel(b *testing.B) {
+	keyPair := generateKeyPair(b, rand.Reader)
+	encryptedVote, proof, err := EncryptVoteWithProof(rand.Reader, int64(Yes), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := VerifyVoteWellFormedness(proof, encryptedVote, &keyPair.Pk); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
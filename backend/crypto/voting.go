@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"encoding/json"
 	"errors"
 	"io"
 	"math"
@@ -40,6 +41,24 @@ func (e *EncryptedVote) Set(v *EncryptedVote) *EncryptedVote {
 	return e
 }
 
+// UnmarshalJSON decodes an encrypted vote, rejecting those which lack
+// either component.
+func (e *EncryptedVote) UnmarshalJSON(data []byte) error {
+	var v struct {
+		A *arith.CurvePoint `json:"a"`
+		B *arith.CurvePoint `json:"b"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.A == nil || v.B == nil {
+		return errors.New("encrypted vote should have both a and b")
+	}
+	e.A.Set(v.A)
+	e.B.Set(v.B)
+	return nil
+}
+
 // Add sets the receiver to the sum of a and b and returns it.
 func (e *EncryptedVote) Add(a, b *EncryptedVote) *EncryptedVote {
 	e.A.Add(&a.A, &b.A)
@@ -47,12 +66,55 @@ func (e *EncryptedVote) Add(a, b *EncryptedVote) *EncryptedVote {
 	return e
 }
 
+// Neg sets the receiver to the negation of a and returns it. If a encrypts
+// m, the result encrypts -m.
+func (e *EncryptedVote) Neg(a *EncryptedVote) *EncryptedVote {
+	e.A.Neg(&a.A)
+	e.B.Neg(&a.B)
+	return e
+}
+
+// Sub sets the receiver to the difference of a and b and returns it. If a
+// encrypts m and b encrypts n, the result encrypts m - n. In particular,
+// subtracting a re-encryption of a ballot from a tally cancels the ballot.
+func (e *EncryptedVote) Sub(a, b *EncryptedVote) *EncryptedVote {
+	negB := new(EncryptedVote).Neg(b)
+	return e.Add(a, negB)
+}
+
+// Scale sets the receiver to the product of a by the scalar k and returns it.
+// If a encrypts m, the result encrypts k*m. This is used to weight a vote
+// by the voting power of the voter.
+func (e *EncryptedVote) Scale(a *EncryptedVote, k *arith.Scalar) *EncryptedVote {
+	e.A.ScalarMult(&a.A, k)
+	e.B.ScalarMult(&a.B, k)
+	return e
+}
+
 // Encrypt encrypts a vote and returns the encrypted vote and the secret
 // random scalar used for ElGamal encryption. This scalar is useful for
 // generating a proof of vote well-formedness with function ProveVoteWellFormedness.
-func (vote Vote) Encrypt(reader io.Reader, pk *arith.CurvePoint) (*EncryptedVote, *arith.Scalar, error) {
-	encodedVote := encode(vote)
-	return encryptInternal(encodedVote, reader, pk)
+//
+// The random scalar is hedged with voterSecret (see NewVoterSecret), so
+// that the vote stays hidden even if reader is weak or repeated.
+//
+// The caller should Destroy the secret scalar once it is no longer needed.
+func (vote Vote) Encrypt(
+	reader io.Reader,
+	voterSecret *arith.SecretScalar,
+	pk *arith.CurvePoint) (*EncryptedVote, *arith.SecretScalar, error) {
+	nonces, err := newVoterReader(reader, voterSecret, pk)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer nonces.destroy()
+	return vote.encrypt(nonces, pk)
+}
+
+// encrypt is Encrypt without a voter secret: reader should be hedged with
+// it already, e.g. be returned by newVoterReader.
+func (vote Vote) encrypt(reader io.Reader, pk *arith.CurvePoint) (*EncryptedVote, *arith.SecretScalar, error) {
+	return encryptInternal(encode(vote), reader, pk)
 }
 
 // Decrypt decrypts an encrypted vote and returns the result.
@@ -60,7 +122,7 @@ func (vote Vote) Encrypt(reader io.Reader, pk *arith.CurvePoint) (*EncryptedVote
 //
 // If the encrypted vote has been obtained by summing a number m 0-1 votes,
 // then m can be used as upper bound.
-func (vote *EncryptedVote) Decrypt(sk *arith.Scalar, n int64) (Vote, error) {
+func (vote *EncryptedVote) Decrypt(sk *arith.SecretScalar, n int64) (Vote, error) {
 	encodedVote := vote.decryptInternal(sk)
 	return decode(encodedVote, n)
 }
@@ -69,12 +131,20 @@ func (vote *EncryptedVote) Decrypt(sk *arith.Scalar, n int64) (Vote, error) {
 func encryptInternal(
 	encodedVote *arith.CurvePoint,
 	reader io.Reader,
-	pk *arith.CurvePoint) (*EncryptedVote, *arith.Scalar, error) {
-	r, a, err := arith.RandomCurvePoint(reader)
+	pk *arith.CurvePoint) (*EncryptedVote, *arith.SecretScalar, error) {
+	// the encoded vote is secret, but it is a curve point, so it is mixed
+	// with the statement
+	nonces, err := hedgedReader(reader, encryptionNonceDomain, nil, pk, encodedVote)
 	if err != nil {
 		return nil, nil, err
 	}
-	b := new(arith.CurvePoint).ScalarMult(pk, r)
+	defer nonces.destroy()
+	r, err := arith.RandomSecretScalar(nonces)
+	if err != nil {
+		return nil, nil, err
+	}
+	a := new(arith.CurvePoint).ScalarBaseMultSecret(r)
+	b := new(arith.CurvePoint).ScalarMultSecret(pk, r)
 	b = new(arith.CurvePoint).Add(b, encodedVote)
 	encryptedVote := new(EncryptedVote)
 	encryptedVote.A.Set(a)
@@ -84,9 +154,9 @@ func encryptInternal(
 
 // DecryptInternal computes the decryption of an encrypted vote (a, b), returning
 // the curve point b - sk*a.
-func (vote *EncryptedVote) decryptInternal(sk *arith.Scalar) *arith.CurvePoint {
-	skNegA := new(arith.CurvePoint).ScalarMult(&vote.A, new(arith.Scalar).Neg(sk))
-	return new(arith.CurvePoint).Add(skNegA, &vote.B)
+func (vote *EncryptedVote) decryptInternal(sk *arith.SecretScalar) *arith.CurvePoint {
+	skA := new(arith.CurvePoint).ScalarMultSecret(&vote.A, sk)
+	return new(arith.CurvePoint).Add(new(arith.CurvePoint).Neg(skA), &vote.B)
 }
 
 // Encode encodes a vote m as the curve point m*g.
@@ -95,6 +165,17 @@ func encode(vote Vote) *arith.CurvePoint {
 	return new(arith.CurvePoint).ScalarBaseMult(scalar)
 }
 
+// Decode returns the vote m such that encodedVote is m*g, where g is the
+// generator of bn256.G1. Since this requires solving a dlog problem, an upper
+// bound n on the result should be provided. It is useful to protocols which
+// build the encoding of a tally without ElGamal encryption.
+func Decode(encodedVote *arith.CurvePoint, n int64) (Vote, error) {
+	if n < 0 {
+		return 0, errors.New("upper bound should not be negative")
+	}
+	return decode(encodedVote, n)
+}
+
 // Decode decodes an encoded vote. Since this requires solving a dlog problem, an
 // upper bound n on the result should be provided.
 func decode(encodedVote *arith.CurvePoint, n int64) (Vote, error) {
@@ -2,8 +2,11 @@ package crypto
 
 import (
 	"crypto/rand"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"math/big"
 	mathrand "math/rand"
 	"testing"
 
@@ -16,7 +19,7 @@ func TestEncryptDecryptVote(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			encryptedVote, _, err := tc.vote.Encrypt(rand.Reader, &keyPair.Pk)
+			encryptedVote, _, err := tc.vote.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -54,6 +57,65 @@ func TestTallying(t *testing.T) {
 	}
 }
 
+func TestScaleEncryptedVote(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	weights := []int64{0, 1, 7, 1000}
+
+	for _, weight := range weights {
+		t.Run(fmt.Sprintf("weight %d", weight), func(t *testing.T) {
+			encryptedVote, _, err := Yes.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			scaled := new(EncryptedVote).Scale(encryptedVote, arith.NewScalar(big.NewInt(weight)))
+			got, err := scaled.Decrypt(&keyPair.Sk, weight)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if int64(got) != weight {
+				t.Fatalf("expected: %d, got: %d", weight, got)
+			}
+		})
+	}
+}
+
+func TestSubEncryptedVotes(t *testing.T) {
+	keyPair := generateKeyPair(t, rand.Reader)
+	tests := map[string]struct {
+		a, b Vote
+	}{
+		"cancel":   {a: Yes, b: Yes},
+		"positive": {a: 42, b: 40},
+		"zero":     {a: 7, b: No},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, _, err := tc.a.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, _, err := tc.b.Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff := new(EncryptedVote).Sub(a, b)
+			got, err := diff.Decrypt(&keyPair.Sk, int64(tc.a))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.a-tc.b {
+				t.Fatalf("expected: %d, got: %d", tc.a-tc.b, got)
+			}
+			// a - b + b = a
+			diff.Sub(diff, new(EncryptedVote).Neg(b))
+			if !diff.A.Equal(&a.A) || !diff.B.Equal(&a.B) {
+				t.Fatal("negation is not the inverse of addition")
+			}
+		})
+	}
+}
+
 func generateEncryptedResult(
 	t *testing.T,
 	r io.Reader,
@@ -73,7 +135,7 @@ func generateEncryptedResult(
 		} else {
 			vote = No
 		}
-		encryptedVote, _, err := vote.Encrypt(rand.Reader, pk)
+		encryptedVote, _, err := vote.Encrypt(rand.Reader, testVoterSecret, pk)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -118,3 +180,50 @@ func generateTallyingTests() tallyingTests {
 	}
 	return tests
 }
+
+func TestUnmarshalIncompleteEncryptedVote(t *testing.T) {
+	tests := map[string]string{
+		"empty":     `{}`,
+		"missing b": `{"a":{"x":"0","y":"0"}}`,
+		"missing a": `{"b":{"x":"0","y":"0"}}`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(data), new(EncryptedVote)); err == nil {
+				t.Fatalf("successfully decoded incomplete encrypted vote: %s", name)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := map[string]struct {
+		m   int64
+		n   int64
+		err bool
+	}{
+		"zero":            {m: 0, n: 0},
+		"at bound":        {m: 100, n: 100},
+		"below bound":     {m: 37, n: 1000},
+		"far above bound": {m: 1000, n: 100, err: true},
+		"negative bound":  {m: 0, n: -1, err: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Decode(encode(Vote(tc.m)), tc.n)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("decoded %d with bound %d", got, tc.n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if int64(got) != tc.m {
+				t.Fatalf("expected: %d, got: %d", tc.m, got)
+			}
+		})
+	}
+}
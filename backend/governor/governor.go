@@ -0,0 +1,656 @@
+// Package governor implements an in-memory simulator of the GovernorEncrypted
+// smart contracts (see smart-contracts/contracts/openzeppelin-voting), so that
+// elections can be simulated and tested entirely in Go.
+//
+// The simulator follows the semantics of an OpenZeppelin Governor extended
+// with GovernorEncrypted, GovernorEncryptedSettings and UpdateablePublicKey,
+// using a quorum expressed as a fraction of the total voting power, like
+// GovernorVotesQuorumFraction. Time is modeled by a logical clock, which
+// plays the role of the block number and is advanced explicitly with
+// Governor.Mine. Voting power is tracked with checkpoints, so that votes are
+// weighted by the voting power of each account at the proposal snapshot.
+//
+// Methods which would revert on-chain return an error instead, leaving the
+// state of the simulator unchanged.
+package governor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrNotInitialized is returned when proposing before the election public key is initialized.
+	ErrNotInitialized = errors.New("contract should be initialized")
+	// ErrAlreadyInitialized is returned when initializing the election public key twice.
+	ErrAlreadyInitialized = errors.New("contract already initialized")
+	// ErrUnknownProposal is returned when referencing a proposal which does not exist.
+	ErrUnknownProposal = errors.New("unknown proposal id")
+	// ErrProposalExists is returned when proposing twice the same proposal.
+	ErrProposalExists = errors.New("proposal already exists")
+	// ErrBelowThreshold is returned when the proposer does not have enough voting power.
+	ErrBelowThreshold = errors.New("proposer votes below proposal threshold")
+	// ErrVoteNotActive is returned when casting a vote on a proposal which is not Active.
+	ErrVoteNotActive = errors.New("vote not currently active")
+	// ErrVotingOver is returned when casting a vote on an Active proposal during its tallying period.
+	ErrVotingOver = errors.New("voting is over")
+	// ErrAlreadyVoted is returned when an account casts a second vote on the same proposal.
+	ErrAlreadyVoted = errors.New("vote already cast")
+	// ErrNotVoted is returned when an account recasts a vote on a proposal it has not voted on.
+	ErrNotVoted = errors.New("no vote to recast")
+	// ErrTallyingNotActive is returned when tallying outside of the tallying period.
+	ErrTallyingNotActive = errors.New("tallying not currently active")
+	// ErrProposalNotPending is returned when canceling a proposal which is not Pending.
+	ErrProposalNotPending = errors.New("too late to cancel")
+	// ErrNotProposer is returned when an account other than the proposer cancels a proposal.
+	ErrNotProposer = errors.New("only proposer can cancel")
+	// ErrProposalNotSuccessful is returned when executing a proposal which has not Succeeded.
+	ErrProposalNotSuccessful = errors.New("proposal not successful")
+)
+
+// ProposalState is the state of a proposal, as in IGovernor.ProposalState.
+type ProposalState int
+
+// Queued and Expired are only reached with a timelock, which the simulator
+// does not model. They are kept so that the numeric values match the ones
+// of the smart contract.
+const (
+	Pending ProposalState = iota
+	Active
+	Canceled
+	Defeated
+	Succeeded
+	Queued
+	Expired
+	Executed
+)
+
+func (s ProposalState) String() string {
+	switch s {
+	case Pending:
+		return "Pending"
+	case Active:
+		return "Active"
+	case Canceled:
+		return "Canceled"
+	case Defeated:
+		return "Defeated"
+	case Succeeded:
+		return "Succeeded"
+	case Queued:
+		return "Queued"
+	case Expired:
+		return "Expired"
+	case Executed:
+		return "Executed"
+	default:
+		return fmt.Sprintf("ProposalState(%d)", int(s))
+	}
+}
+
+// Settings are the governance parameters of the simulator. Periods are
+// measured in ticks of the logical clock.
+type Settings struct {
+	VotingDelay       uint64
+	VotingPeriod      uint64
+	TallyingPeriod    uint64
+	ProposalThreshold uint64
+	// QuorumNumerator is the quorum, expressed as a percentage of the total
+	// voting power at the proposal snapshot.
+	QuorumNumerator uint64
+}
+
+func (s *Settings) validate() error {
+	if s.VotingPeriod == 0 {
+		return errors.New("voting period too low")
+	}
+	if s.TallyingPeriod == 0 {
+		return errors.New("tallying period too low")
+	}
+	if s.TallyingPeriod >= s.VotingPeriod {
+		return errors.New("tallying period too high")
+	}
+	if s.QuorumNumerator > 100 {
+		return errors.New("quorum numerator over quorum denominator")
+	}
+	return nil
+}
+
+// proposal is the state of a proposal, merging Governor.ProposalCore and
+// GovernorEncrypted.ProposalVote.
+type proposal struct {
+	proposer  common.Address
+	voteStart uint64
+	voteEnd   uint64
+	votingEnd uint64
+	executed  bool
+	canceled  bool
+
+	pk        arith.CurvePoint
+	tally     crypto.EncryptedVote
+	forVotes  uint64
+	castVotes uint64
+	hasVoted  map[common.Address]bool
+	// ballots keeps the last ballot of each voter, which a recast vote
+	// must cancel.
+	ballots *crypto.BallotRegistry
+
+	// outcomeOnly is set when the tally has been posted with TallyOutcome,
+	// in which case passed replaces the comparison of forVotes with the
+	// quorum and the majority.
+	outcomeOnly bool
+	passed      bool
+}
+
+// Governor simulates a GovernorEncrypted contract. It is safe for
+// concurrent use.
+type Governor struct {
+	mu          sync.RWMutex
+	settings    Settings
+	clock       uint64
+	votes       *votes
+	initialized bool
+	currentPk   arith.CurvePoint
+	pkHistory   []PkUpdate
+	proposals   map[common.Hash]*proposal
+}
+
+// PkUpdate records that the election public key was set to Pk at
+// Timepoint.
+type PkUpdate struct {
+	Pk        arith.CurvePoint
+	Timepoint uint64
+}
+
+// New returns a Governor with the given settings, whose clock starts at 1.
+// The election public key must be set with Initialize before proposing.
+func New(settings Settings) (*Governor, error) {
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+	return &Governor{
+		settings:  settings,
+		clock:     1,
+		votes:     newVotes(),
+		proposals: make(map[common.Hash]*proposal),
+	}, nil
+}
+
+// Settings returns the governance parameters.
+func (g *Governor) Settings() Settings {
+	return g.settings
+}
+
+// Clock returns the current timepoint.
+func (g *Governor) Clock() uint64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.clock
+}
+
+// Mine advances the clock by n ticks, and returns the new timepoint.
+func (g *Governor) Mine(n uint64) uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.clock += n
+	return g.clock
+}
+
+// SetVotes sets the voting power of account from the current timepoint on.
+func (g *Governor) SetVotes(account common.Address, votes uint64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	total := g.votes.getPastTotalSupply(g.clock) - g.votes.get(account)
+	if votes > math.MaxUint64-total {
+		return errors.New("total voting power overflows uint64")
+	}
+	g.votes.set(g.clock, account, votes)
+	return nil
+}
+
+// GetVotes returns the voting power of account at timepoint.
+func (g *Governor) GetVotes(account common.Address, timepoint uint64) uint64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.votes.getPast(account, timepoint)
+}
+
+// Quorum returns the minimum number of for votes needed for a proposal
+// whose snapshot is timepoint to pass.
+func (g *Governor) Quorum(timepoint uint64) uint64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.quorum(timepoint)
+}
+
+func (g *Governor) quorum(timepoint uint64) uint64 {
+	supply := new(big.Int).SetUint64(g.votes.getPastTotalSupply(timepoint))
+	supply.Mul(supply, new(big.Int).SetUint64(g.settings.QuorumNumerator))
+	return supply.Div(supply, big.NewInt(100)).Uint64()
+}
+
+// Initialize sets the election public key, after verifying the proof of
+// knowledge of its secret key. It can be invoked only once.
+func (g *Governor) Initialize(pk *arith.CurvePoint, proof *crypto.ProofSkKnowledge) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.initialized {
+		return ErrAlreadyInitialized
+	}
+	if err := g.updateCurrentPk(pk, proof); err != nil {
+		return err
+	}
+	g.initialized = true
+	return nil
+}
+
+// UpdateCurrentPk replaces the election public key, after verifying the
+// proof of knowledge of its secret key. Proposals keep the key which was
+// current when they were created, so the secret keys of old public keys
+// must be retained until all of their proposals are tallied.
+//
+// On-chain this can only be performed through a governance proposal; the
+// simulator leaves access control to the caller.
+func (g *Governor) UpdateCurrentPk(pk *arith.CurvePoint, proof *crypto.ProofSkKnowledge) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.initialized {
+		return ErrNotInitialized
+	}
+	return g.updateCurrentPk(pk, proof)
+}
+
+func (g *Governor) updateCurrentPk(pk *arith.CurvePoint, proof *crypto.ProofSkKnowledge) error {
+	if err := crypto.VerifySkKnowledge(proof, pk); err != nil {
+		return err
+	}
+	g.currentPk.Set(pk)
+	update := PkUpdate{Timepoint: g.clock}
+	update.Pk.Set(pk)
+	g.pkHistory = append(g.pkHistory, update)
+	return nil
+}
+
+// CurrentPk returns the public key with which new proposals are created.
+func (g *Governor) CurrentPk() (*arith.CurvePoint, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if !g.initialized {
+		return nil, ErrNotInitialized
+	}
+	return new(arith.CurvePoint).Set(&g.currentPk), nil
+}
+
+// PkHistory returns all the election public keys set so far, oldest first.
+func (g *Governor) PkHistory() []PkUpdate {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	res := make([]PkUpdate, len(g.pkHistory))
+	for i := range g.pkHistory {
+		res[i].Pk.Set(&g.pkHistory[i].Pk)
+		res[i].Timepoint = g.pkHistory[i].Timepoint
+	}
+	return res
+}
+
+// ProposalID returns the id of the proposal with the given description. It
+// stands for the hash of the proposal actions and description computed by
+// Governor.hashProposal, since the simulator does not execute any action.
+func ProposalID(description string) common.Hash {
+	return ethcrypto.Keccak256Hash([]byte(description))
+}
+
+// Propose creates a new proposal, whose votes will be encrypted with the
+// current election public key, and returns its id.
+func (g *Governor) Propose(proposer common.Address, description string) (common.Hash, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.initialized {
+		return common.Hash{}, ErrNotInitialized
+	}
+	if g.votes.getPast(proposer, g.clock-1) < g.settings.ProposalThreshold {
+		return common.Hash{}, ErrBelowThreshold
+	}
+	id := ProposalID(description)
+	if _, ok := g.proposals[id]; ok {
+		return common.Hash{}, ErrProposalExists
+	}
+
+	p := &proposal{
+		proposer:  proposer,
+		voteStart: g.clock + g.settings.VotingDelay,
+		hasVoted:  make(map[common.Address]bool),
+	}
+	p.voteEnd = p.voteStart + g.settings.VotingPeriod
+	p.votingEnd = p.voteEnd - g.settings.TallyingPeriod
+	p.pk.Set(&g.currentPk)
+	p.tally.Set(crypto.NewEncryptedVote())
+	p.ballots = crypto.NewBallotRegistry(&p.pk)
+	g.proposals[id] = p
+	return id, nil
+}
+
+// State returns the current state of a proposal.
+func (g *Governor) State(id common.Hash) (ProposalState, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return 0, ErrUnknownProposal
+	}
+	return g.state(p), nil
+}
+
+func (g *Governor) state(p *proposal) ProposalState {
+	switch {
+	case p.executed:
+		return Executed
+	case p.canceled:
+		return Canceled
+	case p.voteStart >= g.clock:
+		return Pending
+	case p.voteEnd >= g.clock:
+		return Active
+	case p.outcomeOnly && p.passed:
+		return Succeeded
+	case p.outcomeOnly:
+		return Defeated
+	case g.quorumReached(p) && voteSucceeded(p):
+		return Succeeded
+	default:
+		return Defeated
+	}
+}
+
+func (g *Governor) quorumReached(p *proposal) bool {
+	return g.quorum(p.voteStart) <= p.forVotes
+}
+
+func voteSucceeded(p *proposal) bool {
+	return p.forVotes > p.castVotes-p.forVotes
+}
+
+// CastEncryptedVote casts the encrypted vote of voter, weighted by its
+// voting power at the proposal snapshot, after verifying its proof of
+// well-formedness. It returns the weight of the vote.
+func (g *Governor) CastEncryptedVote(
+	id common.Hash,
+	voter common.Address,
+	vote *crypto.EncryptedVote,
+	proof *crypto.ProofVoteWellFormedness) (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return 0, ErrUnknownProposal
+	}
+	if g.state(p) != Active {
+		return 0, ErrVoteNotActive
+	}
+	if p.hasVoted[voter] {
+		return 0, ErrAlreadyVoted
+	}
+	if g.clock > p.votingEnd {
+		return 0, ErrVotingOver
+	}
+
+	weight := g.votes.getPast(voter, p.voteStart)
+	scaled, err := p.ballots.Cast(voter.Hex(), weight, vote, proof)
+	if err != nil {
+		return 0, err
+	}
+	p.tally.Add(&p.tally, scaled)
+	p.castVotes += weight
+	p.hasVoted[voter] = true
+	return weight, nil
+}
+
+// RecastEncryptedVote replaces the last encrypted vote of voter with a new
+// one, after verifying its proof of well-formedness and that the
+// cancellation of the ballot is a re-encryption of the last vote. The
+// tally is updated homomorphically, subtracting the cancellation and
+// adding the new vote, both weighted like the first vote of voter, so that
+// only the last vote of each voter is counted. The cast votes do not
+// change.
+func (g *Governor) RecastEncryptedVote(id common.Hash, voter common.Address, ballot *crypto.RecastBallot) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return ErrUnknownProposal
+	}
+	if g.state(p) != Active {
+		return ErrVoteNotActive
+	}
+	if !p.hasVoted[voter] {
+		return ErrNotVoted
+	}
+	if g.clock > p.votingEnd {
+		return ErrVotingOver
+	}
+	delta, err := p.ballots.Recast(voter.Hex(), ballot)
+	if err != nil {
+		return err
+	}
+	p.tally.Add(&p.tally, delta)
+	return nil
+}
+
+// LastEncryptedVote returns the last encrypted vote cast by account on a
+// proposal, which a recast vote must cancel.
+func (g *Governor) LastEncryptedVote(id common.Hash, account common.Address) (*crypto.EncryptedVote, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return nil, ErrUnknownProposal
+	}
+	if !p.hasVoted[account] {
+		return nil, ErrNotVoted
+	}
+	return p.ballots.LastBallot(account.Hex())
+}
+
+// Tally posts the result of tallying, i.e. the total weight of for votes,
+// after verifying its proof of correct decryption. It must be invoked after
+// VotingDeadline and no later than ProposalDeadline.
+func (g *Governor) Tally(id common.Hash, proof *crypto.ProofCorrectDecryption, forVotes uint64) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return ErrUnknownProposal
+	}
+	if g.state(p) != Active || g.clock <= p.votingEnd {
+		return ErrTallyingNotActive
+	}
+	if forVotes > p.castVotes {
+		return fmt.Errorf("for votes %d exceed cast votes %d", forVotes, p.castVotes)
+	}
+	if err := crypto.VerifyCorrectDecryption(proof, &p.tally, crypto.Vote(forVotes), &p.pk); err != nil {
+		return err
+	}
+	p.forVotes = forVotes
+	p.outcomeOnly = false
+	return nil
+}
+
+// SuccessThreshold returns the minimum weight of for votes for a proposal
+// to succeed, i.e. to reach both the quorum and the majority of cast votes.
+// It only stops changing after VotingDeadline.
+func (g *Governor) SuccessThreshold(id common.Hash) (uint64, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return 0, ErrUnknownProposal
+	}
+	return g.successThreshold(p), nil
+}
+
+func (g *Governor) successThreshold(p *proposal) uint64 {
+	// forVotes > castVotes - forVotes if and only if
+	// forVotes >= castVotes/2 + 1
+	threshold := p.castVotes/2 + 1
+	if quorum := g.quorum(p.voteStart); quorum > threshold {
+		threshold = quorum
+	}
+	return threshold
+}
+
+// TallyOutcome is like Tally, but only posts whether the proposal passed,
+// i.e. whether the weight of for votes is at least SuccessThreshold,
+// without revealing it. The proof should be obtained with
+// crypto.ProveThreshold, using the cast votes as upper bound.
+func (g *Governor) TallyOutcome(id common.Hash, proof *crypto.ProofThreshold, passed bool) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return ErrUnknownProposal
+	}
+	if g.state(p) != Active || g.clock <= p.votingEnd {
+		return ErrTallyingNotActive
+	}
+	threshold := g.successThreshold(p)
+	if err := crypto.VerifyThreshold(proof, &p.tally, p.castVotes, threshold, passed, &p.pk); err != nil {
+		return err
+	}
+	p.forVotes = 0
+	p.outcomeOnly = true
+	p.passed = passed
+	return nil
+}
+
+// Cancel cancels a proposal. Only the proposer can cancel a proposal, and
+// only while it is Pending.
+func (g *Governor) Cancel(id common.Hash, account common.Address) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return ErrUnknownProposal
+	}
+	if g.state(p) != Pending {
+		return ErrProposalNotPending
+	}
+	if account != p.proposer {
+		return ErrNotProposer
+	}
+	p.canceled = true
+	return nil
+}
+
+// Execute marks a Succeeded proposal as Executed.
+func (g *Governor) Execute(id common.Hash) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return ErrUnknownProposal
+	}
+	if g.state(p) != Succeeded {
+		return ErrProposalNotSuccessful
+	}
+	p.executed = true
+	return nil
+}
+
+// HasVoted reports whether account has cast a vote on a proposal.
+func (g *Governor) HasVoted(id common.Hash, account common.Address) (bool, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return false, ErrUnknownProposal
+	}
+	return p.hasVoted[account], nil
+}
+
+// ProposalSnapshot returns the timepoint at which voting power is read,
+// after which the proposal becomes Active.
+func (g *Governor) ProposalSnapshot(id common.Hash) (uint64, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return 0, ErrUnknownProposal
+	}
+	return p.voteStart, nil
+}
+
+// ProposalDeadline returns the last timepoint of the tallying period.
+func (g *Governor) ProposalDeadline(id common.Hash) (uint64, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return 0, ErrUnknownProposal
+	}
+	return p.voteEnd, nil
+}
+
+// VotingDeadline returns the last timepoint at which votes can be cast.
+func (g *Governor) VotingDeadline(id common.Hash) (uint64, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return 0, ErrUnknownProposal
+	}
+	return p.votingEnd, nil
+}
+
+// GetPk returns the election public key of a proposal.
+func (g *Governor) GetPk(id common.Hash) (*arith.CurvePoint, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return nil, ErrUnknownProposal
+	}
+	return new(arith.CurvePoint).Set(&p.pk), nil
+}
+
+// GetCastVotes returns the total weight cast on a proposal.
+func (g *Governor) GetCastVotes(id common.Hash) (uint64, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return 0, ErrUnknownProposal
+	}
+	return p.castVotes, nil
+}
+
+// GetForVotes returns the total weight of for votes posted by Tally, or 0
+// if the proposal has not been tallied yet or if only its outcome has been
+// posted with TallyOutcome.
+func (g *Governor) GetForVotes(id common.Hash) (uint64, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return 0, ErrUnknownProposal
+	}
+	return p.forVotes, nil
+}
+
+// GetTally returns the current encrypted tally of a proposal.
+func (g *Governor) GetTally(id common.Hash) (*crypto.EncryptedVote, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p, ok := g.proposals[id]
+	if !ok {
+		return nil, ErrUnknownProposal
+	}
+	return new(crypto.EncryptedVote).Set(&p.tally), nil
+}
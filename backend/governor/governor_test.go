@@ -0,0 +1,465 @@
+package governor
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var testSettings = Settings{
+	VotingDelay:       2,
+	VotingPeriod:      10,
+	TallyingPeriod:    3,
+	ProposalThreshold: 1,
+	QuorumNumerator:   20,
+}
+
+type voter struct {
+	account common.Address
+	weight  uint64
+	vote    crypto.Vote
+}
+
+// testVoterSecret is the voter secret of the encryptions of the tests.
+var testVoterSecret, _ = crypto.NewVoterSecret([]byte("e-voting test voter"))
+
+func TestProposalWorkflow(t *testing.T) {
+	tests := map[string]struct {
+		voters   []voter
+		expected ProposalState
+	}{
+		"majority of weight in favor": {
+			voters: []voter{
+				{common.HexToAddress("0x01"), 25, crypto.Yes},
+				{common.HexToAddress("0x02"), 15, crypto.No},
+				{common.HexToAddress("0x03"), 5, crypto.No},
+			},
+			expected: Succeeded,
+		},
+		"majority of voters against": {
+			voters: []voter{
+				{common.HexToAddress("0x01"), 1, crypto.No},
+				{common.HexToAddress("0x02"), 1, crypto.No},
+				{common.HexToAddress("0x03"), 30, crypto.Yes},
+			},
+			expected: Succeeded,
+		},
+		"tie": {
+			voters: []voter{
+				{common.HexToAddress("0x01"), 24, crypto.Yes},
+				{common.HexToAddress("0x02"), 24, crypto.No},
+			},
+			expected: Defeated,
+		},
+		"quorum not reached": {
+			voters: []voter{
+				{common.HexToAddress("0x01"), 1, crypto.Yes},
+				{common.HexToAddress("0x02"), 20, crypto.No},
+			},
+			expected: Defeated,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			g, keyPair := newTestGovernor(t)
+			var total, castVotes, forVotes uint64
+			for _, v := range tc.voters {
+				setVotes(t, g, v.account, v.weight)
+				total += v.weight
+			}
+			// the remaining voting power abstains, so that the quorum is 20
+			if err := g.SetVotes(common.HexToAddress("0xff"), 100-total); err != nil {
+				t.Fatal(err)
+			}
+			g.Mine(1)
+			id := propose(t, g, tc.voters[0].account, name)
+			assertState(t, g, id, Pending)
+			g.Mine(testSettings.VotingDelay + 1)
+			assertState(t, g, id, Active)
+
+			for _, v := range tc.voters {
+				weight, err := castVote(g, id, v.account, v.vote, &keyPair.Pk)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if weight != v.weight {
+					t.Fatalf("expected weight: %d, got: %d", v.weight, weight)
+				}
+				castVotes += weight
+				forVotes += weight * uint64(v.vote)
+			}
+			if got, _ := g.GetCastVotes(id); got != castVotes {
+				t.Fatalf("expected cast votes: %d, got: %d", castVotes, got)
+			}
+
+			mineUntil(g, votingDeadline(t, g, id)+1)
+			assertState(t, g, id, Active)
+			tally, err := g.GetTally(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, proof, err := crypto.DecryptTallyWithProof(rand.Reader, tally, int64(castVotes), keyPair)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if uint64(result) != forVotes {
+				t.Fatalf("expected for votes: %d, got: %d", forVotes, result)
+			}
+			if err := g.Tally(id, proof, uint64(result)); err != nil {
+				t.Fatal(err)
+			}
+
+			deadline, _ := g.ProposalDeadline(id)
+			mineUntil(g, deadline+1)
+			assertState(t, g, id, tc.expected)
+			err = g.Execute(id)
+			if tc.expected == Succeeded {
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertState(t, g, id, Executed)
+			} else if !errors.Is(err, ErrProposalNotSuccessful) {
+				t.Fatalf("expected error %v, got %v", ErrProposalNotSuccessful, err)
+			}
+		})
+	}
+}
+
+func TestCastVoteRules(t *testing.T) {
+	g, keyPair := newTestGovernor(t)
+	alice := common.HexToAddress("0x01")
+	bob := common.HexToAddress("0x02")
+	carol := common.HexToAddress("0x03")
+	setVotes(t, g, alice, 2)
+	setVotes(t, g, bob, 3)
+	g.Mine(1)
+	id := propose(t, g, alice, "proposal")
+
+	if _, err := castVote(g, id, alice, crypto.Yes, &keyPair.Pk); !errors.Is(err, ErrVoteNotActive) {
+		t.Fatalf("expected error %v, got %v", ErrVoteNotActive, err)
+	}
+
+	g.Mine(testSettings.VotingDelay + 1)
+	// voting power acquired after the snapshot does not count
+	setVotes(t, g, carol, 50)
+	setVotes(t, g, bob, 0)
+	if weight, err := castVote(g, id, carol, crypto.Yes, &keyPair.Pk); err != nil || weight != 0 {
+		t.Fatalf("expected weight 0, got %d, %v", weight, err)
+	}
+	if weight, err := castVote(g, id, bob, crypto.No, &keyPair.Pk); err != nil || weight != 3 {
+		t.Fatalf("expected weight 3, got %d, %v", weight, err)
+	}
+	if _, err := castVote(g, id, bob, crypto.No, &keyPair.Pk); !errors.Is(err, ErrAlreadyVoted) {
+		t.Fatalf("expected error %v, got %v", ErrAlreadyVoted, err)
+	}
+
+	otherKeyPair, err := crypto.NewKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := castVote(g, id, alice, crypto.Yes, &otherKeyPair.Pk); err == nil {
+		t.Fatal("successfully cast a vote encrypted with the wrong key")
+	}
+	if voted, _ := g.HasVoted(id, alice); voted {
+		t.Fatal("rejected vote has been recorded")
+	}
+
+	tally, _ := g.GetTally(id)
+	result, proof, err := crypto.DecryptTallyWithProof(rand.Reader, tally, 3, keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Tally(id, proof, uint64(result)); !errors.Is(err, ErrTallyingNotActive) {
+		t.Fatalf("expected error %v, got %v", ErrTallyingNotActive, err)
+	}
+
+	mineUntil(g, votingDeadline(t, g, id)+1)
+	if _, err := castVote(g, id, alice, crypto.Yes, &keyPair.Pk); !errors.Is(err, ErrVotingOver) {
+		t.Fatalf("expected error %v, got %v", ErrVotingOver, err)
+	}
+	if err := g.Tally(id, proof, uint64(result)+1); err == nil {
+		t.Fatal("successfully posted a wrong tally")
+	}
+	if err := g.Tally(id, proof, uint64(result)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecastEncryptedVote(t *testing.T) {
+	g, keyPair := newTestGovernor(t)
+	alice := common.HexToAddress("0x01")
+	bob := common.HexToAddress("0x02")
+	setVotes(t, g, alice, 30)
+	setVotes(t, g, bob, 20)
+	g.Mine(1)
+	id := propose(t, g, alice, "proposal")
+	g.Mine(testSettings.VotingDelay + 1)
+
+	if err := recastVote(g, id, alice, crypto.No, &keyPair.Pk); !errors.Is(err, ErrNotVoted) {
+		t.Fatalf("expected error %v, got %v", ErrNotVoted, err)
+	}
+	for _, account := range []common.Address{alice, bob} {
+		if _, err := castVote(g, id, account, crypto.Yes, &keyPair.Pk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// alice revotes against, then bob against and for again
+	for _, revote := range []struct {
+		account common.Address
+		vote    crypto.Vote
+	}{{alice, crypto.No}, {bob, crypto.No}, {bob, crypto.Yes}} {
+		if err := recastVote(g, id, revote.account, revote.vote, &keyPair.Pk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, _ := g.GetCastVotes(id); got != 50 {
+		t.Fatalf("expected cast votes: 50, got: %d", got)
+	}
+
+	// a cancellation of another vote than the last one is rejected
+	other, _, err := crypto.EncryptVoteWithProof(rand.Reader, int64(crypto.No), testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ballot, err := crypto.NewRecastBallot(rand.Reader, int64(crypto.Yes), other, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.RecastEncryptedVote(id, alice, ballot); err == nil {
+		t.Fatal("successfully recast a vote cancelling another vote")
+	}
+
+	mineUntil(g, votingDeadline(t, g, id)+1)
+	if err := recastVote(g, id, alice, crypto.Yes, &keyPair.Pk); !errors.Is(err, ErrVotingOver) {
+		t.Fatalf("expected error %v, got %v", ErrVotingOver, err)
+	}
+	tally, _ := g.GetTally(id)
+	result, proof, err := crypto.DecryptTallyWithProof(rand.Reader, tally, 50, keyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != 20 {
+		t.Fatalf("expected for votes: 20, got: %d", result)
+	}
+	if err := g.Tally(id, proof, uint64(result)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTallyOutcome(t *testing.T) {
+	tests := map[string]struct {
+		voters   []voter
+		expected ProposalState
+	}{
+		"majority in favor": {
+			voters: []voter{
+				{common.HexToAddress("0x01"), 25, crypto.Yes},
+				{common.HexToAddress("0x02"), 15, crypto.No},
+			},
+			expected: Succeeded,
+		},
+		"tie": {
+			voters: []voter{
+				{common.HexToAddress("0x01"), 24, crypto.Yes},
+				{common.HexToAddress("0x02"), 24, crypto.No},
+			},
+			expected: Defeated,
+		},
+		"quorum not reached": {
+			voters: []voter{
+				{common.HexToAddress("0x01"), 19, crypto.Yes},
+			},
+			expected: Defeated,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			g, keyPair := newTestGovernor(t)
+			var total uint64
+			for _, v := range tc.voters {
+				setVotes(t, g, v.account, v.weight)
+				total += v.weight
+			}
+			// the remaining voting power abstains, so that the quorum is 20
+			setVotes(t, g, common.HexToAddress("0xff"), 100-total)
+			g.Mine(1)
+			id := propose(t, g, tc.voters[0].account, name)
+			g.Mine(testSettings.VotingDelay + 1)
+			for _, v := range tc.voters {
+				if _, err := castVote(g, id, v.account, v.vote, &keyPair.Pk); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			mineUntil(g, votingDeadline(t, g, id)+1)
+			tally, _ := g.GetTally(id)
+			castVotes, _ := g.GetCastVotes(id)
+			threshold, err := g.SuccessThreshold(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			passed, proof, err := crypto.ProveThreshold(rand.Reader, tally, castVotes, threshold, keyPair)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := g.TallyOutcome(id, proof, !passed); err == nil {
+				t.Fatal("successfully posted the wrong outcome")
+			}
+			if err := g.TallyOutcome(id, proof, passed); err != nil {
+				t.Fatal(err)
+			}
+
+			deadline, _ := g.ProposalDeadline(id)
+			mineUntil(g, deadline+1)
+			assertState(t, g, id, tc.expected)
+			if forVotes, _ := g.GetForVotes(id); forVotes != 0 {
+				t.Fatalf("for votes have been revealed: %d", forVotes)
+			}
+		})
+	}
+}
+
+func TestProposeAndCancel(t *testing.T) {
+	g, err := New(testSettings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := common.HexToAddress("0x01")
+	bob := common.HexToAddress("0x02")
+	setVotes(t, g, alice, 1)
+	g.Mine(1)
+	if _, err := g.Propose(alice, "proposal"); !errors.Is(err, ErrNotInitialized) {
+		t.Fatalf("expected error %v, got %v", ErrNotInitialized, err)
+	}
+
+	keyPair, proof, err := crypto.NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Initialize(&keyPair.Pk, proof); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Initialize(&keyPair.Pk, proof); !errors.Is(err, ErrAlreadyInitialized) {
+		t.Fatalf("expected error %v, got %v", ErrAlreadyInitialized, err)
+	}
+
+	if _, err := g.Propose(bob, "proposal"); !errors.Is(err, ErrBelowThreshold) {
+		t.Fatalf("expected error %v, got %v", ErrBelowThreshold, err)
+	}
+	id := propose(t, g, alice, "proposal")
+	if _, err := g.Propose(alice, "proposal"); !errors.Is(err, ErrProposalExists) {
+		t.Fatalf("expected error %v, got %v", ErrProposalExists, err)
+	}
+	if err := g.Cancel(id, bob); !errors.Is(err, ErrNotProposer) {
+		t.Fatalf("expected error %v, got %v", ErrNotProposer, err)
+	}
+	if err := g.Cancel(id, alice); err != nil {
+		t.Fatal(err)
+	}
+	assertState(t, g, id, Canceled)
+	if err := g.Cancel(id, alice); !errors.Is(err, ErrProposalNotPending) {
+		t.Fatalf("expected error %v, got %v", ErrProposalNotPending, err)
+	}
+	if _, err := g.State(common.Hash{}); !errors.Is(err, ErrUnknownProposal) {
+		t.Fatalf("expected error %v, got %v", ErrUnknownProposal, err)
+	}
+}
+
+func TestInvalidSettings(t *testing.T) {
+	tests := map[string]Settings{
+		"zero voting period":        {VotingPeriod: 0, TallyingPeriod: 1},
+		"zero tallying period":      {VotingPeriod: 5, TallyingPeriod: 0},
+		"tallying period too high":  {VotingPeriod: 5, TallyingPeriod: 5},
+		"quorum numerator over 100": {VotingPeriod: 5, TallyingPeriod: 1, QuorumNumerator: 101},
+	}
+	for name, settings := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := New(settings); err == nil {
+				t.Fatalf("successfully created governor with invalid settings: %s", name)
+			}
+		})
+	}
+}
+
+func newTestGovernor(t *testing.T) (*Governor, *crypto.KeyPair) {
+	g, err := New(testSettings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPair, proof, err := crypto.NewKeyPairWithProof(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Initialize(&keyPair.Pk, proof); err != nil {
+		t.Fatal(err)
+	}
+	return g, keyPair
+}
+
+func setVotes(t *testing.T, g *Governor, account common.Address, votes uint64) {
+	t.Helper()
+	if err := g.SetVotes(account, votes); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func propose(t *testing.T, g *Governor, proposer common.Address, description string) common.Hash {
+	t.Helper()
+	id, err := g.Propose(proposer, description)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func castVote(g *Governor, id common.Hash, account common.Address, vote crypto.Vote, pk *arith.CurvePoint) (uint64, error) {
+	encryptedVote, proof, err := crypto.EncryptVoteWithProof(rand.Reader, int64(vote), testVoterSecret, pk)
+	if err != nil {
+		return 0, err
+	}
+	return g.CastEncryptedVote(id, account, encryptedVote, proof)
+}
+
+func recastVote(g *Governor, id common.Hash, account common.Address, vote crypto.Vote, pk *arith.CurvePoint) error {
+	previous, err := g.LastEncryptedVote(id, account)
+	if err != nil {
+		return err
+	}
+	ballot, err := crypto.NewRecastBallot(rand.Reader, int64(vote), previous, testVoterSecret, pk)
+	if err != nil {
+		return err
+	}
+	return g.RecastEncryptedVote(id, account, ballot)
+}
+
+func votingDeadline(t *testing.T, g *Governor, id common.Hash) uint64 {
+	t.Helper()
+	deadline, err := g.VotingDeadline(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return deadline
+}
+
+func mineUntil(g *Governor, timepoint uint64) {
+	if now := g.Clock(); now < timepoint {
+		g.Mine(timepoint - now)
+	}
+}
+
+func assertState(t *testing.T, g *Governor, id common.Hash, expected ProposalState) {
+	t.Helper()
+	got, err := g.State(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != expected {
+		t.Fatalf("expected state: %s, got: %s", expected, got)
+	}
+}
@@ -0,0 +1,72 @@
+package governor
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrUnknownKey is returned by Keyring.KeyPairFor when the keyring does not
+// hold the secret key of the public key of a proposal.
+var ErrUnknownKey = errors.New("no key pair for the public key of the proposal")
+
+// Keyring holds the key pairs of a tallying authority across public key
+// rotations, and tells which one decrypts the tally of a given proposal.
+// It is safe for concurrent use.
+type Keyring struct {
+	mu   sync.RWMutex
+	keys map[string]*crypto.KeyPair
+}
+
+// NewKeyring returns an empty Keyring.
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[string]*crypto.KeyPair)}
+}
+
+// Add adds a key pair to the keyring. It fails if the public key does not
+// match the secret key.
+func (k *Keyring) Add(keyPair *crypto.KeyPair) error {
+	if !new(arith.CurvePoint).ScalarBaseMultSecret(&keyPair.Sk).Equal(&keyPair.Pk) {
+		return errors.New("public key does not match secret key")
+	}
+	res := new(crypto.KeyPair)
+	res.Pk.Set(&keyPair.Pk)
+	res.Sk.Set(&keyPair.Sk)
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys[keyringID(&keyPair.Pk)] = res
+	return nil
+}
+
+// KeyPair returns the key pair of public key pk.
+func (k *Keyring) KeyPair(pk *arith.CurvePoint) (*crypto.KeyPair, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	keyPair, ok := k.keys[keyringID(pk)]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	res := new(crypto.KeyPair)
+	res.Pk.Set(&keyPair.Pk)
+	res.Sk.Set(&keyPair.Sk)
+	return res, nil
+}
+
+// KeyPairFor returns the key pair which decrypts the tally of proposal id
+// of g, i.e. the one of the public key which was current when the proposal
+// was created.
+func (k *Keyring) KeyPairFor(g *Governor, id common.Hash) (*crypto.KeyPair, error) {
+	pk, err := g.GetPk(id)
+	if err != nil {
+		return nil, err
+	}
+	return k.KeyPair(pk)
+}
+
+func keyringID(pk *arith.CurvePoint) string {
+	data, _ := pk.MarshalBinary()
+	return string(data)
+}
@@ -0,0 +1,107 @@
+package governor
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestKeyRotation(t *testing.T) {
+	g, err := New(testSettings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyring := NewKeyring()
+	alice := common.HexToAddress("0x01")
+	setVotes(t, g, alice, 10)
+	g.Mine(1)
+
+	newKey := func() (*crypto.KeyPair, *crypto.ProofSkKnowledge) {
+		keyPair, proof, err := crypto.NewKeyPairWithProof(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := keyring.Add(keyPair); err != nil {
+			t.Fatal(err)
+		}
+		return keyPair, proof
+	}
+
+	oldKeyPair, oldProof := newKey()
+	if err := g.UpdateCurrentPk(&oldKeyPair.Pk, oldProof); !errors.Is(err, ErrNotInitialized) {
+		t.Fatalf("expected error %v, got %v", ErrNotInitialized, err)
+	}
+	if err := g.Initialize(&oldKeyPair.Pk, oldProof); err != nil {
+		t.Fatal(err)
+	}
+	oldID := propose(t, g, alice, "old proposal")
+
+	newKeyPair, newProof := newKey()
+	if err := g.UpdateCurrentPk(&newKeyPair.Pk, oldProof); err == nil {
+		t.Fatal("successfully updated pk with a wrong proof")
+	}
+	g.Mine(1)
+	if err := g.UpdateCurrentPk(&newKeyPair.Pk, newProof); err != nil {
+		t.Fatal(err)
+	}
+	newID := propose(t, g, alice, "new proposal")
+
+	current, err := g.CurrentPk()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !current.Equal(&newKeyPair.Pk) {
+		t.Fatal("current pk is not the updated one")
+	}
+	history := g.PkHistory()
+	if len(history) != 2 || !history[0].Pk.Equal(&oldKeyPair.Pk) || history[1].Timepoint != history[0].Timepoint+1 {
+		t.Fatalf("unexpected pk history: %v", history)
+	}
+
+	// each proposal is voted and tallied with the key it was created with
+	g.Mine(testSettings.VotingDelay + 1)
+	for id, keyPair := range map[common.Hash]*crypto.KeyPair{oldID: oldKeyPair, newID: newKeyPair} {
+		pk, err := g.GetPk(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := castVote(g, id, alice, crypto.Yes, pk); err != nil {
+			t.Fatal(err)
+		}
+		got, err := keyring.KeyPairFor(g, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Pk.Equal(&keyPair.Pk) {
+			t.Fatal("keyring returned the wrong key pair")
+		}
+	}
+	mineUntil(g, votingDeadline(t, g, newID)+1)
+	for _, id := range []common.Hash{oldID, newID} {
+		keyPair, err := keyring.KeyPairFor(g, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tally, _ := g.GetTally(id)
+		result, proof, err := crypto.DecryptTallyWithProof(rand.Reader, tally, 10, keyPair)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := g.Tally(id, proof, uint64(result)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := NewKeyring().KeyPairFor(g, oldID); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("expected error %v, got %v", ErrUnknownKey, err)
+	}
+	mismatched := new(crypto.KeyPair)
+	mismatched.Pk.Set(&oldKeyPair.Pk)
+	mismatched.Sk.Set(&newKeyPair.Sk)
+	if err := keyring.Add(mismatched); err == nil {
+		t.Fatal("successfully added a mismatched key pair")
+	}
+}
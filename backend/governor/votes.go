@@ -0,0 +1,87 @@
+package governor
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// checkpoint records the value of a quantity from a given timepoint on.
+type checkpoint struct {
+	timepoint uint64
+	value     uint64
+}
+
+// checkpoints is the history of a quantity, sorted by timepoint, as in
+// OpenZeppelin Checkpoints library.
+type checkpoints []checkpoint
+
+// push sets the value of the quantity from timepoint on. timepoint must not
+// be lower than the one of the latest checkpoint.
+func (c *checkpoints) push(timepoint, value uint64) {
+	if n := len(*c); n > 0 && (*c)[n-1].timepoint == timepoint {
+		(*c)[n-1].value = value
+		return
+	}
+	*c = append(*c, checkpoint{timepoint: timepoint, value: value})
+}
+
+// latest returns the current value of the quantity.
+func (c checkpoints) latest() uint64 {
+	if len(c) == 0 {
+		return 0
+	}
+	return c[len(c)-1].value
+}
+
+// at returns the value of the quantity at timepoint.
+func (c checkpoints) at(timepoint uint64) uint64 {
+	// index of the first checkpoint strictly after timepoint
+	i := sort.Search(len(c), func(i int) bool { return c[i].timepoint > timepoint })
+	if i == 0 {
+		return 0
+	}
+	return c[i-1].value
+}
+
+// votes tracks the voting power of each account over time, playing the role
+// of the IVotes token of the Governor.
+type votes struct {
+	accounts    map[common.Address]*checkpoints
+	totalSupply checkpoints
+}
+
+func newVotes() *votes {
+	return &votes{accounts: make(map[common.Address]*checkpoints)}
+}
+
+func (v *votes) set(timepoint uint64, account common.Address, value uint64) {
+	c, ok := v.accounts[account]
+	if !ok {
+		c = new(checkpoints)
+		v.accounts[account] = c
+	}
+	total := v.totalSupply.latest() - c.latest() + value
+	c.push(timepoint, value)
+	v.totalSupply.push(timepoint, total)
+}
+
+func (v *votes) get(account common.Address) uint64 {
+	c, ok := v.accounts[account]
+	if !ok {
+		return 0
+	}
+	return c.latest()
+}
+
+func (v *votes) getPast(account common.Address, timepoint uint64) uint64 {
+	c, ok := v.accounts[account]
+	if !ok {
+		return 0
+	}
+	return c.at(timepoint)
+}
+
+func (v *votes) getPastTotalSupply(timepoint uint64) uint64 {
+	return v.totalSupply.at(timepoint)
+}
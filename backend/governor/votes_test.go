@@ -0,0 +1,41 @@
+package governor
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestVotesCheckpoints(t *testing.T) {
+	alice := common.HexToAddress("0x01")
+	bob := common.HexToAddress("0x02")
+	v := newVotes()
+	v.set(2, alice, 10)
+	v.set(2, bob, 5)
+	v.set(5, alice, 3)
+	v.set(5, alice, 4)
+	v.set(8, bob, 0)
+
+	tests := map[string]struct {
+		got      uint64
+		expected uint64
+	}{
+		"before first checkpoint":    {v.getPast(alice, 1), 0},
+		"at first checkpoint":        {v.getPast(alice, 2), 10},
+		"between checkpoints":        {v.getPast(alice, 4), 10},
+		"overwritten checkpoint":     {v.getPast(alice, 5), 4},
+		"after last checkpoint":      {v.getPast(alice, 100), 4},
+		"unknown account":            {v.getPast(common.HexToAddress("0x03"), 5), 0},
+		"total supply at start":      {v.getPastTotalSupply(2), 15},
+		"total supply after update":  {v.getPastTotalSupply(6), 9},
+		"total supply after removal": {v.getPastTotalSupply(8), 4},
+		"latest votes":               {v.get(bob), 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tc.got != tc.expected {
+				t.Fatalf("expected: %d, got: %d", tc.expected, tc.got)
+			}
+		})
+	}
+}
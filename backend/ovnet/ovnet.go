@@ -0,0 +1,284 @@
+// Package ovnet implements the self-tallying voting protocol of
+// [Open Vote Network], which needs no tallying authority. It is meant for
+// small committees, such as the signers of a multisig or a council, where
+// every member is expected to take part in every round.
+//
+// The protocol runs in two rounds. In the first one, each voter i publishes
+// an ephemeral public key X_i = x_i*g, together with a proof of knowledge of
+// x_i. In the second one, each voter publishes the ballot
+//
+//	B_i = x_i*Y_i + v_i*g,  where Y_i = sum_{j<i} X_j - sum_{j>i} X_j,
+//
+// together with a proof that v_i is either 0 or 1. The blinding keys Y_i
+// are such that sum_i x_i*Y_i = 0, so once all the ballots have been cast
+// anyone can compute sum_i B_i = (sum_i v_i)*g, and recover the tally with
+// crypto.Decode.
+//
+// A ballot B_i is an ElGamal encryption (X_i, B_i) of v_i under public key
+// Y_i with randomness x_i, so the proofs of this package are those of
+// package crypto: crypto.ProofSkKnowledge for the keys,
+// crypto.ProofVoteWellFormedness for the ballots, and
+// crypto.ProofCorrectDecryption for the recovery shares described below.
+//
+// If some voters register but do not cast a ballot, the tally cannot be
+// computed. In that case, the voters who did cast a ballot run a recovery
+// round, in which each one of them publishes a share which cancels the
+// blinding terms due to the missing voters. Note that the protocol is not
+// fair: the last voter can compute the tally before casting their ballot,
+// and the voters who take part in a recovery round learn the partial tally
+// of the others before publishing their share.
+//
+// [Open Vote Network]: https://eprint.iacr.org/2016/1147.pdf
+package ovnet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+)
+
+// MinVoters is the minimum number of registered voters. With a single
+// voter, the blinding key would be zero and the ballot would reveal the
+// vote.
+const MinVoters = 2
+
+// Registration is the message published by a voter in the first round: an
+// ephemeral public key and a proof of knowledge of its secret key.
+type Registration struct {
+	Pk    arith.CurvePoint        `json:"pk"`
+	Proof crypto.ProofSkKnowledge `json:"proof"`
+}
+
+// Ballot is the message published by a voter in the second round: a vote
+// blinded by the secret key of the voter and by the keys of the others,
+// together with a proof that the vote is either 0 or 1.
+type Ballot struct {
+	Blinded arith.CurvePoint               `json:"blinded"`
+	Proof   crypto.ProofVoteWellFormedness `json:"proof"`
+}
+
+// RecoveryShare is the message published by a voter in the recovery
+// round, which cancels the blinding terms due to the voters who dropped
+// out, together with a proof that it is built with the secret key of the
+// voter.
+type RecoveryShare struct {
+	Share arith.CurvePoint              `json:"share"`
+	Proof crypto.ProofCorrectDecryption `json:"proof"`
+}
+
+// Voter holds the ephemeral key pair of a voter.
+type Voter struct {
+	keyPair *crypto.KeyPair
+}
+
+// NewVoter generates the ephemeral key pair of a voter, and returns the
+// voter together with their registration.
+func NewVoter(r io.Reader) (*Voter, *Registration, error) {
+	keyPair, proof, err := crypto.NewKeyPairWithProof(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	reg := new(Registration)
+	reg.Pk.Set(&keyPair.Pk)
+	reg.Proof.Set(proof)
+	return &Voter{keyPair: keyPair}, reg, nil
+}
+
+// Pk returns the ephemeral public key of the voter.
+func (v *Voter) Pk() *arith.CurvePoint {
+	return new(arith.CurvePoint).Set(&v.keyPair.Pk)
+}
+
+// Destroy wipes the ephemeral secret key of the voter from memory. The
+// voter should not be used afterwards.
+func (v *Voter) Destroy() {
+	v.keyPair.Destroy()
+}
+
+// VerifyRegistration verifies the proof of knowledge of a registration.
+func VerifyRegistration(reg *Registration) error {
+	if reg == nil {
+		return errors.New("missing registration")
+	}
+	return crypto.VerifySkKnowledge(&reg.Proof, &reg.Pk)
+}
+
+// BlindingKey returns the blinding key Y_i of the voter at position i of
+// the registered keys, i.e. the sum of the keys before i minus the sum of
+// the keys after i.
+func BlindingKey(keys []arith.CurvePoint, i int) (*arith.CurvePoint, error) {
+	return partialBlindingKey(keys, i, nil)
+}
+
+// CastBallot blinds vote, which should be 0 or 1, for the voter at
+// position i of the registered keys, and proves it well-formed.
+func (v *Voter) CastBallot(r io.Reader, keys []arith.CurvePoint, i int, vote crypto.Vote) (*Ballot, error) {
+	if vote != crypto.No && vote != crypto.Yes {
+		return nil, errors.New("vote should be 0 or 1")
+	}
+	if err := v.checkPosition(keys, i); err != nil {
+		return nil, err
+	}
+	y, err := BlindingKey(keys, i)
+	if err != nil {
+		return nil, err
+	}
+	encryptedVote := new(crypto.EncryptedVote)
+	encryptedVote.A.Set(&v.keyPair.Pk)
+	encryptedVote.B.ScalarMultSecret(y, &v.keyPair.Sk)
+	encryptedVote.B.Add(&encryptedVote.B, new(arith.CurvePoint).ScalarBaseMult(new(arith.Scalar).SetInt64(int64(vote))))
+	proof, err := crypto.ProveVoteWellFormedness(r, encryptedVote, vote, &v.keyPair.Sk, y)
+	if err != nil {
+		return nil, err
+	}
+	ballot := new(Ballot)
+	ballot.Blinded.Set(&encryptedVote.B)
+	ballot.Proof.Set(proof)
+	return ballot, nil
+}
+
+// VerifyBallot verifies the ballot of the voter at position i of the
+// registered keys.
+func VerifyBallot(ballot *Ballot, keys []arith.CurvePoint, i int) error {
+	if ballot == nil {
+		return errors.New("missing ballot")
+	}
+	y, err := BlindingKey(keys, i)
+	if err != nil {
+		return err
+	}
+	encryptedVote := new(crypto.EncryptedVote)
+	encryptedVote.A.Set(&keys[i])
+	encryptedVote.B.Set(&ballot.Blinded)
+	return crypto.VerifyVoteWellFormedness(&ballot.Proof, encryptedVote, y)
+}
+
+// Recover computes the recovery share of the voter at position i of the
+// registered keys, who cast a ballot, when the voters at the positions in
+// dropped did not.
+func (v *Voter) Recover(r io.Reader, keys []arith.CurvePoint, i int, dropped []int) (*RecoveryShare, error) {
+	if err := v.checkPosition(keys, i); err != nil {
+		return nil, err
+	}
+	z, err := recoveryKey(keys, i, dropped)
+	if err != nil {
+		return nil, err
+	}
+	// the share is x_i*z, which is proven like the decryption of (z, x_i*z)
+	// to 0 under public key X_i
+	encrypted := new(crypto.EncryptedVote)
+	encrypted.A.Set(z)
+	encrypted.B.ScalarMultSecret(z, &v.keyPair.Sk)
+	proof, err := crypto.ProveCorrectDecryption(r, encrypted, v.keyPair)
+	if err != nil {
+		return nil, err
+	}
+	share := new(RecoveryShare)
+	share.Share.Set(&encrypted.B)
+	share.Proof.Set(proof)
+	return share, nil
+}
+
+// VerifyRecoveryShare verifies the recovery share of the voter at position
+// i of the registered keys, when the voters at the positions in dropped did
+// not cast a ballot.
+func VerifyRecoveryShare(share *RecoveryShare, keys []arith.CurvePoint, i int, dropped []int) error {
+	if share == nil {
+		return errors.New("missing recovery share")
+	}
+	z, err := recoveryKey(keys, i, dropped)
+	if err != nil {
+		return err
+	}
+	encrypted := new(crypto.EncryptedVote)
+	encrypted.A.Set(z)
+	encrypted.B.Set(&share.Share)
+	return crypto.VerifyCorrectDecryption(&share.Proof, encrypted, crypto.No, &keys[i])
+}
+
+// Tally computes the number of yes votes from the blinded votes of the
+// ballots cast and, if some voters dropped out, from the recovery shares
+// of all the voters who cast a ballot. The inputs should have been
+// verified.
+func Tally(blinded []arith.CurvePoint, shares []arith.CurvePoint) (uint64, error) {
+	sum := new(arith.CurvePoint).ScalarBaseMult(new(arith.Scalar))
+	for i := range blinded {
+		sum.Add(sum, &blinded[i])
+	}
+	for i := range shares {
+		sum.Add(sum, &shares[i])
+	}
+	tally, err := crypto.Decode(sum, int64(len(blinded)))
+	if err != nil {
+		return 0, errors.New("tally out of range: missing ballots or recovery shares")
+	}
+	return uint64(tally), nil
+}
+
+func (v *Voter) checkPosition(keys []arith.CurvePoint, i int) error {
+	if i < 0 || i >= len(keys) {
+		return fmt.Errorf("voter index %d out of range", i)
+	}
+	if !keys[i].Equal(&v.keyPair.Pk) {
+		return fmt.Errorf("key at index %d is not the key of the voter", i)
+	}
+	return nil
+}
+
+// recoveryKey returns the key z_i such that x_i*z_i cancels the blinding
+// terms of voter i due to the voters in dropped: the sum of the keys of the
+// dropped voters after i minus the sum of those before i.
+func recoveryKey(keys []arith.CurvePoint, i int, dropped []int) (*arith.CurvePoint, error) {
+	if len(dropped) == 0 {
+		return nil, errors.New("no voter dropped out")
+	}
+	isDropped := make(map[int]bool, len(dropped))
+	for _, j := range dropped {
+		if j < 0 || j >= len(keys) {
+			return nil, fmt.Errorf("voter index %d out of range", j)
+		}
+		if isDropped[j] {
+			return nil, fmt.Errorf("voter %d dropped out twice", j)
+		}
+		isDropped[j] = true
+	}
+	if isDropped[i] {
+		return nil, fmt.Errorf("voter %d dropped out", i)
+	}
+	z, err := partialBlindingKey(keys, i, isDropped)
+	if err != nil {
+		return nil, err
+	}
+	return z.Neg(z), nil
+}
+
+// partialBlindingKey returns the blinding key of voter i restricted to the
+// voters j for which include[j] is true, or to all voters if include is
+// nil.
+func partialBlindingKey(keys []arith.CurvePoint, i int, include map[int]bool) (*arith.CurvePoint, error) {
+	if len(keys) < MinVoters {
+		return nil, fmt.Errorf("at least %d voters should be registered", MinVoters)
+	}
+	if i < 0 || i >= len(keys) {
+		return nil, fmt.Errorf("voter index %d out of range", i)
+	}
+	y := new(arith.CurvePoint).ScalarBaseMult(new(arith.Scalar))
+	neg := new(arith.CurvePoint)
+	for j := range keys {
+		if j == i || (include != nil && !include[j]) {
+			continue
+		}
+		if !keys[j].IsValid() {
+			return nil, fmt.Errorf("invalid key at index %d", j)
+		}
+		if j < i {
+			y.Add(y, &keys[j])
+		} else {
+			y.Add(y, neg.Neg(&keys[j]))
+		}
+	}
+	return y, nil
+}
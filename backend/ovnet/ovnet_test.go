@@ -0,0 +1,174 @@
+package ovnet
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+)
+
+func TestSelfTallying(t *testing.T) {
+	tests := map[string]struct {
+		votes   []crypto.Vote
+		dropped []int
+	}{
+		"two voters":        {votes: []crypto.Vote{crypto.Yes, crypto.No}},
+		"all yes":           {votes: []crypto.Vote{crypto.Yes, crypto.Yes, crypto.Yes}},
+		"all no":            {votes: []crypto.Vote{crypto.No, crypto.No, crypto.No}},
+		"mixed":             {votes: []crypto.Vote{crypto.Yes, crypto.No, crypto.Yes, crypto.Yes, crypto.No}},
+		"first dropped":     {votes: []crypto.Vote{crypto.Yes, crypto.No, crypto.Yes, crypto.Yes}, dropped: []int{0}},
+		"last dropped":      {votes: []crypto.Vote{crypto.Yes, crypto.No, crypto.Yes, crypto.Yes}, dropped: []int{3}},
+		"several dropped":   {votes: []crypto.Vote{crypto.Yes, crypto.No, crypto.Yes, crypto.Yes, crypto.Yes}, dropped: []int{4, 1, 2}},
+		"one voter remains": {votes: []crypto.Vote{crypto.Yes, crypto.Yes, crypto.No}, dropped: []int{0, 2}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			voters, keys := registerVoters(t, len(tc.votes))
+			isDropped := make(map[int]bool)
+			for _, j := range tc.dropped {
+				isDropped[j] = true
+			}
+
+			var blinded []arith.CurvePoint
+			var expected uint64
+			for i, voter := range voters {
+				if isDropped[i] {
+					continue
+				}
+				ballot, err := voter.CastBallot(rand.Reader, keys, i, tc.votes[i])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := VerifyBallot(ballot, keys, i); err != nil {
+					t.Fatal(err)
+				}
+				blinded = append(blinded, ballot.Blinded)
+				expected += uint64(tc.votes[i])
+			}
+
+			var shares []arith.CurvePoint
+			if len(tc.dropped) > 0 {
+				if _, err := Tally(blinded, nil); err == nil {
+					t.Fatal("computed the tally without recovery shares")
+				}
+				for i, voter := range voters {
+					if isDropped[i] {
+						continue
+					}
+					share, err := voter.Recover(rand.Reader, keys, i, tc.dropped)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := VerifyRecoveryShare(share, keys, i, tc.dropped); err != nil {
+						t.Fatal(err)
+					}
+					shares = append(shares, share.Share)
+				}
+			}
+
+			got, err := Tally(blinded, shares)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != expected {
+				t.Fatalf("expected: %d, got: %d", expected, got)
+			}
+		})
+	}
+}
+
+func TestVerifyBallotWrongPosition(t *testing.T) {
+	voters, keys := registerVoters(t, 3)
+	ballot, err := voters[1].CastBallot(rand.Reader, keys, 1, crypto.Yes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []int{0, 2} {
+		if err := VerifyBallot(ballot, keys, i); err == nil {
+			t.Fatalf("ballot of voter 1 verified at position %d", i)
+		}
+	}
+	if _, err := voters[1].CastBallot(rand.Reader, keys, 0, crypto.Yes); err == nil {
+		t.Fatal("cast a ballot at the position of another voter")
+	}
+}
+
+func TestVerifyBallotInvalidVote(t *testing.T) {
+	voters, keys := registerVoters(t, 3)
+	ballot, err := voters[0].CastBallot(rand.Reader, keys, 0, crypto.No)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// turn the blinded vote into a vote for 2
+	g := new(arith.CurvePoint).ScalarBaseMult(new(arith.Scalar).SetInt64(2))
+	ballot.Blinded.Add(&ballot.Blinded, g)
+	if err := VerifyBallot(ballot, keys, 0); err == nil {
+		t.Fatal("tampered ballot verified")
+	}
+	if _, err := voters[0].CastBallot(rand.Reader, keys, 0, 2); err == nil {
+		t.Fatal("cast a ballot for 2")
+	}
+}
+
+func TestVerifyRecoveryShareWrongDropped(t *testing.T) {
+	voters, keys := registerVoters(t, 4)
+	share, err := voters[0].Recover(rand.Reader, keys, 0, []int{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string][]int{
+		"other voter dropped": {3},
+		"more voters dropped": {2, 3},
+		"share owner dropped": {0, 2},
+		"duplicate":           {2, 2},
+		"out of range":        {4},
+		"nobody dropped":      nil,
+	}
+	for name, dropped := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := VerifyRecoveryShare(share, keys, 0, dropped); err == nil {
+				t.Fatal("recovery share verified")
+			}
+		})
+	}
+}
+
+func TestBlindingKeysCancel(t *testing.T) {
+	voters, keys := registerVoters(t, 5)
+	sum := new(arith.CurvePoint).ScalarBaseMult(new(arith.Scalar))
+	for i, voter := range voters {
+		ballot, err := voter.CastBallot(rand.Reader, keys, i, crypto.No)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum.Add(sum, &ballot.Blinded)
+	}
+	zero := new(arith.CurvePoint).ScalarBaseMult(new(arith.Scalar))
+	if !sum.Equal(zero) {
+		t.Fatal("blinding terms do not cancel out")
+	}
+	if _, err := BlindingKey(keys[:1], 0); err == nil {
+		t.Fatal("computed the blinding key of a single voter")
+	}
+}
+
+func registerVoters(t *testing.T, n int) ([]*Voter, []arith.CurvePoint) {
+	t.Helper()
+	voters := make([]*Voter, n)
+	keys := make([]arith.CurvePoint, n)
+	for i := range voters {
+		voter, reg, err := NewVoter(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifyRegistration(reg); err != nil {
+			t.Fatal(err)
+		}
+		voters[i] = voter
+		keys[i].Set(&reg.Pk)
+		t.Cleanup(voter.Destroy)
+	}
+	return voters, keys
+}
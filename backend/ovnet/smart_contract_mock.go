@@ -0,0 +1,225 @@
+package ovnet
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+// SmartContractMock mimics a smart contract running the Open Vote Network
+// protocol, analogous to crypto.SmartContractMock. It verifies every
+// message, and computes the tally as soon as it can. It is safe for
+// concurrent use.
+type SmartContractMock struct {
+	mu      sync.RWMutex
+	keys    []arith.CurvePoint
+	ballots []*arith.CurvePoint
+	dropped []int
+	shares  []*arith.CurvePoint
+	result  uint64
+	status  Status
+}
+
+type Status int
+
+const (
+	Registering Status = iota
+	Voting
+	Recovering
+	Fini
+)
+
+func NewSmartContractMock() *SmartContractMock {
+	return &SmartContractMock{status: Registering}
+}
+
+// Register verifies the registration of a voter, and returns the position
+// of the voter, which is needed to cast a ballot.
+func (sc *SmartContractMock) Register(reg *Registration) (int, error) {
+	if err := VerifyRegistration(reg); err != nil {
+		return -1, err
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Registering {
+		return -1, fmt.Errorf("wrong status")
+	}
+	for i := range sc.keys {
+		if sc.keys[i].Equal(&reg.Pk) {
+			return -1, fmt.Errorf("key already registered at index %d", i)
+		}
+	}
+	sc.keys = append(sc.keys, *new(arith.CurvePoint).Set(&reg.Pk))
+	return len(sc.keys) - 1, nil
+}
+
+func (sc *SmartContractMock) StartVotingPhase() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Registering {
+		return fmt.Errorf("wrong status")
+	}
+	if len(sc.keys) < MinVoters {
+		return fmt.Errorf("at least %d voters should be registered", MinVoters)
+	}
+	sc.ballots = make([]*arith.CurvePoint, len(sc.keys))
+	sc.status = Voting
+	return nil
+}
+
+// CastVote verifies and records the ballot of the voter at position i. The
+// tally is computed once all the registered voters have cast a ballot.
+func (sc *SmartContractMock) CastVote(i int, ballot *Ballot) error {
+	// the keys cannot change after registration
+	sc.mu.RLock()
+	if sc.status != Voting {
+		sc.mu.RUnlock()
+		return fmt.Errorf("wrong status")
+	}
+	keys := sc.keys
+	sc.mu.RUnlock()
+	if err := VerifyBallot(ballot, keys, i); err != nil {
+		return err
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Voting {
+		return fmt.Errorf("wrong status")
+	}
+	if sc.ballots[i] != nil {
+		return fmt.Errorf("voter %d already voted", i)
+	}
+	sc.ballots[i] = new(arith.CurvePoint).Set(&ballot.Blinded)
+	for _, b := range sc.ballots {
+		if b == nil {
+			return nil
+		}
+	}
+	return sc.tally()
+}
+
+// StopVotingPhase ends the voting phase before all the registered voters
+// have cast a ballot, and starts the recovery round, in which each voter
+// who did cast a ballot should submit a recovery share.
+func (sc *SmartContractMock) StopVotingPhase() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Voting {
+		return fmt.Errorf("wrong status")
+	}
+	for i, b := range sc.ballots {
+		if b == nil {
+			sc.dropped = append(sc.dropped, i)
+		}
+	}
+	sc.shares = make([]*arith.CurvePoint, len(sc.keys))
+	if len(sc.dropped) == len(sc.keys) {
+		// nobody voted, so there is nothing to recover
+		sc.result = 0
+		sc.status = Fini
+		return nil
+	}
+	sc.status = Recovering
+	return nil
+}
+
+// SubmitRecoveryShare verifies and records the recovery share of the voter
+// at position i. The tally is computed once all the voters who cast a
+// ballot have submitted their share.
+func (sc *SmartContractMock) SubmitRecoveryShare(i int, share *RecoveryShare) error {
+	sc.mu.RLock()
+	if sc.status != Recovering {
+		sc.mu.RUnlock()
+		return fmt.Errorf("wrong status")
+	}
+	keys, dropped := sc.keys, sc.dropped
+	sc.mu.RUnlock()
+	if err := VerifyRecoveryShare(share, keys, i, dropped); err != nil {
+		return err
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.status != Recovering {
+		return fmt.Errorf("wrong status")
+	}
+	if sc.shares[i] != nil {
+		return fmt.Errorf("voter %d already submitted a recovery share", i)
+	}
+	sc.shares[i] = new(arith.CurvePoint).Set(&share.Share)
+	for j, b := range sc.ballots {
+		if b != nil && sc.shares[j] == nil {
+			return nil
+		}
+	}
+	return sc.tally()
+}
+
+// tally computes the result from the ballots and the recovery shares.
+func (sc *SmartContractMock) tally() error {
+	var blinded, shares []arith.CurvePoint
+	for i, b := range sc.ballots {
+		if b == nil {
+			continue
+		}
+		blinded = append(blinded, *b)
+		if sc.shares != nil {
+			shares = append(shares, *sc.shares[i])
+		}
+	}
+	result, err := Tally(blinded, shares)
+	if err != nil {
+		return err
+	}
+	sc.result = result
+	sc.status = Fini
+	return nil
+}
+
+// GetKeys returns the registered keys, indexed by the position of the
+// voters.
+func (sc *SmartContractMock) GetKeys() []arith.CurvePoint {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	keys := make([]arith.CurvePoint, len(sc.keys))
+	for i := range sc.keys {
+		keys[i].Set(&sc.keys[i])
+	}
+	return keys
+}
+
+// GetDropped returns the positions of the voters who did not cast a
+// ballot, once the recovery round has started.
+func (sc *SmartContractMock) GetDropped() ([]int, error) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	if sc.status != Recovering && !(sc.status == Fini && sc.shares != nil) {
+		return nil, fmt.Errorf("wrong status")
+	}
+	return append([]int(nil), sc.dropped...), nil
+}
+
+// HasVoted reports whether the voter at position i has cast a ballot.
+func (sc *SmartContractMock) HasVoted(i int) bool {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return i >= 0 && i < len(sc.ballots) && sc.ballots[i] != nil
+}
+
+func (sc *SmartContractMock) GetResult() (uint64, error) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	if sc.status != Fini {
+		return 0, fmt.Errorf("wrong status")
+	}
+	return sc.result, nil
+}
+
+// GetStatus returns the current status of the contract.
+func (sc *SmartContractMock) GetStatus() Status {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.status
+}
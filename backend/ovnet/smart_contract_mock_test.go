@@ -0,0 +1,207 @@
+package ovnet
+
+import (
+	"crypto/rand"
+	"sync"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+)
+
+func TestSmartContractMock(t *testing.T) {
+	tests := map[string]struct {
+		votes   []crypto.Vote
+		dropped map[int]bool
+	}{
+		"everybody votes": {
+			votes: []crypto.Vote{crypto.Yes, crypto.No, crypto.Yes, crypto.Yes, crypto.No, crypto.Yes},
+		},
+		"some voters drop out": {
+			votes:   []crypto.Vote{crypto.Yes, crypto.No, crypto.Yes, crypto.Yes, crypto.No, crypto.Yes},
+			dropped: map[int]bool{1: true, 3: true},
+		},
+		"nobody votes": {
+			votes:   []crypto.Vote{crypto.Yes, crypto.Yes},
+			dropped: map[int]bool{0: true, 1: true},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sc := NewSmartContractMock()
+			voters := make([]*Voter, len(tc.votes))
+			for i := range voters {
+				voter, reg, err := NewVoter(rand.Reader)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer voter.Destroy()
+				index, err := sc.Register(reg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if index != i {
+					t.Fatalf("expected index %d, got %d", i, index)
+				}
+				voters[i] = voter
+			}
+			if err := sc.StartVotingPhase(); err != nil {
+				t.Fatal(err)
+			}
+
+			keys := sc.GetKeys()
+			var expected uint64
+			var wg sync.WaitGroup
+			errs := make(chan error, len(voters))
+			for i, voter := range voters {
+				if tc.dropped[i] {
+					continue
+				}
+				expected += uint64(tc.votes[i])
+				wg.Add(1)
+				go func(i int, voter *Voter) {
+					defer wg.Done()
+					ballot, err := voter.CastBallot(rand.Reader, keys, i, tc.votes[i])
+					if err == nil {
+						err = sc.CastVote(i, ballot)
+					}
+					errs <- err
+				}(i, voter)
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if len(tc.dropped) > 0 {
+				if sc.GetStatus() != Voting {
+					t.Fatalf("expected status %d, got %d", Voting, sc.GetStatus())
+				}
+				if err := sc.StopVotingPhase(); err != nil {
+					t.Fatal(err)
+				}
+				if len(tc.dropped) < len(voters) {
+					dropped, err := sc.GetDropped()
+					if err != nil {
+						t.Fatal(err)
+					}
+					if len(dropped) != len(tc.dropped) {
+						t.Fatalf("expected %d dropped voters, got %v", len(tc.dropped), dropped)
+					}
+					for i, voter := range voters {
+						if !sc.HasVoted(i) {
+							continue
+						}
+						share, err := voter.Recover(rand.Reader, keys, i, dropped)
+						if err != nil {
+							t.Fatal(err)
+						}
+						if err := sc.SubmitRecoveryShare(i, share); err != nil {
+							t.Fatal(err)
+						}
+					}
+				}
+			}
+
+			got, err := sc.GetResult()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != expected {
+				t.Fatalf("expected: %d, got: %d", expected, got)
+			}
+		})
+	}
+}
+
+func TestSmartContractMockRejects(t *testing.T) {
+	sc := NewSmartContractMock()
+	voters := make([]*Voter, 3)
+	regs := make([]*Registration, 3)
+	for i := range voters {
+		voter, reg, err := NewVoter(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer voter.Destroy()
+		voters[i], regs[i] = voter, reg
+	}
+
+	if _, err := sc.Register(regs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.StartVotingPhase(); err == nil {
+		t.Fatal("started voting with a single voter")
+	}
+	if _, err := sc.Register(regs[0]); err == nil {
+		t.Fatal("registered the same key twice")
+	}
+	invalid := *regs[1]
+	invalid.Proof.S.Set(&regs[2].Proof.S)
+	if _, err := sc.Register(&invalid); err == nil {
+		t.Fatal("registered a key with an invalid proof")
+	}
+	for _, reg := range regs[1:] {
+		if _, err := sc.Register(reg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sc.StopVotingPhase(); err == nil {
+		t.Fatal("stopped voting before it started")
+	}
+	if err := sc.StartVotingPhase(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sc.Register(regs[0]); err == nil {
+		t.Fatal("registered during voting")
+	}
+
+	keys := sc.GetKeys()
+	ballot, err := voters[0].CastBallot(rand.Reader, keys, 0, crypto.Yes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.CastVote(1, ballot); err == nil {
+		t.Fatal("cast a ballot at the wrong position")
+	}
+	if err := sc.CastVote(0, ballot); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.CastVote(0, ballot); err == nil {
+		t.Fatal("voted twice")
+	}
+	if _, err := sc.GetResult(); err == nil {
+		t.Fatal("got a result before all the ballots were cast")
+	}
+
+	if err := sc.StopVotingPhase(); err != nil {
+		t.Fatal(err)
+	}
+	dropped, err := sc.GetDropped()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := voters[1].Recover(rand.Reader, keys, 1, dropped); err == nil {
+		t.Fatal("a voter who dropped out computed a recovery share")
+	}
+	share, err := voters[0].Recover(rand.Reader, keys, 0, []int{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.SubmitRecoveryShare(0, share); err == nil {
+		t.Fatal("submitted a recovery share for the wrong dropped voters")
+	}
+	share, err = voters[0].Recover(rand.Reader, keys, 0, dropped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.SubmitRecoveryShare(0, share); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := sc.GetResult(); err != nil || got != 1 {
+		t.Fatalf("expected result 1, got %d, %v", got, err)
+	}
+}
@@ -0,0 +1,1239 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: rpc/evotingpb/evoting.proto
+
+package evotingpb
+
+import (
+	cryptopb "github.com/HorizenLabs/e-voting-poc/backend/crypto/cryptopb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type NewKeyPairWithProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NewKeyPairWithProofRequest) Reset() {
+	*x = NewKeyPairWithProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewKeyPairWithProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewKeyPairWithProofRequest) ProtoMessage() {}
+
+func (x *NewKeyPairWithProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewKeyPairWithProofRequest.ProtoReflect.Descriptor instead.
+func (*NewKeyPairWithProofRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{0}
+}
+
+type NewKeyPairWithProofResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyPair *cryptopb.KeyPair          `protobuf:"bytes,1,opt,name=key_pair,json=keyPair,proto3" json:"key_pair,omitempty"`
+	Proof   *cryptopb.ProofSkKnowledge `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *NewKeyPairWithProofResponse) Reset() {
+	*x = NewKeyPairWithProofResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewKeyPairWithProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewKeyPairWithProofResponse) ProtoMessage() {}
+
+func (x *NewKeyPairWithProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewKeyPairWithProofResponse.ProtoReflect.Descriptor instead.
+func (*NewKeyPairWithProofResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NewKeyPairWithProofResponse) GetKeyPair() *cryptopb.KeyPair {
+	if x != nil {
+		return x.KeyPair
+	}
+	return nil
+}
+
+func (x *NewKeyPairWithProofResponse) GetProof() *cryptopb.ProofSkKnowledge {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type EncryptVoteWithProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 0 (No) or 1 (Yes).
+	Vote int64                `protobuf:"varint,1,opt,name=vote,proto3" json:"vote,omitempty"`
+	Pk   *cryptopb.CurvePoint `protobuf:"bytes,2,opt,name=pk,proto3" json:"pk,omitempty"`
+}
+
+func (x *EncryptVoteWithProofRequest) Reset() {
+	*x = EncryptVoteWithProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncryptVoteWithProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptVoteWithProofRequest) ProtoMessage() {}
+
+func (x *EncryptVoteWithProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptVoteWithProofRequest.ProtoReflect.Descriptor instead.
+func (*EncryptVoteWithProofRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EncryptVoteWithProofRequest) GetVote() int64 {
+	if x != nil {
+		return x.Vote
+	}
+	return 0
+}
+
+func (x *EncryptVoteWithProofRequest) GetPk() *cryptopb.CurvePoint {
+	if x != nil {
+		return x.Pk
+	}
+	return nil
+}
+
+type EncryptVoteWithProofResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EncryptedVote *cryptopb.EncryptedVote           `protobuf:"bytes,1,opt,name=encrypted_vote,json=encryptedVote,proto3" json:"encrypted_vote,omitempty"`
+	Proof         *cryptopb.ProofVoteWellFormedness `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *EncryptVoteWithProofResponse) Reset() {
+	*x = EncryptVoteWithProofResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncryptVoteWithProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptVoteWithProofResponse) ProtoMessage() {}
+
+func (x *EncryptVoteWithProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptVoteWithProofResponse.ProtoReflect.Descriptor instead.
+func (*EncryptVoteWithProofResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EncryptVoteWithProofResponse) GetEncryptedVote() *cryptopb.EncryptedVote {
+	if x != nil {
+		return x.EncryptedVote
+	}
+	return nil
+}
+
+func (x *EncryptVoteWithProofResponse) GetProof() *cryptopb.ProofVoteWellFormedness {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type DecryptTallyWithProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tally *cryptopb.EncryptedVote `protobuf:"bytes,1,opt,name=tally,proto3" json:"tally,omitempty"`
+	// An upper bound on the decrypted tally, e.g. the total weight cast.
+	N       int64             `protobuf:"varint,2,opt,name=n,proto3" json:"n,omitempty"`
+	KeyPair *cryptopb.KeyPair `protobuf:"bytes,3,opt,name=key_pair,json=keyPair,proto3" json:"key_pair,omitempty"`
+}
+
+func (x *DecryptTallyWithProofRequest) Reset() {
+	*x = DecryptTallyWithProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecryptTallyWithProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecryptTallyWithProofRequest) ProtoMessage() {}
+
+func (x *DecryptTallyWithProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecryptTallyWithProofRequest.ProtoReflect.Descriptor instead.
+func (*DecryptTallyWithProofRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DecryptTallyWithProofRequest) GetTally() *cryptopb.EncryptedVote {
+	if x != nil {
+		return x.Tally
+	}
+	return nil
+}
+
+func (x *DecryptTallyWithProofRequest) GetN() int64 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *DecryptTallyWithProofRequest) GetKeyPair() *cryptopb.KeyPair {
+	if x != nil {
+		return x.KeyPair
+	}
+	return nil
+}
+
+type DecryptTallyWithProofResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result int64                            `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	Proof  *cryptopb.ProofCorrectDecryption `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *DecryptTallyWithProofResponse) Reset() {
+	*x = DecryptTallyWithProofResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecryptTallyWithProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecryptTallyWithProofResponse) ProtoMessage() {}
+
+func (x *DecryptTallyWithProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecryptTallyWithProofResponse.ProtoReflect.Descriptor instead.
+func (*DecryptTallyWithProofResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DecryptTallyWithProofResponse) GetResult() int64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *DecryptTallyWithProofResponse) GetProof() *cryptopb.ProofCorrectDecryption {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type VerifySkKnowledgeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proof *cryptopb.ProofSkKnowledge `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	Pk    *cryptopb.CurvePoint       `protobuf:"bytes,2,opt,name=pk,proto3" json:"pk,omitempty"`
+}
+
+func (x *VerifySkKnowledgeRequest) Reset() {
+	*x = VerifySkKnowledgeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifySkKnowledgeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifySkKnowledgeRequest) ProtoMessage() {}
+
+func (x *VerifySkKnowledgeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifySkKnowledgeRequest.ProtoReflect.Descriptor instead.
+func (*VerifySkKnowledgeRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VerifySkKnowledgeRequest) GetProof() *cryptopb.ProofSkKnowledge {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *VerifySkKnowledgeRequest) GetPk() *cryptopb.CurvePoint {
+	if x != nil {
+		return x.Pk
+	}
+	return nil
+}
+
+type VerifyVoteWellFormednessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proof         *cryptopb.ProofVoteWellFormedness `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	EncryptedVote *cryptopb.EncryptedVote           `protobuf:"bytes,2,opt,name=encrypted_vote,json=encryptedVote,proto3" json:"encrypted_vote,omitempty"`
+	Pk            *cryptopb.CurvePoint              `protobuf:"bytes,3,opt,name=pk,proto3" json:"pk,omitempty"`
+}
+
+func (x *VerifyVoteWellFormednessRequest) Reset() {
+	*x = VerifyVoteWellFormednessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyVoteWellFormednessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyVoteWellFormednessRequest) ProtoMessage() {}
+
+func (x *VerifyVoteWellFormednessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyVoteWellFormednessRequest.ProtoReflect.Descriptor instead.
+func (*VerifyVoteWellFormednessRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VerifyVoteWellFormednessRequest) GetProof() *cryptopb.ProofVoteWellFormedness {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *VerifyVoteWellFormednessRequest) GetEncryptedVote() *cryptopb.EncryptedVote {
+	if x != nil {
+		return x.EncryptedVote
+	}
+	return nil
+}
+
+func (x *VerifyVoteWellFormednessRequest) GetPk() *cryptopb.CurvePoint {
+	if x != nil {
+		return x.Pk
+	}
+	return nil
+}
+
+type VerifyCorrectDecryptionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proof  *cryptopb.ProofCorrectDecryption `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	Tally  *cryptopb.EncryptedVote          `protobuf:"bytes,2,opt,name=tally,proto3" json:"tally,omitempty"`
+	Result int64                            `protobuf:"varint,3,opt,name=result,proto3" json:"result,omitempty"`
+	Pk     *cryptopb.CurvePoint             `protobuf:"bytes,4,opt,name=pk,proto3" json:"pk,omitempty"`
+}
+
+func (x *VerifyCorrectDecryptionRequest) Reset() {
+	*x = VerifyCorrectDecryptionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyCorrectDecryptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCorrectDecryptionRequest) ProtoMessage() {}
+
+func (x *VerifyCorrectDecryptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCorrectDecryptionRequest.ProtoReflect.Descriptor instead.
+func (*VerifyCorrectDecryptionRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VerifyCorrectDecryptionRequest) GetProof() *cryptopb.ProofCorrectDecryption {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *VerifyCorrectDecryptionRequest) GetTally() *cryptopb.EncryptedVote {
+	if x != nil {
+		return x.Tally
+	}
+	return nil
+}
+
+func (x *VerifyCorrectDecryptionRequest) GetResult() int64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *VerifyCorrectDecryptionRequest) GetPk() *cryptopb.CurvePoint {
+	if x != nil {
+		return x.Pk
+	}
+	return nil
+}
+
+// VerifyResponse is the result of a proof verification. Malformed requests
+// fail with status INVALID_ARGUMENT instead.
+type VerifyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// The reason of the verification failure, if valid is false.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *VerifyResponse) Reset() {
+	*x = VerifyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyResponse) ProtoMessage() {}
+
+func (x *VerifyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyResponse.ProtoReflect.Descriptor instead.
+func (*VerifyResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VerifyResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AddEncryptedVotesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EncryptedVotes []*cryptopb.EncryptedVote `protobuf:"bytes,1,rep,name=encrypted_votes,json=encryptedVotes,proto3" json:"encrypted_votes,omitempty"`
+}
+
+func (x *AddEncryptedVotesRequest) Reset() {
+	*x = AddEncryptedVotesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddEncryptedVotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddEncryptedVotesRequest) ProtoMessage() {}
+
+func (x *AddEncryptedVotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddEncryptedVotesRequest.ProtoReflect.Descriptor instead.
+func (*AddEncryptedVotesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AddEncryptedVotesRequest) GetEncryptedVotes() []*cryptopb.EncryptedVote {
+	if x != nil {
+		return x.EncryptedVotes
+	}
+	return nil
+}
+
+type AddEncryptedVotesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sum *cryptopb.EncryptedVote `protobuf:"bytes,1,opt,name=sum,proto3" json:"sum,omitempty"`
+}
+
+func (x *AddEncryptedVotesResponse) Reset() {
+	*x = AddEncryptedVotesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddEncryptedVotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddEncryptedVotesResponse) ProtoMessage() {}
+
+func (x *AddEncryptedVotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddEncryptedVotesResponse.ProtoReflect.Descriptor instead.
+func (*AddEncryptedVotesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AddEncryptedVotesResponse) GetSum() *cryptopb.EncryptedVote {
+	if x != nil {
+		return x.Sum
+	}
+	return nil
+}
+
+type ScaleEncryptedVoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EncryptedVote *cryptopb.EncryptedVote `protobuf:"bytes,1,opt,name=encrypted_vote,json=encryptedVote,proto3" json:"encrypted_vote,omitempty"`
+	Weight        uint64                  `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *ScaleEncryptedVoteRequest) Reset() {
+	*x = ScaleEncryptedVoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScaleEncryptedVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScaleEncryptedVoteRequest) ProtoMessage() {}
+
+func (x *ScaleEncryptedVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScaleEncryptedVoteRequest.ProtoReflect.Descriptor instead.
+func (*ScaleEncryptedVoteRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ScaleEncryptedVoteRequest) GetEncryptedVote() *cryptopb.EncryptedVote {
+	if x != nil {
+		return x.EncryptedVote
+	}
+	return nil
+}
+
+func (x *ScaleEncryptedVoteRequest) GetWeight() uint64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type ScaleEncryptedVoteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EncryptedVote *cryptopb.EncryptedVote `protobuf:"bytes,1,opt,name=encrypted_vote,json=encryptedVote,proto3" json:"encrypted_vote,omitempty"`
+}
+
+func (x *ScaleEncryptedVoteResponse) Reset() {
+	*x = ScaleEncryptedVoteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_evotingpb_evoting_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScaleEncryptedVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScaleEncryptedVoteResponse) ProtoMessage() {}
+
+func (x *ScaleEncryptedVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_evotingpb_evoting_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScaleEncryptedVoteResponse.ProtoReflect.Descriptor instead.
+func (*ScaleEncryptedVoteResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_evotingpb_evoting_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ScaleEncryptedVoteResponse) GetEncryptedVote() *cryptopb.EncryptedVote {
+	if x != nil {
+		return x.EncryptedVote
+	}
+	return nil
+}
+
+var File_rpc_evotingpb_evoting_proto protoreflect.FileDescriptor
+
+var file_rpc_evotingpb_evoting_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x72, 0x70, 0x63, 0x2f, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x2f,
+	0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x65,
+	0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x2f, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x70, 0x62, 0x2f, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1c, 0x0a, 0x1a, 0x4e, 0x65, 0x77, 0x4b, 0x65,
+	0x79, 0x50, 0x61, 0x69, 0x72, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x8f, 0x01, 0x0a, 0x1b, 0x4e, 0x65, 0x77, 0x4b, 0x65, 0x79,
+	0x50, 0x61, 0x69, 0x72, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x61, 0x69,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x50,
+	0x61, 0x69, 0x72, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x50, 0x61, 0x69, 0x72, 0x12, 0x39, 0x0a, 0x05,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x65, 0x76,
+	0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x53, 0x6b, 0x4b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65,
+	0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x60, 0x0a, 0x1b, 0x45, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x76, 0x6f, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x02, 0x70, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72, 0x76, 0x65,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x02, 0x70, 0x6b, 0x22, 0xa9, 0x01, 0x0a, 0x1c, 0x45, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0e, 0x65, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64,
+	0x56, 0x6f, 0x74, 0x65, 0x52, 0x0d, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56,
+	0x6f, 0x74, 0x65, 0x12, 0x40, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x56, 0x6f, 0x74, 0x65,
+	0x57, 0x65, 0x6c, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x05,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x9b, 0x01, 0x0a, 0x1c, 0x44, 0x65, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x54, 0x61, 0x6c, 0x6c, 0x79, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x05, 0x74, 0x61, 0x6c, 0x6c, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x74, 0x61, 0x6c, 0x6c, 0x79, 0x12, 0x0c,
+	0x0a, 0x01, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x01, 0x6e, 0x12, 0x35, 0x0a, 0x08,
+	0x6b, 0x65, 0x79, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x69, 0x72, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x50,
+	0x61, 0x69, 0x72, 0x22, 0x78, 0x0a, 0x1d, 0x44, 0x65, 0x63, 0x72, 0x79, 0x70, 0x74, 0x54, 0x61,
+	0x6c, 0x6c, 0x79, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x3f, 0x0a, 0x05,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x65, 0x76,
+	0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x44, 0x65, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x84, 0x01,
+	0x0a, 0x18, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53, 0x6b, 0x4b, 0x6e, 0x6f, 0x77, 0x6c, 0x65,
+	0x64, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x05, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x65, 0x76, 0x6f, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x53, 0x6b, 0x4b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x52, 0x05,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x2d, 0x0a, 0x02, 0x70, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x02, 0x70, 0x6b, 0x22, 0xdb, 0x01, 0x0a, 0x1f, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x56,
+	0x6f, 0x74, 0x65, 0x57, 0x65, 0x6c, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x6e, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x56, 0x6f, 0x74, 0x65, 0x57, 0x65, 0x6c, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x6e,
+	0x65, 0x73, 0x73, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x47, 0x0a, 0x0e, 0x65, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64,
+	0x56, 0x6f, 0x74, 0x65, 0x52, 0x0d, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56,
+	0x6f, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x02, 0x70, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x02,
+	0x70, 0x6b, 0x22, 0xe0, 0x01, 0x0a, 0x1e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x72,
+	0x72, 0x65, 0x63, 0x74, 0x44, 0x65, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x43, 0x6f,
+	0x72, 0x72, 0x65, 0x63, 0x74, 0x44, 0x65, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x36, 0x0a, 0x05, 0x74, 0x61, 0x6c, 0x6c, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x74, 0x61, 0x6c, 0x6c, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2d, 0x0a, 0x02, 0x70, 0x6b, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x02, 0x70, 0x6b, 0x22, 0x3e, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x65, 0x0a, 0x18, 0x41, 0x64, 0x64, 0x45, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x49, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x76,
+	0x6f, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x76, 0x6f,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x0e, 0x65, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x22, 0x4f, 0x0a, 0x19,
+	0x41, 0x64, 0x64, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x03, 0x73, 0x75, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x03, 0x73, 0x75, 0x6d, 0x22, 0x7c, 0x0a,
+	0x19, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56,
+	0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x47, 0x0a, 0x0e, 0x65, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64,
+	0x56, 0x6f, 0x74, 0x65, 0x52, 0x0d, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56,
+	0x6f, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x65, 0x0a, 0x1a, 0x53,
+	0x63, 0x61, 0x6c, 0x65, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0e, 0x65, 0x6e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56,
+	0x6f, 0x74, 0x65, 0x52, 0x0d, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56, 0x6f,
+	0x74, 0x65, 0x32, 0xb6, 0x06, 0x0a, 0x0d, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x66, 0x0a, 0x13, 0x4e, 0x65, 0x77, 0x4b, 0x65, 0x79, 0x50, 0x61,
+	0x69, 0x72, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x26, 0x2e, 0x65, 0x76,
+	0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x77, 0x4b, 0x65, 0x79, 0x50,
+	0x61, 0x69, 0x72, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x65, 0x77, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x69, 0x72, 0x57, 0x69, 0x74, 0x68, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x14,
+	0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x57, 0x69, 0x74, 0x68, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x12, 0x27, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x57, 0x69, 0x74,
+	0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e,
+	0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6c, 0x0a, 0x15, 0x44, 0x65, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x54, 0x61, 0x6c, 0x6c, 0x79, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x12, 0x28, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x54, 0x61, 0x6c, 0x6c, 0x79, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x65, 0x76, 0x6f,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x63, 0x72, 0x79, 0x70, 0x74, 0x54,
+	0x61, 0x6c, 0x6c, 0x79, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x11, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53,
+	0x6b, 0x4b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x12, 0x24, 0x2e, 0x65, 0x76, 0x6f,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53, 0x6b,
+	0x4b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x18,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x56, 0x6f, 0x74, 0x65, 0x57, 0x65, 0x6c, 0x6c, 0x46, 0x6f,
+	0x72, 0x6d, 0x65, 0x64, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x56, 0x6f, 0x74, 0x65,
+	0x57, 0x65, 0x6c, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x61, 0x0a, 0x17, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x72, 0x72, 0x65,
+	0x63, 0x74, 0x44, 0x65, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x2e, 0x65,
+	0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x44, 0x65, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x45, 0x6e, 0x63, 0x72, 0x79,
+	0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x65, 0x76, 0x6f, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64,
+	0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x12, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x45,
+	0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x25, 0x2e, 0x65,
+	0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x45,
+	0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x65, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x56,
+	0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3b, 0x5a, 0x39, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x48, 0x6f, 0x72, 0x69, 0x7a, 0x65,
+	0x6e, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x2d, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x2d, 0x70,
+	0x6f, 0x63, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x65,
+	0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_evotingpb_evoting_proto_rawDescOnce sync.Once
+	file_rpc_evotingpb_evoting_proto_rawDescData = file_rpc_evotingpb_evoting_proto_rawDesc
+)
+
+func file_rpc_evotingpb_evoting_proto_rawDescGZIP() []byte {
+	file_rpc_evotingpb_evoting_proto_rawDescOnce.Do(func() {
+		file_rpc_evotingpb_evoting_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_evotingpb_evoting_proto_rawDescData)
+	})
+	return file_rpc_evotingpb_evoting_proto_rawDescData
+}
+
+var file_rpc_evotingpb_evoting_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_rpc_evotingpb_evoting_proto_goTypes = []interface{}{
+	(*NewKeyPairWithProofRequest)(nil),       // 0: evoting.v1.NewKeyPairWithProofRequest
+	(*NewKeyPairWithProofResponse)(nil),      // 1: evoting.v1.NewKeyPairWithProofResponse
+	(*EncryptVoteWithProofRequest)(nil),      // 2: evoting.v1.EncryptVoteWithProofRequest
+	(*EncryptVoteWithProofResponse)(nil),     // 3: evoting.v1.EncryptVoteWithProofResponse
+	(*DecryptTallyWithProofRequest)(nil),     // 4: evoting.v1.DecryptTallyWithProofRequest
+	(*DecryptTallyWithProofResponse)(nil),    // 5: evoting.v1.DecryptTallyWithProofResponse
+	(*VerifySkKnowledgeRequest)(nil),         // 6: evoting.v1.VerifySkKnowledgeRequest
+	(*VerifyVoteWellFormednessRequest)(nil),  // 7: evoting.v1.VerifyVoteWellFormednessRequest
+	(*VerifyCorrectDecryptionRequest)(nil),   // 8: evoting.v1.VerifyCorrectDecryptionRequest
+	(*VerifyResponse)(nil),                   // 9: evoting.v1.VerifyResponse
+	(*AddEncryptedVotesRequest)(nil),         // 10: evoting.v1.AddEncryptedVotesRequest
+	(*AddEncryptedVotesResponse)(nil),        // 11: evoting.v1.AddEncryptedVotesResponse
+	(*ScaleEncryptedVoteRequest)(nil),        // 12: evoting.v1.ScaleEncryptedVoteRequest
+	(*ScaleEncryptedVoteResponse)(nil),       // 13: evoting.v1.ScaleEncryptedVoteResponse
+	(*cryptopb.KeyPair)(nil),                 // 14: evoting.crypto.v1.KeyPair
+	(*cryptopb.ProofSkKnowledge)(nil),        // 15: evoting.crypto.v1.ProofSkKnowledge
+	(*cryptopb.CurvePoint)(nil),              // 16: evoting.crypto.v1.CurvePoint
+	(*cryptopb.EncryptedVote)(nil),           // 17: evoting.crypto.v1.EncryptedVote
+	(*cryptopb.ProofVoteWellFormedness)(nil), // 18: evoting.crypto.v1.ProofVoteWellFormedness
+	(*cryptopb.ProofCorrectDecryption)(nil),  // 19: evoting.crypto.v1.ProofCorrectDecryption
+}
+var file_rpc_evotingpb_evoting_proto_depIdxs = []int32{
+	14, // 0: evoting.v1.NewKeyPairWithProofResponse.key_pair:type_name -> evoting.crypto.v1.KeyPair
+	15, // 1: evoting.v1.NewKeyPairWithProofResponse.proof:type_name -> evoting.crypto.v1.ProofSkKnowledge
+	16, // 2: evoting.v1.EncryptVoteWithProofRequest.pk:type_name -> evoting.crypto.v1.CurvePoint
+	17, // 3: evoting.v1.EncryptVoteWithProofResponse.encrypted_vote:type_name -> evoting.crypto.v1.EncryptedVote
+	18, // 4: evoting.v1.EncryptVoteWithProofResponse.proof:type_name -> evoting.crypto.v1.ProofVoteWellFormedness
+	17, // 5: evoting.v1.DecryptTallyWithProofRequest.tally:type_name -> evoting.crypto.v1.EncryptedVote
+	14, // 6: evoting.v1.DecryptTallyWithProofRequest.key_pair:type_name -> evoting.crypto.v1.KeyPair
+	19, // 7: evoting.v1.DecryptTallyWithProofResponse.proof:type_name -> evoting.crypto.v1.ProofCorrectDecryption
+	15, // 8: evoting.v1.VerifySkKnowledgeRequest.proof:type_name -> evoting.crypto.v1.ProofSkKnowledge
+	16, // 9: evoting.v1.VerifySkKnowledgeRequest.pk:type_name -> evoting.crypto.v1.CurvePoint
+	18, // 10: evoting.v1.VerifyVoteWellFormednessRequest.proof:type_name -> evoting.crypto.v1.ProofVoteWellFormedness
+	17, // 11: evoting.v1.VerifyVoteWellFormednessRequest.encrypted_vote:type_name -> evoting.crypto.v1.EncryptedVote
+	16, // 12: evoting.v1.VerifyVoteWellFormednessRequest.pk:type_name -> evoting.crypto.v1.CurvePoint
+	19, // 13: evoting.v1.VerifyCorrectDecryptionRequest.proof:type_name -> evoting.crypto.v1.ProofCorrectDecryption
+	17, // 14: evoting.v1.VerifyCorrectDecryptionRequest.tally:type_name -> evoting.crypto.v1.EncryptedVote
+	16, // 15: evoting.v1.VerifyCorrectDecryptionRequest.pk:type_name -> evoting.crypto.v1.CurvePoint
+	17, // 16: evoting.v1.AddEncryptedVotesRequest.encrypted_votes:type_name -> evoting.crypto.v1.EncryptedVote
+	17, // 17: evoting.v1.AddEncryptedVotesResponse.sum:type_name -> evoting.crypto.v1.EncryptedVote
+	17, // 18: evoting.v1.ScaleEncryptedVoteRequest.encrypted_vote:type_name -> evoting.crypto.v1.EncryptedVote
+	17, // 19: evoting.v1.ScaleEncryptedVoteResponse.encrypted_vote:type_name -> evoting.crypto.v1.EncryptedVote
+	0,  // 20: evoting.v1.CryptoService.NewKeyPairWithProof:input_type -> evoting.v1.NewKeyPairWithProofRequest
+	2,  // 21: evoting.v1.CryptoService.EncryptVoteWithProof:input_type -> evoting.v1.EncryptVoteWithProofRequest
+	4,  // 22: evoting.v1.CryptoService.DecryptTallyWithProof:input_type -> evoting.v1.DecryptTallyWithProofRequest
+	6,  // 23: evoting.v1.CryptoService.VerifySkKnowledge:input_type -> evoting.v1.VerifySkKnowledgeRequest
+	7,  // 24: evoting.v1.CryptoService.VerifyVoteWellFormedness:input_type -> evoting.v1.VerifyVoteWellFormednessRequest
+	8,  // 25: evoting.v1.CryptoService.VerifyCorrectDecryption:input_type -> evoting.v1.VerifyCorrectDecryptionRequest
+	10, // 26: evoting.v1.CryptoService.AddEncryptedVotes:input_type -> evoting.v1.AddEncryptedVotesRequest
+	12, // 27: evoting.v1.CryptoService.ScaleEncryptedVote:input_type -> evoting.v1.ScaleEncryptedVoteRequest
+	1,  // 28: evoting.v1.CryptoService.NewKeyPairWithProof:output_type -> evoting.v1.NewKeyPairWithProofResponse
+	3,  // 29: evoting.v1.CryptoService.EncryptVoteWithProof:output_type -> evoting.v1.EncryptVoteWithProofResponse
+	5,  // 30: evoting.v1.CryptoService.DecryptTallyWithProof:output_type -> evoting.v1.DecryptTallyWithProofResponse
+	9,  // 31: evoting.v1.CryptoService.VerifySkKnowledge:output_type -> evoting.v1.VerifyResponse
+	9,  // 32: evoting.v1.CryptoService.VerifyVoteWellFormedness:output_type -> evoting.v1.VerifyResponse
+	9,  // 33: evoting.v1.CryptoService.VerifyCorrectDecryption:output_type -> evoting.v1.VerifyResponse
+	11, // 34: evoting.v1.CryptoService.AddEncryptedVotes:output_type -> evoting.v1.AddEncryptedVotesResponse
+	13, // 35: evoting.v1.CryptoService.ScaleEncryptedVote:output_type -> evoting.v1.ScaleEncryptedVoteResponse
+	28, // [28:36] is the sub-list for method output_type
+	20, // [20:28] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_rpc_evotingpb_evoting_proto_init() }
+func file_rpc_evotingpb_evoting_proto_init() {
+	if File_rpc_evotingpb_evoting_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_evotingpb_evoting_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewKeyPairWithProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewKeyPairWithProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EncryptVoteWithProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EncryptVoteWithProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecryptTallyWithProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecryptTallyWithProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifySkKnowledgeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyVoteWellFormednessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyCorrectDecryptionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddEncryptedVotesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddEncryptedVotesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScaleEncryptedVoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_evotingpb_evoting_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScaleEncryptedVoteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_evotingpb_evoting_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rpc_evotingpb_evoting_proto_goTypes,
+		DependencyIndexes: file_rpc_evotingpb_evoting_proto_depIdxs,
+		MessageInfos:      file_rpc_evotingpb_evoting_proto_msgTypes,
+	}.Build()
+	File_rpc_evotingpb_evoting_proto = out.File
+	file_rpc_evotingpb_evoting_proto_rawDesc = nil
+	file_rpc_evotingpb_evoting_proto_goTypes = nil
+	file_rpc_evotingpb_evoting_proto_depIdxs = nil
+}
@@ -0,0 +1,398 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.21.12
+// source: rpc/evotingpb/evoting.proto
+
+package evotingpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CryptoService_NewKeyPairWithProof_FullMethodName      = "/evoting.v1.CryptoService/NewKeyPairWithProof"
+	CryptoService_EncryptVoteWithProof_FullMethodName     = "/evoting.v1.CryptoService/EncryptVoteWithProof"
+	CryptoService_DecryptTallyWithProof_FullMethodName    = "/evoting.v1.CryptoService/DecryptTallyWithProof"
+	CryptoService_VerifySkKnowledge_FullMethodName        = "/evoting.v1.CryptoService/VerifySkKnowledge"
+	CryptoService_VerifyVoteWellFormedness_FullMethodName = "/evoting.v1.CryptoService/VerifyVoteWellFormedness"
+	CryptoService_VerifyCorrectDecryption_FullMethodName  = "/evoting.v1.CryptoService/VerifyCorrectDecryption"
+	CryptoService_AddEncryptedVotes_FullMethodName        = "/evoting.v1.CryptoService/AddEncryptedVotes"
+	CryptoService_ScaleEncryptedVote_FullMethodName       = "/evoting.v1.CryptoService/ScaleEncryptedVote"
+)
+
+// CryptoServiceClient is the client API for CryptoService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CryptoServiceClient interface {
+	// NewKeyPairWithProof generates an election key pair, together with a
+	// proof of knowledge of its secret key.
+	NewKeyPairWithProof(ctx context.Context, in *NewKeyPairWithProofRequest, opts ...grpc.CallOption) (*NewKeyPairWithProofResponse, error)
+	// EncryptVoteWithProof encrypts a yes/no vote, and generates a proof of
+	// its well-formedness.
+	EncryptVoteWithProof(ctx context.Context, in *EncryptVoteWithProofRequest, opts ...grpc.CallOption) (*EncryptVoteWithProofResponse, error)
+	// DecryptTallyWithProof decrypts an encrypted tally, and generates a proof
+	// of correct decryption.
+	DecryptTallyWithProof(ctx context.Context, in *DecryptTallyWithProofRequest, opts ...grpc.CallOption) (*DecryptTallyWithProofResponse, error)
+	// VerifySkKnowledge verifies a proof of knowledge of the secret key
+	// behind an election public key.
+	VerifySkKnowledge(ctx context.Context, in *VerifySkKnowledgeRequest, opts ...grpc.CallOption) (*VerifyResponse, error)
+	// VerifyVoteWellFormedness verifies a proof of well-formedness of an
+	// encrypted vote.
+	VerifyVoteWellFormedness(ctx context.Context, in *VerifyVoteWellFormednessRequest, opts ...grpc.CallOption) (*VerifyResponse, error)
+	// VerifyCorrectDecryption verifies a proof of correct decryption of an
+	// encrypted tally.
+	VerifyCorrectDecryption(ctx context.Context, in *VerifyCorrectDecryptionRequest, opts ...grpc.CallOption) (*VerifyResponse, error)
+	// AddEncryptedVotes homomorphically adds encrypted votes.
+	AddEncryptedVotes(ctx context.Context, in *AddEncryptedVotesRequest, opts ...grpc.CallOption) (*AddEncryptedVotesResponse, error)
+	// ScaleEncryptedVote homomorphically multiplies an encrypted vote by a
+	// weight.
+	ScaleEncryptedVote(ctx context.Context, in *ScaleEncryptedVoteRequest, opts ...grpc.CallOption) (*ScaleEncryptedVoteResponse, error)
+}
+
+type cryptoServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCryptoServiceClient(cc grpc.ClientConnInterface) CryptoServiceClient {
+	return &cryptoServiceClient{cc}
+}
+
+func (c *cryptoServiceClient) NewKeyPairWithProof(ctx context.Context, in *NewKeyPairWithProofRequest, opts ...grpc.CallOption) (*NewKeyPairWithProofResponse, error) {
+	out := new(NewKeyPairWithProofResponse)
+	err := c.cc.Invoke(ctx, CryptoService_NewKeyPairWithProof_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) EncryptVoteWithProof(ctx context.Context, in *EncryptVoteWithProofRequest, opts ...grpc.CallOption) (*EncryptVoteWithProofResponse, error) {
+	out := new(EncryptVoteWithProofResponse)
+	err := c.cc.Invoke(ctx, CryptoService_EncryptVoteWithProof_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) DecryptTallyWithProof(ctx context.Context, in *DecryptTallyWithProofRequest, opts ...grpc.CallOption) (*DecryptTallyWithProofResponse, error) {
+	out := new(DecryptTallyWithProofResponse)
+	err := c.cc.Invoke(ctx, CryptoService_DecryptTallyWithProof_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) VerifySkKnowledge(ctx context.Context, in *VerifySkKnowledgeRequest, opts ...grpc.CallOption) (*VerifyResponse, error) {
+	out := new(VerifyResponse)
+	err := c.cc.Invoke(ctx, CryptoService_VerifySkKnowledge_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) VerifyVoteWellFormedness(ctx context.Context, in *VerifyVoteWellFormednessRequest, opts ...grpc.CallOption) (*VerifyResponse, error) {
+	out := new(VerifyResponse)
+	err := c.cc.Invoke(ctx, CryptoService_VerifyVoteWellFormedness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) VerifyCorrectDecryption(ctx context.Context, in *VerifyCorrectDecryptionRequest, opts ...grpc.CallOption) (*VerifyResponse, error) {
+	out := new(VerifyResponse)
+	err := c.cc.Invoke(ctx, CryptoService_VerifyCorrectDecryption_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) AddEncryptedVotes(ctx context.Context, in *AddEncryptedVotesRequest, opts ...grpc.CallOption) (*AddEncryptedVotesResponse, error) {
+	out := new(AddEncryptedVotesResponse)
+	err := c.cc.Invoke(ctx, CryptoService_AddEncryptedVotes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) ScaleEncryptedVote(ctx context.Context, in *ScaleEncryptedVoteRequest, opts ...grpc.CallOption) (*ScaleEncryptedVoteResponse, error) {
+	out := new(ScaleEncryptedVoteResponse)
+	err := c.cc.Invoke(ctx, CryptoService_ScaleEncryptedVote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CryptoServiceServer is the server API for CryptoService service.
+// All implementations must embed UnimplementedCryptoServiceServer
+// for forward compatibility
+type CryptoServiceServer interface {
+	// NewKeyPairWithProof generates an election key pair, together with a
+	// proof of knowledge of its secret key.
+	NewKeyPairWithProof(context.Context, *NewKeyPairWithProofRequest) (*NewKeyPairWithProofResponse, error)
+	// EncryptVoteWithProof encrypts a yes/no vote, and generates a proof of
+	// its well-formedness.
+	EncryptVoteWithProof(context.Context, *EncryptVoteWithProofRequest) (*EncryptVoteWithProofResponse, error)
+	// DecryptTallyWithProof decrypts an encrypted tally, and generates a proof
+	// of correct decryption.
+	DecryptTallyWithProof(context.Context, *DecryptTallyWithProofRequest) (*DecryptTallyWithProofResponse, error)
+	// VerifySkKnowledge verifies a proof of knowledge of the secret key
+	// behind an election public key.
+	VerifySkKnowledge(context.Context, *VerifySkKnowledgeRequest) (*VerifyResponse, error)
+	// VerifyVoteWellFormedness verifies a proof of well-formedness of an
+	// encrypted vote.
+	VerifyVoteWellFormedness(context.Context, *VerifyVoteWellFormednessRequest) (*VerifyResponse, error)
+	// VerifyCorrectDecryption verifies a proof of correct decryption of an
+	// encrypted tally.
+	VerifyCorrectDecryption(context.Context, *VerifyCorrectDecryptionRequest) (*VerifyResponse, error)
+	// AddEncryptedVotes homomorphically adds encrypted votes.
+	AddEncryptedVotes(context.Context, *AddEncryptedVotesRequest) (*AddEncryptedVotesResponse, error)
+	// ScaleEncryptedVote homomorphically multiplies an encrypted vote by a
+	// weight.
+	ScaleEncryptedVote(context.Context, *ScaleEncryptedVoteRequest) (*ScaleEncryptedVoteResponse, error)
+	mustEmbedUnimplementedCryptoServiceServer()
+}
+
+// UnimplementedCryptoServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCryptoServiceServer struct {
+}
+
+func (UnimplementedCryptoServiceServer) NewKeyPairWithProof(context.Context, *NewKeyPairWithProofRequest) (*NewKeyPairWithProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewKeyPairWithProof not implemented")
+}
+func (UnimplementedCryptoServiceServer) EncryptVoteWithProof(context.Context, *EncryptVoteWithProofRequest) (*EncryptVoteWithProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EncryptVoteWithProof not implemented")
+}
+func (UnimplementedCryptoServiceServer) DecryptTallyWithProof(context.Context, *DecryptTallyWithProofRequest) (*DecryptTallyWithProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecryptTallyWithProof not implemented")
+}
+func (UnimplementedCryptoServiceServer) VerifySkKnowledge(context.Context, *VerifySkKnowledgeRequest) (*VerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifySkKnowledge not implemented")
+}
+func (UnimplementedCryptoServiceServer) VerifyVoteWellFormedness(context.Context, *VerifyVoteWellFormednessRequest) (*VerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyVoteWellFormedness not implemented")
+}
+func (UnimplementedCryptoServiceServer) VerifyCorrectDecryption(context.Context, *VerifyCorrectDecryptionRequest) (*VerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyCorrectDecryption not implemented")
+}
+func (UnimplementedCryptoServiceServer) AddEncryptedVotes(context.Context, *AddEncryptedVotesRequest) (*AddEncryptedVotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddEncryptedVotes not implemented")
+}
+func (UnimplementedCryptoServiceServer) ScaleEncryptedVote(context.Context, *ScaleEncryptedVoteRequest) (*ScaleEncryptedVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScaleEncryptedVote not implemented")
+}
+func (UnimplementedCryptoServiceServer) mustEmbedUnimplementedCryptoServiceServer() {}
+
+// UnsafeCryptoServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CryptoServiceServer will
+// result in compilation errors.
+type UnsafeCryptoServiceServer interface {
+	mustEmbedUnimplementedCryptoServiceServer()
+}
+
+func RegisterCryptoServiceServer(s grpc.ServiceRegistrar, srv CryptoServiceServer) {
+	s.RegisterService(&CryptoService_ServiceDesc, srv)
+}
+
+func _CryptoService_NewKeyPairWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewKeyPairWithProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).NewKeyPairWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_NewKeyPairWithProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).NewKeyPairWithProof(ctx, req.(*NewKeyPairWithProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_EncryptVoteWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncryptVoteWithProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).EncryptVoteWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_EncryptVoteWithProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).EncryptVoteWithProof(ctx, req.(*EncryptVoteWithProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_DecryptTallyWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecryptTallyWithProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).DecryptTallyWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_DecryptTallyWithProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).DecryptTallyWithProof(ctx, req.(*DecryptTallyWithProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_VerifySkKnowledge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifySkKnowledgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).VerifySkKnowledge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_VerifySkKnowledge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).VerifySkKnowledge(ctx, req.(*VerifySkKnowledgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_VerifyVoteWellFormedness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyVoteWellFormednessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).VerifyVoteWellFormedness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_VerifyVoteWellFormedness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).VerifyVoteWellFormedness(ctx, req.(*VerifyVoteWellFormednessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_VerifyCorrectDecryption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyCorrectDecryptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).VerifyCorrectDecryption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_VerifyCorrectDecryption_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).VerifyCorrectDecryption(ctx, req.(*VerifyCorrectDecryptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_AddEncryptedVotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddEncryptedVotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).AddEncryptedVotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_AddEncryptedVotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).AddEncryptedVotes(ctx, req.(*AddEncryptedVotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_ScaleEncryptedVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaleEncryptedVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).ScaleEncryptedVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_ScaleEncryptedVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).ScaleEncryptedVote(ctx, req.(*ScaleEncryptedVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CryptoService_ServiceDesc is the grpc.ServiceDesc for CryptoService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CryptoService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "evoting.v1.CryptoService",
+	HandlerType: (*CryptoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NewKeyPairWithProof",
+			Handler:    _CryptoService_NewKeyPairWithProof_Handler,
+		},
+		{
+			MethodName: "EncryptVoteWithProof",
+			Handler:    _CryptoService_EncryptVoteWithProof_Handler,
+		},
+		{
+			MethodName: "DecryptTallyWithProof",
+			Handler:    _CryptoService_DecryptTallyWithProof_Handler,
+		},
+		{
+			MethodName: "VerifySkKnowledge",
+			Handler:    _CryptoService_VerifySkKnowledge_Handler,
+		},
+		{
+			MethodName: "VerifyVoteWellFormedness",
+			Handler:    _CryptoService_VerifyVoteWellFormedness_Handler,
+		},
+		{
+			MethodName: "VerifyCorrectDecryption",
+			Handler:    _CryptoService_VerifyCorrectDecryption_Handler,
+		},
+		{
+			MethodName: "AddEncryptedVotes",
+			Handler:    _CryptoService_AddEncryptedVotes_Handler,
+		},
+		{
+			MethodName: "ScaleEncryptedVote",
+			Handler:    _CryptoService_ScaleEncryptedVote_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc/evotingpb/evoting.proto",
+}
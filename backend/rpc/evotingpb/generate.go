@@ -0,0 +1,5 @@
+// Package evotingpb contains the protobuf definitions of the gRPC API of
+// the cryptographic backend, and the Go code generated from them.
+package evotingpb
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative rpc/evotingpb/evoting.proto
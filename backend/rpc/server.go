@@ -0,0 +1,199 @@
+// Package rpc exposes the cryptographic backend as a gRPC service (see
+// package evotingpb for the API definition), so that it can be used from
+// languages other than Go and javascript without going through wasm.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto/cryptopb"
+	"github.com/HorizenLabs/e-voting-poc/backend/rpc/evotingpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements evotingpb.CryptoServiceServer.
+type Server struct {
+	evotingpb.UnimplementedCryptoServiceServer
+	reader      io.Reader
+	voterSecret *arith.SecretScalar
+}
+
+// NewServer returns a new Server, which draws all the randomness it needs
+// from r. r should be safe for concurrent use, like crypto/rand.Reader.
+// The encryptions of votes are hedged with voterSecret (see
+// crypto.NewVoterSecret), which should be held by the voter running the
+// server.
+func NewServer(r io.Reader, voterSecret *arith.SecretScalar) *Server {
+	return &Server{reader: r, voterSecret: voterSecret}
+}
+
+func (s *Server) NewKeyPairWithProof(
+	ctx context.Context,
+	req *evotingpb.NewKeyPairWithProofRequest) (*evotingpb.NewKeyPairWithProofResponse, error) {
+	keyPair, proof, err := crypto.NewKeyPairWithProof(s.reader)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	defer keyPair.Destroy()
+	return &evotingpb.NewKeyPairWithProofResponse{
+		KeyPair: cryptopb.NewKeyPair(keyPair),
+		Proof:   cryptopb.NewProofSkKnowledge(proof),
+	}, nil
+}
+
+func (s *Server) EncryptVoteWithProof(
+	ctx context.Context,
+	req *evotingpb.EncryptVoteWithProofRequest) (*evotingpb.EncryptVoteWithProofResponse, error) {
+	if req.Vote != int64(crypto.No) && req.Vote != int64(crypto.Yes) {
+		return nil, invalidArgument(newFieldParsingError("vote", fmt.Errorf("vote should be 0 or 1, got %d", req.Vote)))
+	}
+	pk, err := req.Pk.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("pk", err))
+	}
+
+	encryptedVote, proof, err := crypto.EncryptVoteWithProof(s.reader, req.Vote, s.voterSecret, pk)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &evotingpb.EncryptVoteWithProofResponse{
+		EncryptedVote: cryptopb.NewEncryptedVote(encryptedVote),
+		Proof:         cryptopb.NewProofVoteWellFormedness(proof),
+	}, nil
+}
+
+func (s *Server) DecryptTallyWithProof(
+	ctx context.Context,
+	req *evotingpb.DecryptTallyWithProofRequest) (*evotingpb.DecryptTallyWithProofResponse, error) {
+	tally, err := req.Tally.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("tally", err))
+	}
+	if req.N < 0 {
+		return nil, invalidArgument(newFieldParsingError("n", fmt.Errorf("upper bound should be non-negative, got %d", req.N)))
+	}
+	keyPair, err := req.KeyPair.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("key_pair", err))
+	}
+	defer keyPair.Destroy()
+
+	result, proof, err := crypto.DecryptTallyWithProof(s.reader, tally, req.N, keyPair)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &evotingpb.DecryptTallyWithProofResponse{
+		Result: result,
+		Proof:  cryptopb.NewProofCorrectDecryption(proof),
+	}, nil
+}
+
+func (s *Server) VerifySkKnowledge(
+	ctx context.Context,
+	req *evotingpb.VerifySkKnowledgeRequest) (*evotingpb.VerifyResponse, error) {
+	proof, err := req.Proof.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("proof", err))
+	}
+	pk, err := req.Pk.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("pk", err))
+	}
+	return verifyResponse(crypto.VerifySkKnowledge(proof, pk)), nil
+}
+
+func (s *Server) VerifyVoteWellFormedness(
+	ctx context.Context,
+	req *evotingpb.VerifyVoteWellFormednessRequest) (*evotingpb.VerifyResponse, error) {
+	proof, err := req.Proof.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("proof", err))
+	}
+	encryptedVote, err := req.EncryptedVote.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("encrypted_vote", err))
+	}
+	pk, err := req.Pk.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("pk", err))
+	}
+	return verifyResponse(crypto.VerifyVoteWellFormedness(proof, encryptedVote, pk)), nil
+}
+
+func (s *Server) VerifyCorrectDecryption(
+	ctx context.Context,
+	req *evotingpb.VerifyCorrectDecryptionRequest) (*evotingpb.VerifyResponse, error) {
+	proof, err := req.Proof.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("proof", err))
+	}
+	tally, err := req.Tally.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("tally", err))
+	}
+	pk, err := req.Pk.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("pk", err))
+	}
+	err = crypto.VerifyCorrectDecryption(proof, tally, crypto.Vote(req.Result), pk)
+	return verifyResponse(err), nil
+}
+
+func (s *Server) AddEncryptedVotes(
+	ctx context.Context,
+	req *evotingpb.AddEncryptedVotesRequest) (*evotingpb.AddEncryptedVotesResponse, error) {
+	sum := crypto.NewEncryptedVote()
+	for i, v := range req.EncryptedVotes {
+		vote, err := v.Decode()
+		if err != nil {
+			return nil, invalidArgument(newFieldParsingError(fmt.Sprintf("encrypted_votes[%d]", i), err))
+		}
+		sum.Add(sum, vote)
+	}
+	return &evotingpb.AddEncryptedVotesResponse{Sum: cryptopb.NewEncryptedVote(sum)}, nil
+}
+
+func (s *Server) ScaleEncryptedVote(
+	ctx context.Context,
+	req *evotingpb.ScaleEncryptedVoteRequest) (*evotingpb.ScaleEncryptedVoteResponse, error) {
+	vote, err := req.EncryptedVote.Decode()
+	if err != nil {
+		return nil, invalidArgument(newFieldParsingError("encrypted_vote", err))
+	}
+	weight := arith.NewScalar(new(big.Int).SetUint64(req.Weight))
+	scaled := new(crypto.EncryptedVote).Scale(vote, weight)
+	return &evotingpb.ScaleEncryptedVoteResponse{EncryptedVote: cryptopb.NewEncryptedVote(scaled)}, nil
+}
+
+func verifyResponse(err error) *evotingpb.VerifyResponse {
+	if err != nil {
+		return &evotingpb.VerifyResponse{Valid: false, Reason: err.Error()}
+	}
+	return &evotingpb.VerifyResponse{Valid: true}
+}
+
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+type fieldParsingError struct {
+	key string
+	err error
+}
+
+func newFieldParsingError(key string, err error) *fieldParsingError {
+	return &fieldParsingError{
+		key: key,
+		err: err,
+	}
+}
+
+func (e *fieldParsingError) Error() string {
+	return fmt.Sprintf("error parsing field %s: %v", e.key, e.err)
+}
@@ -0,0 +1,181 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"net"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto/cryptopb"
+	"github.com/HorizenLabs/e-voting-poc/backend/rpc/evotingpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestElectionWorkflow(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	keys, err := client.NewKeyPairWithProof(ctx, &evotingpb.NewKeyPairWithProofRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := keys.KeyPair.Pk
+	res, err := client.VerifySkKnowledge(ctx, &evotingpb.VerifySkKnowledgeRequest{
+		Proof: keys.Proof,
+		Pk:    pk,
+	})
+	assertValid(t, res, err)
+
+	votes := []int64{1, 0, 1, 1}
+	weights := []uint64{3, 5, 1, 2}
+	var scaledVotes []*cryptopb.EncryptedVote
+	var castWeight, forWeight int64
+	for i, vote := range votes {
+		ballot, err := client.EncryptVoteWithProof(ctx, &evotingpb.EncryptVoteWithProofRequest{Vote: vote, Pk: pk})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := client.VerifyVoteWellFormedness(ctx, &evotingpb.VerifyVoteWellFormednessRequest{
+			Proof:         ballot.Proof,
+			EncryptedVote: ballot.EncryptedVote,
+			Pk:            pk,
+		})
+		assertValid(t, res, err)
+		scaled, err := client.ScaleEncryptedVote(ctx, &evotingpb.ScaleEncryptedVoteRequest{
+			EncryptedVote: ballot.EncryptedVote,
+			Weight:        weights[i],
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		scaledVotes = append(scaledVotes, scaled.EncryptedVote)
+		castWeight += int64(weights[i])
+		forWeight += vote * int64(weights[i])
+	}
+
+	sum, err := client.AddEncryptedVotes(ctx, &evotingpb.AddEncryptedVotesRequest{EncryptedVotes: scaledVotes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := client.DecryptTallyWithProof(ctx, &evotingpb.DecryptTallyWithProofRequest{
+		Tally:   sum.Sum,
+		N:       castWeight,
+		KeyPair: keys.KeyPair,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decrypted.Result != forWeight {
+		t.Fatalf("expected: %d, got: %d", forWeight, decrypted.Result)
+	}
+	res, err = client.VerifyCorrectDecryption(ctx, &evotingpb.VerifyCorrectDecryptionRequest{
+		Proof:  decrypted.Proof,
+		Tally:  sum.Sum,
+		Result: decrypted.Result,
+		Pk:     pk,
+	})
+	assertValid(t, res, err)
+
+	res, err = client.VerifyCorrectDecryption(ctx, &evotingpb.VerifyCorrectDecryptionRequest{
+		Proof:  decrypted.Proof,
+		Tally:  sum.Sum,
+		Result: decrypted.Result + 1,
+		Pk:     pk,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Valid || res.Reason == "" {
+		t.Fatal("successfully verified a proof of correct decryption passing a wrong result")
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	keys, err := client.NewKeyPairWithProof(ctx, &evotingpb.NewKeyPairWithProofRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	offCurve := &cryptopb.CurvePoint{X: keys.KeyPair.Pk.X, Y: keys.KeyPair.Pk.X}
+	overModulus := &cryptopb.Scalar{Value: make([]byte, 32)}
+	for i := range overModulus.Value {
+		overModulus.Value[i] = 0xff
+	}
+
+	tests := map[string]func() error{
+		"vote out of range": func() error {
+			_, err := client.EncryptVoteWithProof(ctx, &evotingpb.EncryptVoteWithProofRequest{Vote: 2, Pk: keys.KeyPair.Pk})
+			return err
+		},
+		"missing pk": func() error {
+			_, err := client.EncryptVoteWithProof(ctx, &evotingpb.EncryptVoteWithProofRequest{Vote: 1})
+			return err
+		},
+		"pk outside curve": func() error {
+			_, err := client.EncryptVoteWithProof(ctx, &evotingpb.EncryptVoteWithProofRequest{Vote: 1, Pk: offCurve})
+			return err
+		},
+		"scalar over modulus": func() error {
+			_, err := client.VerifySkKnowledge(ctx, &evotingpb.VerifySkKnowledgeRequest{
+				Proof: &cryptopb.ProofSkKnowledge{S: overModulus, C: keys.Proof.C},
+				Pk:    keys.KeyPair.Pk,
+			})
+			return err
+		},
+		"short challenge": func() error {
+			_, err := client.VerifySkKnowledge(ctx, &evotingpb.VerifySkKnowledgeRequest{
+				Proof: &cryptopb.ProofSkKnowledge{S: keys.Proof.S, C: &cryptopb.Challenge{Value: []byte{1}}},
+				Pk:    keys.KeyPair.Pk,
+			})
+			return err
+		},
+	}
+
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := f()
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("expected status %s, got %v", codes.InvalidArgument, err)
+			}
+		})
+	}
+}
+
+// testVoterSecret is the voter secret of the encryptions of the tests.
+var testVoterSecret, _ = crypto.NewVoterSecret([]byte("e-voting test voter"))
+
+func newTestClient(t *testing.T) evotingpb.CryptoServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	evotingpb.RegisterCryptoServiceServer(server, NewServer(rand.Reader, testVoterSecret))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return evotingpb.NewCryptoServiceClient(conn)
+}
+
+func assertValid(t *testing.T, res *evotingpb.VerifyResponse, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Valid {
+		t.Fatal(res.Reason)
+	}
+}
@@ -0,0 +1,383 @@
+package timelock
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// A cheating authority passes the verification of a LockedKey with
+// probability 1/binomial(numPuzzles, numOpened). The defaults give about
+// 2^-124.
+const (
+	DefaultNumPuzzles = 128
+	DefaultNumOpened  = 64
+	// MaxNumPuzzles bounds the work of the verifier.
+	MaxNumPuzzles = 1024
+)
+
+const (
+	lockedKeyDomain      = "e-voting timelock locked key"
+	lockedKeyNonceDomain = "e-voting timelock locked key nonce"
+)
+
+// LockedKey is a secret key sk locked in time-lock puzzles, with a proof
+// that sk is the discrete log of the public key pk.
+//
+// sk is split into numPuzzles Shamir shares s_i = f(i+1), where f is a
+// random polynomial of degree numOpened with f(0) = sk. Commitments[i] is
+// s_i*g, Puzzles[i] is a puzzle with solution y_i, and Ciphertexts[i] is
+// s_i plus a mask derived from y_i. The puzzles to open are chosen by
+// Fiat-Shamir, and Openings reveals their randomness and their shares.
+//
+// The verifier checks the opened puzzles, and that the commitments lie on
+// the polynomial defined by pk and the opened commitments. Then, if any
+// unopened puzzle is correct, solving it gives numOpened+1 shares, from
+// which sk can be interpolated. A cheating authority would have to guess
+// exactly which puzzles are left unopened.
+type LockedKey struct {
+	Commitments []arith.CurvePoint `json:"commitments"`
+	Puzzles     []Puzzle           `json:"puzzles"`
+	Ciphertexts []arith.Scalar     `json:"ciphertexts"`
+	Openings    []Opening          `json:"openings"`
+}
+
+// Opening reveals the randomness and the share of the puzzle of a
+// LockedKey at Index.
+type Opening struct {
+	Index int          `json:"index"`
+	R     *big.Int     `json:"r"`
+	Share arith.Scalar `json:"share"`
+}
+
+// LockKey locks the secret key of keyPair in numPuzzles puzzles, opening
+// numOpened of them. params should have been verified.
+func LockKey(
+	reader io.Reader,
+	params *Params,
+	keyPair *crypto.KeyPair,
+	numPuzzles int,
+	numOpened int) (*LockedKey, error) {
+	if err := checkCutAndChoose(numPuzzles, numOpened); err != nil {
+		return nil, err
+	}
+	// the coefficients and the randomness of the puzzles are hedged like the
+	// nonces of the provers of package crypto: locking the same key twice
+	// with the same randomness would open more than numOpened shares
+	reader, err := lockKeyNonces(reader, params, keyPair, numPuzzles, numOpened)
+	if err != nil {
+		return nil, err
+	}
+	coefficients := make([]*arith.SecretScalar, numOpened)
+	for i := range coefficients {
+		c, err := arith.RandomSecretScalar(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer c.Destroy()
+		coefficients[i] = c
+	}
+
+	lk := &LockedKey{
+		Commitments: make([]arith.CurvePoint, numPuzzles),
+		Puzzles:     make([]Puzzle, numPuzzles),
+		Ciphertexts: make([]arith.Scalar, numPuzzles),
+	}
+	shares := make([]*arith.SecretScalar, numPuzzles)
+	randomness := make([]*big.Int, numPuzzles)
+	for i := range shares {
+		shares[i] = evaluate(&keyPair.Sk, coefficients, uint64(i+1))
+		defer shares[i].Destroy()
+		lk.Commitments[i].ScalarBaseMultSecret(shares[i])
+
+		puzzle, r, solution, err := params.NewPuzzle(reader)
+		if err != nil {
+			return nil, err
+		}
+		randomness[i] = r
+		lk.Puzzles[i] = *puzzle
+		key := params.solutionKey(solution)
+		key.Add(key, shares[i])
+		lk.Ciphertexts[i].Set(key.Declassify())
+		key.Destroy()
+	}
+
+	indices, err := lk.challenge(params, &keyPair.Pk, numOpened)
+	if err != nil {
+		return nil, err
+	}
+	lk.Openings = make([]Opening, numOpened)
+	for k, i := range indices {
+		lk.Openings[k].Index = i
+		lk.Openings[k].R = randomness[i]
+		lk.Openings[k].Share.Set(shares[i].Declassify())
+	}
+	return lk, nil
+}
+
+// lockKeyNonces returns the hedged source of the randomness of LockKey.
+func lockKeyNonces(
+	reader io.Reader,
+	params *Params,
+	keyPair *crypto.KeyPair,
+	numPuzzles int,
+	numOpened int) (io.Reader, error) {
+	bytesPk, err := keyPair.Pk.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	size := (params.N.BitLen() + 7) / 8
+	return crypto.NewHedgedReader(
+		reader,
+		lockedKeyNonceDomain,
+		[]*arith.SecretScalar{&keyPair.Sk},
+		bytesPk,
+		params.N.FillBytes(make([]byte, size)),
+		params.H.FillBytes(make([]byte, size)),
+		binary.BigEndian.AppendUint64(nil, params.T),
+		binary.BigEndian.AppendUint64(nil, uint64(numPuzzles)),
+		binary.BigEndian.AppendUint64(nil, uint64(numOpened)))
+}
+
+// VerifyLockedKey verifies that lk locks the discrete log of pk in
+// numPuzzles puzzles, numOpened of which are opened. params should have
+// been verified.
+func VerifyLockedKey(params *Params, pk *arith.CurvePoint, lk *LockedKey, numPuzzles, numOpened int) error {
+	if err := checkCutAndChoose(numPuzzles, numOpened); err != nil {
+		return err
+	}
+	if lk == nil {
+		return errors.New("missing locked key")
+	}
+	if pk == nil || !pk.IsValid() {
+		return errors.New("invalid public key")
+	}
+	if len(lk.Commitments) != numPuzzles || len(lk.Puzzles) != numPuzzles || len(lk.Ciphertexts) != numPuzzles {
+		return fmt.Errorf("locked key should have %d commitments, puzzles and ciphertexts", numPuzzles)
+	}
+	if len(lk.Openings) != numOpened {
+		return fmt.Errorf("locked key should have %d openings", numOpened)
+	}
+	for i := range lk.Puzzles {
+		if !lk.Commitments[i].IsValid() {
+			return fmt.Errorf("invalid commitment %d", i)
+		}
+		if lk.Puzzles[i].U == nil || !params.isElement(lk.Puzzles[i].U) {
+			return fmt.Errorf("invalid puzzle %d", i)
+		}
+	}
+	indices, err := lk.challenge(params, pk, numOpened)
+	if err != nil {
+		return err
+	}
+
+	opened := make([]bool, numPuzzles)
+	xs := []uint64{0}
+	points := []*arith.CurvePoint{pk}
+	for k, opening := range lk.Openings {
+		i := indices[k]
+		if opening.Index != i {
+			return fmt.Errorf("opening %d should open puzzle %d", k, i)
+		}
+		if opening.R == nil || opening.R.Sign() < 0 {
+			return fmt.Errorf("invalid randomness of puzzle %d", i)
+		}
+		puzzle, solution := params.Open(opening.R)
+		if puzzle.U.Cmp(lk.Puzzles[i].U) != 0 {
+			return fmt.Errorf("wrong randomness of puzzle %d", i)
+		}
+		key := params.solutionKey(solution)
+		ciphertext := new(arith.Scalar).Add(&opening.Share, key.Declassify())
+		key.Destroy()
+		if !ciphertext.Equal(&lk.Ciphertexts[i]) {
+			return fmt.Errorf("puzzle %d does not lock its share", i)
+		}
+		if !new(arith.CurvePoint).ScalarBaseMult(&opening.Share).Equal(&lk.Commitments[i]) {
+			return fmt.Errorf("share %d does not match its commitment", i)
+		}
+		opened[i] = true
+		xs = append(xs, uint64(i+1))
+		points = append(points, &lk.Commitments[i])
+	}
+
+	// the unopened commitments must lie on the polynomial defined by pk and
+	// the opened commitments
+	term := new(arith.CurvePoint)
+	for i := range lk.Commitments {
+		if opened[i] {
+			continue
+		}
+		expected := new(arith.CurvePoint).ScalarBaseMult(new(arith.Scalar))
+		for k, lambda := range lagrange(xs, uint64(i+1)) {
+			expected.Add(expected, term.ScalarMult(points[k], lambda))
+		}
+		if !expected.Equal(&lk.Commitments[i]) {
+			return fmt.Errorf("commitment %d is not a share of the secret key", i)
+		}
+	}
+	return nil
+}
+
+// SolveLockedKey solves the unopened puzzles of a verified LockedKey, one
+// at a time, until it finds a correct share, and returns the key pair of
+// pk. It returns early with the error of ctx if ctx is done.
+func SolveLockedKey(ctx context.Context, params *Params, pk *arith.CurvePoint, lk *LockedKey) (*crypto.KeyPair, error) {
+	if lk == nil || len(lk.Ciphertexts) != len(lk.Puzzles) || len(lk.Commitments) != len(lk.Puzzles) {
+		return nil, errors.New("invalid locked key")
+	}
+	opened := make(map[int]bool, len(lk.Openings))
+	for _, opening := range lk.Openings {
+		opened[opening.Index] = true
+	}
+	for i := range lk.Puzzles {
+		if opened[i] {
+			continue
+		}
+		solution, err := params.Solve(ctx, &lk.Puzzles[i])
+		if err != nil {
+			return nil, err
+		}
+		share := arith.NewSecretScalar(&lk.Ciphertexts[i])
+		key := params.solutionKey(solution)
+		share.Sub(share, key)
+		key.Destroy()
+		if !new(arith.CurvePoint).ScalarBaseMultSecret(share).Equal(&lk.Commitments[i]) {
+			// a bad puzzle, which the cut-and-choose did not catch
+			share.Destroy()
+			continue
+		}
+		keyPair := interpolate(lk.Openings, i, share)
+		share.Destroy()
+		if !keyPair.Pk.Equal(pk) {
+			keyPair.Sk.Destroy()
+			return nil, errors.New("shares do not interpolate to the secret key")
+		}
+		return keyPair, nil
+	}
+	return nil, errors.New("no puzzle locks a correct share")
+}
+
+// interpolate returns the key pair whose secret key is f(0), given the
+// opened shares and the share of the puzzle at index.
+func interpolate(openings []Opening, index int, share *arith.SecretScalar) *crypto.KeyPair {
+	xs := make([]uint64, 0, len(openings)+1)
+	shares := make([]*arith.SecretScalar, 0, len(openings)+1)
+	for k := range openings {
+		xs = append(xs, uint64(openings[k].Index+1))
+		shares = append(shares, arith.NewSecretScalar(&openings[k].Share))
+	}
+	xs = append(xs, uint64(index+1))
+	shares = append(shares, share)
+
+	keyPair := new(crypto.KeyPair)
+	term := new(arith.SecretScalar)
+	defer term.Destroy()
+	for k, lambda := range lagrange(xs, 0) {
+		term.Mul(arith.NewSecretScalar(lambda), shares[k])
+		keyPair.Sk.Add(&keyPair.Sk, term)
+	}
+	keyPair.Pk.ScalarBaseMultSecret(&keyPair.Sk)
+	return keyPair
+}
+
+// challenge returns the sorted indices of the puzzles to open, derived
+// from a hash of the parameters, pk and the locked key.
+func (lk *LockedKey) challenge(params *Params, pk *arith.CurvePoint, numOpened int) ([]int, error) {
+	size := (params.N.BitLen() + 7) / 8
+	h := ethcrypto.NewKeccakState()
+	h.Write([]byte(lockedKeyDomain))
+	for _, v := range []*big.Int{params.N, params.G, params.H} {
+		h.Write(v.FillBytes(make([]byte, size)))
+	}
+	h.Write(binary.BigEndian.AppendUint64(nil, params.T))
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(numOpened)))
+	points := append([]*arith.CurvePoint{pk}, make([]*arith.CurvePoint, len(lk.Commitments))...)
+	for i := range lk.Commitments {
+		points[i+1] = &lk.Commitments[i]
+	}
+	for _, p := range points {
+		data, err := p.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		h.Write(data)
+	}
+	for i := range lk.Puzzles {
+		h.Write(lk.Puzzles[i].U.FillBytes(make([]byte, size)))
+		data, err := lk.Ciphertexts[i].MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		h.Write(data)
+	}
+	seed := h.Sum(nil)
+
+	n := uint64(len(lk.Puzzles))
+	chosen := make(map[int]bool, numOpened)
+	indices := make([]int, 0, numOpened)
+	for counter := uint64(0); len(indices) < numOpened; counter++ {
+		digest := ethcrypto.Keccak256(seed, binary.BigEndian.AppendUint64(nil, counter))
+		// the bias of the reduction is at most n/2^64
+		i := int(binary.BigEndian.Uint64(digest) % n)
+		if !chosen[i] {
+			chosen[i] = true
+			indices = append(indices, i)
+		}
+	}
+	sort.Ints(indices)
+	return indices, nil
+}
+
+// evaluate returns sk + sum(coefficients[k] * x^(k+1)).
+func evaluate(sk *arith.SecretScalar, coefficients []*arith.SecretScalar, x uint64) *arith.SecretScalar {
+	xs := arith.NewSecretScalar(new(arith.Scalar).SetUint64(x))
+	defer xs.Destroy()
+	y := new(arith.SecretScalar)
+	for k := len(coefficients) - 1; k >= 0; k-- {
+		y.Add(y, coefficients[k])
+		y.Mul(y, xs)
+	}
+	return y.Add(y, sk)
+}
+
+// lagrange returns the Lagrange coefficients which evaluate at x the
+// polynomial going through points with abscissas xs, which should be
+// distinct.
+func lagrange(xs []uint64, x uint64) []*arith.Scalar {
+	order := bn256.Order
+	coefficients := make([]*arith.Scalar, len(xs))
+	for k, xk := range xs {
+		num, den := big.NewInt(1), big.NewInt(1)
+		for m, xm := range xs {
+			if m == k {
+				continue
+			}
+			num.Mul(num, new(big.Int).Sub(new(big.Int).SetUint64(x), new(big.Int).SetUint64(xm)))
+			num.Mod(num, order)
+			den.Mul(den, new(big.Int).Sub(new(big.Int).SetUint64(xk), new(big.Int).SetUint64(xm)))
+			den.Mod(den, order)
+		}
+		num.Mul(num, den.ModInverse(den, order))
+		coefficients[k] = arith.NewScalar(num)
+	}
+	return coefficients
+}
+
+func checkCutAndChoose(numPuzzles, numOpened int) error {
+	if numPuzzles > MaxNumPuzzles {
+		return fmt.Errorf("number of puzzles should be at most %d", MaxNumPuzzles)
+	}
+	if numOpened < 1 || numOpened >= numPuzzles {
+		return fmt.Errorf("number of opened puzzles should be in [1, %d)", numPuzzles)
+	}
+	return nil
+}
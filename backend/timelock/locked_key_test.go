@@ -0,0 +1,214 @@
+package timelock
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+)
+
+const (
+	testNumPuzzles = 8
+	testNumOpened  = 4
+)
+
+// testVoterSecret is the voter secret of the encryptions of the tests.
+var testVoterSecret, _ = crypto.NewVoterSecret([]byte("e-voting test voter"))
+
+func TestLockAndSolveKey(t *testing.T) {
+	params := generateParams(t)
+	keyPair, err := crypto.NewKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lk, err := LockKey(rand.Reader, params, keyPair, testNumPuzzles, testNumOpened)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyLockedKey(params, &keyPair.Pk, lk, testNumPuzzles, testNumOpened); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyLockedKey(params, &keyPair.Pk, lk, testNumPuzzles, testNumOpened-1); err == nil {
+		t.Fatal("successfully verified a locked key with the wrong number of openings")
+	}
+
+	// after the delay, anyone can recover the key and tally
+	tally, _, err := crypto.Vote(3).Encrypt(rand.Reader, testVoterSecret, &keyPair.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered, err := SolveLockedKey(context.Background(), params, &keyPair.Pk, lk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !recovered.Sk.Equal(&keyPair.Sk) {
+		t.Fatal("recovered secret key is different from the locked one")
+	}
+	result, proof, err := crypto.DecryptTallyWithProof(rand.Reader, tally, 3, recovered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := crypto.VerifyCorrectDecryption(proof, tally, crypto.Vote(result), &keyPair.Pk); err != nil {
+		t.Fatal(err)
+	}
+	if result != 3 {
+		t.Fatalf("expected: 3, got: %d", result)
+	}
+}
+
+func TestVerifyInvalidLockedKey(t *testing.T) {
+	params := generateParams(t)
+	keyPair, err := crypto.NewKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyPair, err := crypto.NewKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, err := LockKey(rand.Reader, params, keyPair, testNumPuzzles, testNumOpened)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unopened := unopenedPuzzles(valid)[0]
+
+	tests := map[string]func(lk *LockedKey){
+		"key of another pk": func(lk *LockedKey) {
+			other, err := LockKey(rand.Reader, params, otherKeyPair, testNumPuzzles, testNumOpened)
+			if err != nil {
+				t.Fatal(err)
+			}
+			*lk = *other
+		},
+		"wrong unopened commitment": func(lk *LockedKey) {
+			lk.Commitments[unopened].Add(&lk.Commitments[unopened], &keyPair.Pk)
+		},
+		"wrong opened share": func(lk *LockedKey) {
+			lk.Openings[0].Share.Add(&lk.Openings[0].Share, new(arith.Scalar).SetUint64(1))
+		},
+		"wrong randomness": func(lk *LockedKey) {
+			lk.Openings[0].R = new(big.Int).Add(lk.Openings[0].R, big.NewInt(1))
+		},
+		"wrong index": func(lk *LockedKey) {
+			lk.Openings[0].Index = unopened
+		},
+		"missing puzzle": func(lk *LockedKey) {
+			lk.Puzzles = lk.Puzzles[1:]
+		},
+		"invalid puzzle": func(lk *LockedKey) {
+			lk.Puzzles[unopened].U = new(big.Int).Sub(params.N, lk.Puzzles[unopened].U)
+		},
+		"tampered ciphertext": func(lk *LockedKey) {
+			// changes the challenge, so the openings do not match anymore
+			lk.Ciphertexts[unopened].Add(&lk.Ciphertexts[unopened], new(arith.Scalar).SetUint64(1))
+		},
+	}
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			lk := copyLockedKey(valid)
+			tamper(lk)
+			if err := VerifyLockedKey(params, &keyPair.Pk, lk, testNumPuzzles, testNumOpened); err == nil {
+				t.Fatalf("successfully verified invalid locked key: %s", name)
+			}
+		})
+	}
+	if err := VerifyLockedKey(params, &keyPair.Pk, valid, testNumPuzzles, testNumPuzzles); err == nil {
+		t.Fatal("successfully verified a locked key with all puzzles opened")
+	}
+}
+
+func TestLockKeyDeterministic(t *testing.T) {
+	params := generateParams(t)
+	keyPair, err := crypto.NewKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock := func(seed string, numOpened int) *LockedKey {
+		lk, err := LockKey(crypto.NewSeededReader([]byte(seed)), params, keyPair, testNumPuzzles, numOpened)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return lk
+	}
+	want := lock("seed", testNumOpened)
+	if !reflect.DeepEqual(lock("seed", testNumOpened), want) {
+		t.Fatal("the same seed locked the key differently")
+	}
+	// a repeated seed with other parameters must not reuse the shares, or
+	// the two locked keys would open more shares than the threshold
+	other := lock("seed", testNumOpened-1)
+	for i := range other.Commitments {
+		if other.Commitments[i].Equal(&want.Commitments[i]) {
+			t.Fatalf("share %d reused across locked keys", i)
+		}
+	}
+}
+
+func TestSolveLockedKeySkipsBadPuzzles(t *testing.T) {
+	params := generateParams(t)
+	keyPair, err := crypto.NewKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lk, err := LockKey(rand.Reader, params, keyPair, testNumPuzzles, testNumOpened)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// break all the unopened puzzles but the last one
+	unopened := unopenedPuzzles(lk)
+	last := unopened[len(unopened)-1]
+	for _, i := range unopened[:len(unopened)-1] {
+		lk.Ciphertexts[i].Add(&lk.Ciphertexts[i], new(arith.Scalar).SetUint64(1))
+	}
+	recovered, err := SolveLockedKey(context.Background(), params, &keyPair.Pk, lk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !recovered.Sk.Equal(&keyPair.Sk) {
+		t.Fatal("recovered secret key is different from the locked one")
+	}
+
+	lk.Ciphertexts[last].Add(&lk.Ciphertexts[last], new(arith.Scalar).SetUint64(1))
+	if _, err := SolveLockedKey(context.Background(), params, &keyPair.Pk, lk); err == nil {
+		t.Fatal("successfully solved a locked key without correct puzzles")
+	}
+}
+
+// unopenedPuzzles returns the sorted indices of the puzzles which lk does
+// not open.
+func unopenedPuzzles(lk *LockedKey) []int {
+	opened := make(map[int]bool)
+	for _, o := range lk.Openings {
+		opened[o.Index] = true
+	}
+	var unopened []int
+	for i := range lk.Puzzles {
+		if !opened[i] {
+			unopened = append(unopened, i)
+		}
+	}
+	return unopened
+}
+
+func copyLockedKey(lk *LockedKey) *LockedKey {
+	c := &LockedKey{
+		Commitments: make([]arith.CurvePoint, len(lk.Commitments)),
+		Puzzles:     make([]Puzzle, len(lk.Puzzles)),
+		Ciphertexts: make([]arith.Scalar, len(lk.Ciphertexts)),
+		Openings:    make([]Opening, len(lk.Openings)),
+	}
+	for i := range lk.Commitments {
+		c.Commitments[i].Set(&lk.Commitments[i])
+		c.Puzzles[i].U = new(big.Int).Set(lk.Puzzles[i].U)
+		c.Ciphertexts[i].Set(&lk.Ciphertexts[i])
+	}
+	for i, o := range lk.Openings {
+		c.Openings[i] = Opening{Index: o.Index, R: new(big.Int).Set(o.R)}
+		c.Openings[i].Share.Set(&o.Share)
+	}
+	return c
+}
@@ -0,0 +1,80 @@
+package timelock
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+)
+
+const keyDomain = "e-voting timelock key"
+
+// Puzzle is a time-lock puzzle U = G^r mod N, whose solution is
+// U^(2^T) = H^r mod N.
+type Puzzle struct {
+	U *big.Int `json:"u"`
+}
+
+// NewPuzzle returns a new puzzle, together with its secret randomness r,
+// which can be revealed to open the puzzle without solving it, and its
+// solution.
+func (p *Params) NewPuzzle(reader io.Reader) (*Puzzle, *big.Int, *big.Int, error) {
+	// r is 128 bits longer than N, so that G^r is statistically close to a
+	// uniform element of the subgroup generated by G, whatever its order
+	bound := new(big.Int).Lsh(big.NewInt(1), uint(p.N.BitLen()+challengeBits))
+	r, err := rand.Int(reader, bound)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	puzzle, solution := p.Open(r)
+	return puzzle, r, solution, nil
+}
+
+// Open returns the puzzle with randomness r, and its solution, with two
+// exponentiations.
+func (p *Params) Open(r *big.Int) (*Puzzle, *big.Int) {
+	u := new(big.Int).Exp(p.G, r, p.N)
+	solution := new(big.Int).Exp(p.H, r, p.N)
+	return &Puzzle{U: abs(u, p.N)}, abs(solution, p.N)
+}
+
+// Solve solves a puzzle with T squarings. It returns early with the error
+// of ctx if ctx is done.
+func (p *Params) Solve(ctx context.Context, puzzle *Puzzle) (*big.Int, error) {
+	if puzzle == nil || puzzle.U == nil || !p.isElement(puzzle.U) {
+		return nil, errors.New("invalid puzzle")
+	}
+	// checking ctx is much cheaper than a squaring, but not free
+	const checkInterval = 1 << 10
+	y := new(big.Int).Set(puzzle.U)
+	for i := uint64(0); i < p.T; i++ {
+		if i%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		square(y, y, p.N)
+	}
+	return y, nil
+}
+
+// solutionKey derives from the solution of a puzzle the scalar which masks
+// the value locked in the puzzle. 512 bits of hash are reduced modulo the
+// group order, so that the bias of the mask is negligible.
+func (p *Params) solutionKey(solution *big.Int) *arith.SecretScalar {
+	size := (p.N.BitLen() + 7) / 8
+	h := sha512.New()
+	h.Write([]byte(keyDomain))
+	h.Write(solution.FillBytes(make([]byte, size)))
+	var digest [arith.NumBytesWide]byte
+	h.Sum(digest[:0])
+	key := new(arith.SecretScalar).SetWideBytes(&digest)
+	for i := range digest {
+		digest[i] = 0
+	}
+	return key
+}
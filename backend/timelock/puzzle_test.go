@@ -0,0 +1,45 @@
+package timelock
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestSolvePuzzle(t *testing.T) {
+	params := generateParams(t)
+	for i := 0; i < 4; i++ {
+		puzzle, r, expected, err := params.NewPuzzle(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := params.Solve(context.Background(), puzzle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Cmp(expected) != 0 {
+			t.Fatal("solution is different from the one of the creator")
+		}
+		opened, solution := params.Open(r)
+		if opened.U.Cmp(puzzle.U) != 0 || solution.Cmp(expected) != 0 {
+			t.Fatal("opened puzzle is different from the original one")
+		}
+	}
+}
+
+func TestSolvePuzzleCanceled(t *testing.T) {
+	params := generateParams(t)
+	puzzle, _, _, err := params.NewPuzzle(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := params.Solve(ctx, puzzle); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected error %v, got %v", context.Canceled, err)
+	}
+	if _, err := params.Solve(context.Background(), &Puzzle{}); err == nil {
+		t.Fatal("successfully solved an invalid puzzle")
+	}
+}
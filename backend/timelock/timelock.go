@@ -0,0 +1,280 @@
+// Package timelock lets a tallying authority lock the secret key of an
+// election in time-lock puzzles, so that anyone can recover it, and tally
+// with crypto.DecryptTallyWithProof, after a delay, even if the authority
+// disappears.
+//
+// The puzzles are based on repeated squaring in an RSA group [RSW96]:
+// given a modulus N of unknown factorization and a base u, computing
+// u^(2^T) mod N is believed to take T sequential squarings. Puzzles share
+// public parameters (see Setup): N, a base g derived from N, h = g^(2^T)
+// and a Wesolowski proof [Wes19] that h is correct, which anyone can check
+// without repeating the squarings. The parameters depend only on N and T,
+// so they can be computed once and reused across elections. A puzzle is
+// u = g^r, for a random r, and its solution is u^(2^T) = h^r: the creator
+// of the puzzle computes it with two exponentiations, while anyone else
+// needs T squarings.
+//
+// The secret key is locked with a cut-and-choose proof that the puzzles
+// hide the discrete log of the public key (see LockKey), similar to the
+// verifiable timed discrete logarithms of [TM20].
+//
+// All the computations are performed on the signed quadratic residues,
+// i.e. an element x is identified with -x and represented by
+// min(x, N - x), which rules out the elements of order 2 whose roots are
+// known, such as -1.
+//
+// [RSW96]: https://people.csail.mit.edu/rivest/pubs/RSW96.pdf
+// [Wes19]: https://eprint.iacr.org/2018/623.pdf
+// [TM20]: https://eprint.iacr.org/2020/1563.pdf
+package timelock
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// DefaultModulusBits is the recommended size of the RSA modulus.
+	DefaultModulusBits = 2048
+	// MinModulusBits is the minimum size of the RSA modulus. Moduli below
+	// DefaultModulusBits are only meant for tests.
+	MinModulusBits = 512
+	// challengeBits is the size of the prime challenge of a Wesolowski
+	// proof.
+	challengeBits = 128
+)
+
+const (
+	generatorDomain = "e-voting timelock generator"
+	challengeDomain = "e-voting timelock challenge"
+)
+
+// Params are the public parameters of the puzzles: the modulus N, the base
+// G derived from N, H = G^(2^T) mod N and the Wesolowski proof that H is
+// correct.
+type Params struct {
+	N     *big.Int `json:"n"`
+	G     *big.Int `json:"g"`
+	H     *big.Int `json:"h"`
+	T     uint64   `json:"t"`
+	Proof *big.Int `json:"proof"`
+}
+
+// GenerateModulus returns an RSA modulus of the given number of bits,
+// discarding its factors. Whoever knows the factors can solve the puzzles
+// instantly, so the modulus should rather come from a source that nobody
+// can factor, such as the RSA-2048 challenge number or a multiparty
+// ceremony, unless the generating party is trusted to forget them.
+func GenerateModulus(reader io.Reader, bits int) (*big.Int, error) {
+	if bits < MinModulusBits {
+		return nil, fmt.Errorf("modulus should have at least %d bits", MinModulusBits)
+	}
+	for {
+		p, err := rand.Prime(reader, (bits+1)/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := rand.Prime(reader, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		n := new(big.Int).Mul(p, q)
+		if p.Cmp(q) != 0 && n.BitLen() == bits {
+			return n, nil
+		}
+	}
+}
+
+// Setup computes the parameters of puzzles which take t squarings modulo
+// n to solve. It performs 2*t squarings itself: t to compute H, and t to
+// compute the proof.
+func Setup(n *big.Int, t uint64) (*Params, error) {
+	if err := checkModulus(n); err != nil {
+		return nil, err
+	}
+	if t == 0 {
+		return nil, errors.New("number of squarings should be positive")
+	}
+	g, err := generator(n)
+	if err != nil {
+		return nil, err
+	}
+	h := new(big.Int).Set(g)
+	for i := uint64(0); i < t; i++ {
+		square(h, h, n)
+	}
+	return &Params{
+		N:     new(big.Int).Set(n),
+		G:     g,
+		H:     h,
+		T:     t,
+		Proof: proveExponentiation(n, g, h, t),
+	}, nil
+}
+
+// Verify checks that the parameters are well-formed, and verifies the
+// proof that H = G^(2^T) mod N.
+func (p *Params) Verify() error {
+	if p == nil || p.N == nil || p.G == nil || p.H == nil || p.Proof == nil {
+		return errors.New("missing timelock parameters")
+	}
+	if err := checkModulus(p.N); err != nil {
+		return err
+	}
+	if p.T == 0 {
+		return errors.New("number of squarings should be positive")
+	}
+	g, err := generator(p.N)
+	if err != nil {
+		return err
+	}
+	if g.Cmp(p.G) != 0 {
+		return errors.New("wrong generator")
+	}
+	if !p.isElement(p.H) || !p.isElement(p.Proof) {
+		return errors.New("invalid group element")
+	}
+	l := hashToPrime(p.N, p.G, p.H, p.T)
+	// r = 2^T mod l
+	r := new(big.Int).Exp(big.NewInt(2), new(big.Int).SetUint64(p.T), l)
+	// proof^l * g^r must be h
+	y := new(big.Int).Exp(p.Proof, l, p.N)
+	y.Mul(y, new(big.Int).Exp(p.G, r, p.N))
+	y.Mod(y, p.N)
+	if abs(y, p.N).Cmp(p.H) != 0 {
+		return errors.New("timelock parameters proof verification failed")
+	}
+	return nil
+}
+
+// Calibrate estimates the number of squarings modulo n which take delay on
+// this machine. Since a solver may own faster hardware, the delay should
+// be chosen with some margin.
+func Calibrate(n *big.Int, delay time.Duration) (uint64, error) {
+	if err := checkModulus(n); err != nil {
+		return 0, err
+	}
+	const sample = 1 << 12
+	x, err := generator(n)
+	if err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	for i := 0; i < sample; i++ {
+		square(x, x, n)
+	}
+	elapsed := time.Since(start)
+	if elapsed <= 0 {
+		elapsed = 1
+	}
+	t := float64(sample) * float64(delay) / float64(elapsed)
+	if t < 1 {
+		return 1, nil
+	}
+	return uint64(t), nil
+}
+
+// isElement reports whether x is the representative of an element, i.e.
+// it is in (0, N/2).
+func (p *Params) isElement(x *big.Int) bool {
+	return x.Sign() > 0 && new(big.Int).Lsh(x, 1).Cmp(p.N) < 0
+}
+
+// proveExponentiation returns the Wesolowski proof that y = x^(2^t), i.e.
+// x^floor(2^t/l) for the prime challenge l, computed bit by bit with t
+// more squarings.
+func proveExponentiation(n, x, y *big.Int, t uint64) *big.Int {
+	l := hashToPrime(n, x, y, t)
+	two := big.NewInt(2)
+	proof := big.NewInt(1)
+	// r is 2^i mod l, and proof is x^floor(2^i/l)
+	r := big.NewInt(1)
+	for i := uint64(0); i < t; i++ {
+		square(proof, proof, n)
+		r.Mul(r, two)
+		if r.Cmp(l) >= 0 {
+			r.Sub(r, l)
+			proof.Mul(proof, x)
+			proof.Mod(proof, n)
+		}
+	}
+	return abs(proof, n)
+}
+
+// hashToPrime returns the prime challenge of a Wesolowski proof that
+// y = x^(2^t) mod n: the first prime among the challengeBits bits
+// integers obtained by hashing the statement with a counter.
+func hashToPrime(n, x, y *big.Int, t uint64) *big.Int {
+	size := (n.BitLen() + 7) / 8
+	data := make([]byte, 0, len(challengeDomain)+3*size+16)
+	data = append(data, challengeDomain...)
+	for _, v := range []*big.Int{n, x, y} {
+		data = append(data, v.FillBytes(make([]byte, size))...)
+	}
+	data = binary.BigEndian.AppendUint64(data, t)
+	candidate := new(big.Int)
+	for counter := uint64(0); ; counter++ {
+		digest := ethcrypto.Keccak256(binary.BigEndian.AppendUint64(data, counter))
+		candidate.SetBytes(digest[:challengeBits/8])
+		// fix the size of the candidate, and make it odd
+		candidate.SetBit(candidate, challengeBits-1, 1)
+		candidate.SetBit(candidate, 0, 1)
+		if candidate.ProbablyPrime(20) {
+			return candidate
+		}
+	}
+}
+
+// generator derives the base of the puzzles from n: the square of a hash
+// of n, so that nobody can choose it, nor know its order.
+func generator(n *big.Int) (*big.Int, error) {
+	size := (n.BitLen() + 7) / 8
+	seed := append([]byte(generatorDomain), n.FillBytes(make([]byte, size))...)
+	// expand the hash to 128 bits more than n, so that the bias of the
+	// reduction is negligible
+	var digest []byte
+	for counter := uint32(0); len(digest) < size+challengeBits/8; counter++ {
+		digest = append(digest, ethcrypto.Keccak256(binary.BigEndian.AppendUint32(seed, counter))...)
+	}
+	g := new(big.Int).SetBytes(digest)
+	g.Mod(g, n)
+	square(g, g, n)
+	if g.Cmp(big.NewInt(1)) == 0 || new(big.Int).GCD(nil, nil, g, n).Cmp(big.NewInt(1)) != 0 {
+		return nil, errors.New("degenerate modulus")
+	}
+	return g, nil
+}
+
+// square sets z to abs(x^2 mod n).
+func square(z, x, n *big.Int) {
+	z.Mul(x, x)
+	z.Mod(z, n)
+	z.Set(abs(z, n))
+}
+
+// abs returns min(x, n - x), the representative of x as a signed quadratic
+// residue. x should be in [0, n).
+func abs(x, n *big.Int) *big.Int {
+	neg := new(big.Int).Sub(n, x)
+	if neg.Cmp(x) < 0 {
+		return x.Set(neg)
+	}
+	return x
+}
+
+func checkModulus(n *big.Int) error {
+	if n == nil || n.BitLen() < MinModulusBits {
+		return fmt.Errorf("modulus should have at least %d bits", MinModulusBits)
+	}
+	if n.Bit(0) == 0 {
+		return errors.New("modulus should be odd")
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package timelock
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+	"time"
+)
+
+const testSquarings = 1000
+
+func TestSetupAndVerify(t *testing.T) {
+	params := generateParams(t)
+	if err := params.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]func(p *Params){
+		"wrong h":         func(p *Params) { p.H = multiply(p.H, p.G, p.N) },
+		"negated h":       func(p *Params) { p.H = new(big.Int).Sub(p.N, p.H) },
+		"wrong proof":     func(p *Params) { p.Proof = multiply(p.Proof, p.G, p.N) },
+		"wrong generator": func(p *Params) { p.G = new(big.Int).Set(p.H) },
+		"more squarings":  func(p *Params) { p.T++ },
+		"fewer squarings": func(p *Params) { p.T-- },
+		"zero squarings":  func(p *Params) { p.T = 0 },
+		"missing proof":   func(p *Params) { p.Proof = nil },
+		"small modulus":   func(p *Params) { p.N = big.NewInt(1<<61 - 1) },
+	}
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &Params{
+				N:     new(big.Int).Set(params.N),
+				G:     new(big.Int).Set(params.G),
+				H:     new(big.Int).Set(params.H),
+				T:     params.T,
+				Proof: new(big.Int).Set(params.Proof),
+			}
+			tamper(p)
+			if err := p.Verify(); err == nil {
+				t.Fatalf("successfully verified invalid parameters: %s", name)
+			}
+		})
+	}
+}
+
+func TestSetupInvalidInputs(t *testing.T) {
+	if _, err := GenerateModulus(rand.Reader, MinModulusBits-1); err == nil {
+		t.Fatal("successfully generated a small modulus")
+	}
+	n, err := GenerateModulus(rand.Reader, MinModulusBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.BitLen() != MinModulusBits {
+		t.Fatalf("expected a modulus of %d bits, got %d", MinModulusBits, n.BitLen())
+	}
+	if _, err := Setup(n, 0); err == nil {
+		t.Fatal("successfully set up puzzles with no squarings")
+	}
+	if _, err := Setup(new(big.Int).Lsh(n, 1), testSquarings); err == nil {
+		t.Fatal("successfully set up puzzles with an even modulus")
+	}
+}
+
+func TestCalibrate(t *testing.T) {
+	n, err := GenerateModulus(rand.Reader, MinModulusBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	short, err := Calibrate(n, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	long, err := Calibrate(n, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if short == 0 || long <= short {
+		t.Fatalf("expected more squarings for a longer delay, got %d and %d", short, long)
+	}
+}
+
+// generateParams sets up puzzles of testSquarings squarings modulo a fresh
+// modulus of MinModulusBits bits.
+func generateParams(t testing.TB) *Params {
+	t.Helper()
+	n, err := GenerateModulus(rand.Reader, MinModulusBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, err := Setup(n, testSquarings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return params
+}
+
+// multiply returns abs(x*y mod n).
+func multiply(x, y, n *big.Int) *big.Int {
+	z := new(big.Int).Mul(x, y)
+	return abs(z.Mod(z, n), n)
+}
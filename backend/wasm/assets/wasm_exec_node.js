@@ -34,7 +34,9 @@ function loadEVotingBackend() {
 						typeof (goNewKeyPairWithProof) !== "function" ||
 						typeof (goEncryptVoteWithProof) !== "function" ||
 						typeof (goDecryptTallyWithProof) !== "function" ||
-						typeof (goAddEncryptedVotes) !== "function"
+						typeof (goAddEncryptedVotes) !== "function" ||
+						typeof (goEncodeCastVoteParams) !== "function" ||
+						typeof (goDecodeCastVoteParams) !== "function"
 					) {
 						return;
 					}
@@ -1,14 +1,83 @@
 package main
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"net/http"
+	"os"
+
+	"github.com/HorizenLabs/e-voting-poc/backend/arith"
+	"github.com/HorizenLabs/e-voting-poc/backend/bulletin"
+	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
 )
 
+// electionKey is the content of the file passed with flag -pk.
+type electionKey struct {
+	Pk    arith.CurvePoint        `json:"pk"`
+	Proof crypto.ProofSkKnowledge `json:"proof"`
+}
+
 func main() {
-	err := http.ListenAndServe(":9090", http.FileServer(http.Dir("../../assets")))
+	addr := flag.String("addr", ":9090", "address to listen on")
+	assets := flag.String("assets", "../../assets", "directory of the static assets")
+	pkFile := flag.String("pk", "", "JSON file containing the election public key and the proof of knowledge of its secret key; if set, the bulletin board is served under /board/")
+	signingKeyFile := flag.String("signing-key", "", "file containing the hex-encoded ed25519 seed used to sign tree heads; if not set, a fresh key is generated")
+	flag.Parse()
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir(*assets)))
+	if *pkFile != "" {
+		board, err := newBoard(*pkFile, *signingKeyFile)
+		if err != nil {
+			fmt.Println("Failed to start bulletin board", err)
+			return
+		}
+		fmt.Println("Bulletin board tree heads are signed with key", hex.EncodeToString(board.SignerPublicKey()))
+		mux.Handle("/board/", http.StripPrefix("/board", bulletin.NewHandler(board)))
+	}
+
+	err := http.ListenAndServe(*addr, mux)
 	if err != nil {
 		fmt.Println("Failed to start server", err)
 		return
 	}
 }
+
+func newBoard(pkFile, signingKeyFile string) (*bulletin.Board, error) {
+	data, err := os.ReadFile(pkFile)
+	if err != nil {
+		return nil, err
+	}
+	key := new(electionKey)
+	if err := json.Unmarshal(data, key); err != nil {
+		return nil, err
+	}
+
+	var signer ed25519.PrivateKey
+	if signingKeyFile == "" {
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		data, err := os.ReadFile(signingKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		seed, err := hex.DecodeString(string(bytes.TrimSpace(data)))
+		if err != nil {
+			return nil, err
+		}
+		if len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("signing key seed should be %d bytes long", ed25519.SeedSize)
+		}
+		signer = ed25519.NewKeyFromSeed(seed)
+	}
+
+	return bulletin.NewBoard(&key.Pk, &key.Proof, signer)
+}
@@ -34,6 +34,14 @@ func jsValueScalar(s *arith.Scalar) (js.Value, error) {
 	return js.ValueOf(string(sJSON)), nil
 }
 
+func jsValueSecretScalar(s *arith.SecretScalar) (js.Value, error) {
+	sJSON, err := json.Marshal(s)
+	if err != nil {
+		return js.Null(), err
+	}
+	return js.ValueOf(string(sJSON)), nil
+}
+
 func jsValueChallenge(c *arith.Challenge) (js.Value, error) {
 	cJSON, err := json.Marshal(c)
 	if err != nil {
@@ -47,7 +55,7 @@ func jsValueKeyPair(keyPair *crypto.KeyPair) (js.Value, error) {
 	if err != nil {
 		return js.Null(), err
 	}
-	sk, err := jsValueScalar(&keyPair.Sk)
+	sk, err := jsValueSecretScalar(&keyPair.Sk)
 	if err != nil {
 		return js.Null(), err
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/HorizenLabs/e-voting-poc/backend/arith"
 	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 func goNumber(v js.Value) (int64, error) {
@@ -48,6 +49,20 @@ func goScalar(v js.Value) (*arith.Scalar, error) {
 	return res, nil
 }
 
+func goSecretScalar(v js.Value) (*arith.SecretScalar, error) {
+	if err := isType(v, js.TypeString); err != nil {
+		return nil, err
+	}
+
+	sJSON := v.String()
+	res := new(arith.SecretScalar)
+	err := json.Unmarshal([]byte(sJSON), res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func goEncryptedVote(v js.Value) (*crypto.EncryptedVote, error) {
 	keys := []string{"a", "b"}
 	types := []js.Type{js.TypeObject, js.TypeObject}
@@ -81,13 +96,68 @@ func goKeyPair(v js.Value) (*crypto.KeyPair, error) {
 	if err != nil {
 		return nil, NewFieldParsingError("pk", err)
 	}
-	sk, err := goScalar(v.Get("sk"))
+	sk, err := goSecretScalar(v.Get("sk"))
 	if err != nil {
 		return nil, NewFieldParsingError("sk", err)
 	}
 
+	defer sk.Destroy()
+
 	res := new(crypto.KeyPair)
 	res.Pk.Set(pk)
 	res.Sk.Set(sk)
 	return res, nil
 }
+
+func goChallenge(v js.Value) (*arith.Challenge, error) {
+	if err := isType(v, js.TypeString); err != nil {
+		return nil, err
+	}
+
+	cJSON := v.String()
+	res := new(arith.Challenge)
+	err := json.Unmarshal([]byte(cJSON), res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func goProofVoteWellFormedness(v js.Value) (*crypto.ProofVoteWellFormedness, error) {
+	keys := []string{"r0", "r1", "c0", "c1"}
+	types := []js.Type{js.TypeString, js.TypeString, js.TypeString, js.TypeString}
+	if err := isObject(v, keys, types); err != nil {
+		return nil, err
+	}
+
+	r0, err := goScalar(v.Get("r0"))
+	if err != nil {
+		return nil, NewFieldParsingError("r0", err)
+	}
+	r1, err := goScalar(v.Get("r1"))
+	if err != nil {
+		return nil, NewFieldParsingError("r1", err)
+	}
+	c0, err := goChallenge(v.Get("c0"))
+	if err != nil {
+		return nil, NewFieldParsingError("c0", err)
+	}
+	c1, err := goChallenge(v.Get("c1"))
+	if err != nil {
+		return nil, NewFieldParsingError("c1", err)
+	}
+
+	res := new(crypto.ProofVoteWellFormedness)
+	res.R0.Set(r0)
+	res.R1.Set(r1)
+	res.C0.Set(c0)
+	res.C1.Set(c1)
+	return res, nil
+}
+
+func goHexBytes(v js.Value) ([]byte, error) {
+	if err := isType(v, js.TypeString); err != nil {
+		return nil, err
+	}
+	return hexutil.Decode(v.String())
+}
@@ -6,6 +6,7 @@ import (
 	"syscall/js"
 
 	"github.com/HorizenLabs/e-voting-poc/backend/crypto"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 func main() {
@@ -13,6 +14,8 @@ func main() {
 	js.Global().Set("goEncryptVoteWithProof", promiseWrapper(encryptVoteWithProof))
 	js.Global().Set("goDecryptTallyWithProof", promiseWrapper(decryptTallyWithProof))
 	js.Global().Set("goAddEncryptedVotes", promiseWrapper(addEncryptedVotes))
+	js.Global().Set("goEncodeCastVoteParams", promiseWrapper(encodeCastVoteParams))
+	js.Global().Set("goDecodeCastVoteParams", promiseWrapper(decodeCastVoteParams))
 	<-make(chan bool)
 }
 
@@ -25,6 +28,7 @@ func newKeyPairWithProof(this js.Value, args []js.Value) (js.Value, error) {
 	if err != nil {
 		return js.Null(), err
 	}
+	defer keyPair.Destroy()
 
 	jsKeyPair, err := jsValueKeyPair(keyPair)
 	if err != nil {
@@ -43,8 +47,11 @@ func newKeyPairWithProof(this js.Value, args []js.Value) (js.Value, error) {
 	return js.ValueOf(result), nil
 }
 
+// encryptVoteWithProof encrypts a vote under pk, hedging the randomness
+// with a voter secret derived from voterKey, hex-encoded secret key
+// material of the voter (see crypto.NewVoterSecret).
 func encryptVoteWithProof(this js.Value, args []js.Value) (js.Value, error) {
-	if err := checkArgsNum(args, 2); err != nil {
+	if err := checkArgsNum(args, 3); err != nil {
 		return js.Null(), err
 	}
 	vote, err := goNumber(args[0])
@@ -55,8 +62,17 @@ func encryptVoteWithProof(this js.Value, args []js.Value) (js.Value, error) {
 	if err != nil {
 		return js.Null(), NewArgParsingError(1, err)
 	}
+	voterKey, err := goHexBytes(args[2])
+	if err != nil {
+		return js.Null(), NewArgParsingError(2, err)
+	}
+	voterSecret, err := crypto.NewVoterSecret(voterKey)
+	if err != nil {
+		return js.Null(), NewArgParsingError(2, err)
+	}
+	defer voterSecret.Destroy()
 
-	encryptedVote, proof, err := crypto.EncryptVoteWithProof(rand.Reader, vote, pk)
+	encryptedVote, proof, err := crypto.EncryptVoteWithProof(rand.Reader, vote, voterSecret, pk)
 	if err != nil {
 		return js.Null(), err
 	}
@@ -93,6 +109,7 @@ func decryptTallyWithProof(this js.Value, args []js.Value) (js.Value, error) {
 	if err != nil {
 		return js.Null(), NewArgParsingError(2, err)
 	}
+	defer keyPair.Destroy()
 
 	jsDecryptedTally, proof, err := crypto.DecryptTallyWithProof(rand.Reader, tally, n, keyPair)
 	if err != nil {
@@ -134,6 +151,54 @@ func addEncryptedVotes(this js.Value, args []js.Value) (js.Value, error) {
 	return jsVote, nil
 }
 
+func encodeCastVoteParams(this js.Value, args []js.Value) (js.Value, error) {
+	if err := checkArgsNum(args, 2); err != nil {
+		return js.Null(), err
+	}
+	encryptedVote, err := goEncryptedVote(args[0])
+	if err != nil {
+		return js.Null(), NewArgParsingError(0, err)
+	}
+	proof, err := goProofVoteWellFormedness(args[1])
+	if err != nil {
+		return js.Null(), NewArgParsingError(1, err)
+	}
+
+	params := crypto.EncodeCastVoteParams(encryptedVote, proof)
+
+	return js.ValueOf(hexutil.Encode(params)), nil
+}
+
+func decodeCastVoteParams(this js.Value, args []js.Value) (js.Value, error) {
+	if err := checkArgsNum(args, 1); err != nil {
+		return js.Null(), err
+	}
+	params, err := goHexBytes(args[0])
+	if err != nil {
+		return js.Null(), NewArgParsingError(0, err)
+	}
+
+	encryptedVote, proof, err := crypto.DecodeCastVoteParams(params)
+	if err != nil {
+		return js.Null(), err
+	}
+
+	jsEncryptedVote, err := jsValueEncryptedVote(encryptedVote)
+	if err != nil {
+		return js.Null(), err
+	}
+	jsProof, err := jsValueProofVoteWellFormedness(proof)
+	if err != nil {
+		return js.Null(), err
+	}
+
+	result := jsObject{
+		"encryptedVote": jsEncryptedVote,
+		"proof":         jsProof,
+	}
+	return js.ValueOf(result), nil
+}
+
 func checkArgsNum(args []js.Value, num int) error {
 	if len(args) != num {
 		return fmt.Errorf("function takes %d arguments", num)
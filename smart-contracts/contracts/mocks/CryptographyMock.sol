@@ -0,0 +1,49 @@
+//SPDX-License-Identifier: Apache-2.0
+
+pragma solidity ^0.8.9;
+
+import "../cryptography/BN256Group.sol";
+import "../cryptography/Cryptography.sol";
+
+/// @dev Exposes the verifiers and the homomorphic operations of
+/// Cryptography, so that they can be tested against the conformance vectors
+/// generated by the Go backend (see backend/conformance).
+contract CryptographyMock is BN256Group, Cryptography {
+    function verifySkKnowledge(
+        ProofSkKnowledge calldata proof,
+        GroupElement calldata pk
+    ) external view returns (bool) {
+        return _verifySkKnowledge(proof, pk);
+    }
+
+    function verifyVoteWellFormedness(
+        ProofVoteWellFormedness calldata proof,
+        EncryptedVote calldata vote,
+        GroupElement calldata pk
+    ) external view returns (bool) {
+        return _verifyVoteWellFormedness(proof, vote, pk);
+    }
+
+    function verifyCorrectDecryption(
+        ProofCorrectDecryption calldata proof,
+        EncryptedVote calldata encryptedVote,
+        uint decryptedVote,
+        GroupElement calldata pk
+    ) external view returns (bool) {
+        return _verifyCorrectDecryption(proof, encryptedVote, decryptedVote, pk);
+    }
+
+    function addVotes(
+        EncryptedVote calldata vote0,
+        EncryptedVote calldata vote1
+    ) external view returns (EncryptedVote memory) {
+        return _addVotes(vote0, vote1);
+    }
+
+    function scaleVote(
+        EncryptedVote calldata vote,
+        Scalar s
+    ) external view returns (EncryptedVote memory) {
+        return _scaleVote(vote, s);
+    }
+}
@@ -0,0 +1,141 @@
+const { expect } = require("chai");
+const { loadFixture } = require("@nomicfoundation/hardhat-network-helpers");
+const vectors = require("../../../backend/conformance/testdata/vectors.json");
+
+// ABI types of the structs of Cryptography.sol
+const GROUP_ELEMENT = "tuple(uint256 x, uint256 y)";
+const ENCRYPTED_VOTE = `tuple(${GROUP_ELEMENT} a, ${GROUP_ELEMENT} b)`;
+const PROOF_SK_KNOWLEDGE = "tuple(uint256 s, uint128 c)";
+const PROOF_CORRECT_DECRYPTION = "tuple(uint256 s, uint128 c)";
+const PROOF_VOTE_WELL_FORMEDNESS = "tuple(uint256 r0, uint256 r1, uint128 c0, uint128 c1)";
+
+const abiCoder = ethers.utils.defaultAbiCoder;
+
+function decode(type, data) {
+    return abiCoder.decode([type], data)[0];
+}
+
+// jsonNumbers returns the numbers of a JSON encoding of the Go backend, in
+// order, as strings: they do not fit in javascript numbers, but the fields
+// of the JSON encodings are in the same order as the ABI encodings.
+function jsonNumbers(json) {
+    return [...json.matchAll(/:(\d+)/g)].map(match => match[1]);
+}
+
+// checkEncoding checks that the ABI encoding of a vector is exactly the one
+// produced by ethers, and that its JSON encoding holds the same values.
+function checkEncoding(type, encoding, values) {
+    const decoded = decode(type, encoding.abi);
+    expect(abiCoder.encode([type], [decoded])).to.equal(encoding.abi);
+    expect(jsonNumbers(encoding.json)).to.deep.equal(values(decoded).map(v => v.toString()));
+}
+
+const groupElementValues = p => [p.x, p.y];
+const encryptedVoteValues = e => [e.a.x, e.a.y, e.b.x, e.b.y];
+const proofValues = p => [...p];
+
+// rejects returns whether a verifier returns false or reverts
+async function rejects(verify) {
+    try {
+        return !(await verify());
+    } catch (e) {
+        return true;
+    }
+}
+
+describe("Cryptography conformance vectors", function () {
+    async function deployedFixture() {
+        const CryptographyMock = await ethers.getContractFactory("CryptographyMock");
+        const mock = await CryptographyMock.deploy();
+        await mock.deployed();
+        return { mock };
+    }
+
+    it("Should have a supported version", function () {
+        expect(vectors.version).to.equal(2);
+    });
+
+    describe("keys", function () {
+        vectors.keys.forEach((key, i) => {
+            it(`Should verify the proof of knowledge of sk ${i}`, async function () {
+                const { mock } = await loadFixture(deployedFixture);
+                checkEncoding(GROUP_ELEMENT, key.pk, groupElementValues);
+                checkEncoding(PROOF_SK_KNOWLEDGE, key.proof, proofValues);
+                const pk = decode(GROUP_ELEMENT, key.pk.abi);
+                const proof = decode(PROOF_SK_KNOWLEDGE, key.proof.abi);
+                expect(await mock.verifySkKnowledge(proof, pk)).to.be.true;
+            });
+        });
+    });
+
+    describe("ballots", function () {
+        vectors.ballots.forEach((ballot, i) => {
+            it(`Should verify the proof of well-formedness of ballot ${i}`, async function () {
+                const { mock } = await loadFixture(deployedFixture);
+                checkEncoding(ENCRYPTED_VOTE, ballot.encryptedVote, encryptedVoteValues);
+                checkEncoding(PROOF_VOTE_WELL_FORMEDNESS, ballot.proof, proofValues);
+                const pk = decode(GROUP_ELEMENT, vectors.keys[ballot.key].pk.abi);
+                const vote = decode(ENCRYPTED_VOTE, ballot.encryptedVote.abi);
+                const proof = decode(PROOF_VOTE_WELL_FORMEDNESS, ballot.proof.abi);
+                expect(abiCoder.encode([ENCRYPTED_VOTE, PROOF_VOTE_WELL_FORMEDNESS], [vote, proof]))
+                    .to.equal(ballot.castVoteParams);
+                expect(await mock.verifyVoteWellFormedness(proof, vote, pk)).to.be.true;
+            });
+        });
+    });
+
+    describe("tallies", function () {
+        vectors.tallies.forEach((tally, i) => {
+            it(`Should add the ballots and verify the decryption of tally ${i}`, async function () {
+                const { mock } = await loadFixture(deployedFixture);
+                checkEncoding(ENCRYPTED_VOTE, tally.encryptedTally, encryptedVoteValues);
+                checkEncoding(PROOF_CORRECT_DECRYPTION, tally.proof, proofValues);
+                let sum;
+                for (let j = 0; j < tally.ballots.length; j++) {
+                    const vote = decode(ENCRYPTED_VOTE, vectors.ballots[tally.ballots[j]].encryptedVote.abi);
+                    const scaled = await mock.scaleVote(vote, tally.weights[j]);
+                    sum = (j == 0) ? scaled : await mock.addVotes(sum, scaled);
+                }
+                const encryptedTally = decode(ENCRYPTED_VOTE, tally.encryptedTally.abi);
+                expect(encryptedVoteValues(sum).map(String)).to.deep.equal(encryptedVoteValues(encryptedTally).map(String));
+
+                const pk = decode(GROUP_ELEMENT, vectors.keys[tally.key].pk.abi);
+                const proof = decode(PROOF_CORRECT_DECRYPTION, tally.proof.abi);
+                expect(await mock.verifyCorrectDecryption(proof, encryptedTally, tally.result, pk)).to.be.true;
+            });
+        });
+    });
+
+    describe("invalid", function () {
+        vectors.invalid.forEach(c => {
+            it(`Should reject ${c.kind} proof: ${c.description}`, async function () {
+                const { mock } = await loadFixture(deployedFixture);
+                const pk = decode(GROUP_ELEMENT, c.pk);
+                let verify;
+                switch (c.kind) {
+                    case "skKnowledge":
+                        verify = () => mock.verifySkKnowledge(decode(PROOF_SK_KNOWLEDGE, c.proof), pk);
+                        break;
+                    case "voteWellFormedness":
+                        verify = () => mock.verifyVoteWellFormedness(
+                            decode(PROOF_VOTE_WELL_FORMEDNESS, c.proof),
+                            decode(ENCRYPTED_VOTE, c.encryptedVote),
+                            pk
+                        );
+                        break;
+                    case "correctDecryption":
+                        verify = () => mock.verifyCorrectDecryption(
+                            decode(PROOF_CORRECT_DECRYPTION, c.proof),
+                            decode(ENCRYPTED_VOTE, c.encryptedVote),
+                            c.result || 0,
+                            pk
+                        );
+                        break;
+                    default:
+                        expect.fail(`unknown kind ${c.kind}`);
+                }
+                expect(await rejects(verify)).to.be.true;
+            });
+        });
+    });
+});
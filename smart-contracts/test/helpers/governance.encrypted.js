@@ -1,5 +1,6 @@
 const { GovernorHelper } = require('../../lib/openzeppelin-contracts/test/helpers/governance.js');
 const { forward } = require('../../lib/openzeppelin-contracts/test/helpers/time.js');
+const { randomBytes } = require('crypto');
 
 function concatOpts(args, opts = null) {
     return opts ? args.concat(opts) : args;
@@ -9,6 +10,12 @@ function stringifyPk(pk) {
     return "x:" + pk.x + ",y:" + pk.y;
 }
 
+// newVoterKey returns the key material of a fresh voter, hedging the
+// randomness of their encryptions
+function newVoterKey() {
+    return "0x" + randomBytes(32).toString("hex");
+}
+
 VoteType = {
     Against: 0,
     For: 1,
@@ -32,10 +39,10 @@ class GovernorEncryptedHelper extends GovernorHelper {
         var encryptedVote, proof;
         if (vote.vote == VoteType.Against || vote.vote == VoteType.For) {
             const pk = vote.pk ? vote.pk : await this.governor.getPk(proposal.id);
-            ({ encryptedVote, proof } = await goEncryptVoteWithProof(vote.vote, pk));
+            ({ encryptedVote, proof } = await goEncryptVoteWithProof(vote.vote, pk, newVoterKey()));
         } else {
             const { keyPair } = await goNewKeyPairWithProof();
-            ({ encryptedVote, proof } = await goEncryptVoteWithProof(VoteType.Against, keyPair.pk));
+            ({ encryptedVote, proof } = await goEncryptVoteWithProof(VoteType.Against, keyPair.pk, newVoterKey()));
         }
         return this.governor.castEncryptedVote(...concatOpts([proposal.id, encryptedVote, proof], opts));
     }
@@ -65,7 +72,7 @@ class GovernorEncryptedHelper extends GovernorHelper {
         var result, proof;
         if (args.fake) {
             const { keyPair } = await goNewKeyPairWithProof();
-            const { encryptedVote } = await goEncryptVoteWithProof(VoteType.Against, keyPair.pk);
+            const { encryptedVote } = await goEncryptVoteWithProof(VoteType.Against, keyPair.pk, newVoterKey());
             ({ result, proof } = await goDecryptTallyWithProof(encryptedVote, 1, keyPair));
         } else {
             const encryptedTally = await this.governor.getTally(proposal.id);
@@ -1,6 +1,7 @@
 const { expect } = require("chai");
 const { loadFixture } = require("@nomicfoundation/hardhat-network-helpers");
 const { loadEVotingBackend } = require("../../../backend/wasm/assets/wasm_exec_node")
+const { randomBytes } = require("crypto");
 
 const Status = {
     INIT: 0,
@@ -28,7 +29,7 @@ describe("Voting contract", function () {
         data.ProofsVoteWellFormednessValid = [];
         const votes = [1, 0, 1, 1, 0];
         for (let i = 0; i < votes.length; i++) {
-            const { encryptedVote: encrypedVote, proof: proof } = await goEncryptVoteWithProof(votes[i], KeyPairA.pk);
+            const { encryptedVote: encrypedVote, proof: proof } = await goEncryptVoteWithProof(votes[i], KeyPairA.pk, "0x" + randomBytes(32).toString("hex"));
             if (i == 0) {
                 encryptedTally = encrypedVote;
             } else {
@@ -43,7 +44,7 @@ describe("Voting contract", function () {
         data.ProofsVoteWellFormednessInvalid = [];
         const votesInvalid = [1, 0];
         for (let i = 0; i < votesInvalid.length; i++) {
-            const { encryptedVote: encrypedVote, proof: proof } = await goEncryptVoteWithProof(votes[i], KeyPairB.pk);
+            const { encryptedVote: encrypedVote, proof: proof } = await goEncryptVoteWithProof(votes[i], KeyPairB.pk, "0x" + randomBytes(32).toString("hex"));
             if (i == 0) {
                 encryptedTallyInvalid = encrypedVote;
             } else {